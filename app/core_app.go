@@ -27,6 +27,8 @@ type core struct {
 	BookDiskStore    filestore.DiskStore
 	BookBlobStore    filestore.BlobStore
 	BookDataScrapper scrapper.BookDataScrapper
+	BookPageParser   scrapper.BookPageParser
+	BookURLScrapper  scrapper.BookDataScrapper
 	Logger           *log.Logger
 }
 
@@ -45,13 +47,81 @@ func NewCore(config config.AppConfig, bookDBStore book.Store, blobStore filestor
 // PrepareBook scrapes and parse a book page, downloads the book cover image.
 // If there are book files - compress and put them into temporary folder,
 // otherwise - copies the book file name to clipboard, and skips the compression.
-func (c *core) PrepareBook(bookIDString string) (*book.ParsedData, *book.StoredData, *filestore.TempFilesData) {
-	var existingData *book.StoredData
-
+// The context limits the book page scrapping and the existing book search.
+// The scrapping errors (a blocked or missing book page, a timeout) are returned, so the lookup could be repeated.
+func (c *core) PrepareBook(ctx context.Context,
+	bookIDString string) (*book.ParsedData, *book.StoredData, *filestore.TempFilesData, error) {
 	// -------------------- Parse book page --------------------
-	parsedData, err := c.BookDataScrapper.GetBookData(bookIDString)
+	parsedData, err := c.BookDataScrapper.GetBookData(ctx, bookIDString)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("can not scrape the book data for %q: %w", bookIDString, err)
+	}
+	preparedData, existingData, tempFilesData := c.prepareParsedBook(ctx, parsedData)
+
+	return preparedData, existingData, tempFilesData, nil
+}
+
+// ImportBookPage parses a saved book product page file, and prepares the book the same way as PrepareBook.
+// Returns the names of the book data fields, which could not be extracted from the page.
+func (c *core) ImportBookPage(ctx context.Context, pagePath string) (*book.ParsedData, *book.StoredData,
+	*filestore.TempFilesData, []string, error) {
+	if c.BookPageParser == nil {
+		return nil, nil, nil, nil, fmt.Errorf("the book page import is not configured")
+	}
+
+	// -------------------- Parse book page file --------------------
+	parsedData, err := c.BookPageParser.ParseBookPageFile(pagePath)
 	if err != nil {
-		c.Logger.Fatalf("Can not scrape a book metadata: %v", err)
+		return nil, nil, nil, nil, fmt.Errorf("can not parse the book page file %q: %w", pagePath, err)
+	}
+	preparedData, existingData, tempFilesData, missingFields := c.prepareImportedBook(ctx, pagePath, parsedData)
+
+	return preparedData, existingData, tempFilesData, missingFields, nil
+}
+
+// ImportBookURL scrapes the schema.org/OpenGraph structured data of a book product page URL (like a publisher site),
+// and prepares the book the same way as PrepareBook.
+// Returns the names of the book data fields, which could not be extracted from the page.
+func (c *core) ImportBookURL(ctx context.Context, pageURL string) (*book.ParsedData, *book.StoredData,
+	*filestore.TempFilesData, []string, error) {
+	if c.BookURLScrapper == nil {
+		return nil, nil, nil, nil, fmt.Errorf("the book URL import is not configured")
+	}
+
+	// -------------------- Scrape book page URL --------------------
+	parsedData, err := c.BookURLScrapper.GetBookData(ctx, pageURL)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("can not get the book data from the page %q: %w", pageURL, err)
+	}
+	preparedData, existingData, tempFilesData, missingFields := c.prepareImportedBook(ctx, pageURL, parsedData)
+
+	return preparedData, existingData, tempFilesData, missingFields, nil
+}
+
+// prepareImportedBook reports the fields not extracted from the imported page, and prepares the book.
+func (c *core) prepareImportedBook(ctx context.Context, page string, parsedData book.ParsedData) (*book.ParsedData,
+	*book.StoredData, *filestore.TempFilesData, []string) {
+	missingFields := scrapper.MissingFields(parsedData)
+	if len(missingFields) > 0 {
+		c.Logger.Printf("[WARN] - Fields not extracted from the book page %q: %s",
+			page, strings.Join(missingFields, ", "))
+	}
+	preparedData, existingData, tempFilesData := c.prepareParsedBook(ctx, parsedData)
+
+	return preparedData, existingData, tempFilesData, missingFields
+}
+
+func (c *core) prepareParsedBook(ctx context.Context,
+	parsedData book.ParsedData) (*book.ParsedData, *book.StoredData, *filestore.TempFilesData) {
+	var existingData *book.StoredData
+	parsedData.BookFileName = parsedData.GetBookFileNameWithRules(c.getFileNameRules())
+
+	// -------------------- Report the book data diagnostics --------------------
+	if !parsedData.Diagnostics.IsEmpty() {
+		c.Logger.Printf("[INFO] - Book data diagnostics:\n%s", parsedData.Diagnostics)
+	}
+	if missingRequired := parsedData.Diagnostics.MissingRequired; len(missingRequired) > 0 {
+		c.Logger.Printf("[WARN] - Required book data fields are missing: %s", strings.Join(missingRequired, ", "))
 	}
 
 	// -------------------- Check if there are book files --------------------
@@ -62,7 +132,6 @@ func (c *core) PrepareBook(bookIDString string) (*book.ParsedData, *book.StoredD
 
 	if c.Config.DBAvailable {
 		// -------------------- Search for existing book --------------------
-		ctx := context.Background()
 		existingData, err = c.findExistingBook(ctx, parsedData)
 		if err != nil {
 			c.Logger.Fatalf("Failed to find a book: %v", err)
@@ -90,6 +159,16 @@ func (c *core) PrepareBook(bookIDString string) (*book.ParsedData, *book.StoredD
 	return &parsedData, existingData, &tempFilesData
 }
 
+// SearchBooks finds book candidates by a free text (a title and/or author names), using the configured sources.
+func (c *core) SearchBooks(ctx context.Context, query string) ([]scrapper.SearchCandidate, error) {
+	searcher, ok := c.BookDataScrapper.(scrapper.BookSearcher)
+	if !ok {
+		return nil, scrapper.ErrSearchNotSupported
+	}
+
+	return searcher.SearchBooks(ctx, query, scrapper.DefaultSearchLimit)
+}
+
 // StoreBook inserts a new book record to database (or updates an existing one if any).
 // Moves book archive and book cover to output folder. Stores book archive and book cover to BLOB store.
 func (c *core) StoreBook(parsedData *book.ParsedData, existingData *book.StoredData, tempData *filestore.TempFilesData) {
@@ -142,7 +221,7 @@ func (c *core) getBookID() string {
 func (c *core) findExistingBook(ctx context.Context, parsedData book.ParsedData) (*book.StoredData, error) {
 	existingData, err := c.BookDBStore.Find(ctx, book.SearchRequest{
 		Title:     parsedData.Title,
-		Edition:   parsedData.Edition,
+		Edition:   parsedData.Edition.Number,
 		ISBN10:    parsedData.ISBN10,
 		ISBN13:    parsedData.ISBN13,
 		ASIN:      parsedData.ASIN,
@@ -155,6 +234,15 @@ func (c *core) findExistingBook(ctx context.Context, parsedData book.ParsedData)
 	return existingData, nil
 }
 
+// getFileNameRules returns the configured rules of the edition part of the book file name.
+func (c *core) getFileNameRules() book.FileNameRules {
+	return book.FileNameRules{
+		MinEdition:       c.Config.BookFileNameMinEdition,
+		EditionQualifier: c.Config.BookFileNameEditionQualifier,
+		EditionYear:      c.Config.BookFileNameEditionYear,
+	}
+}
+
 func (c *core) copyToClipboard(str string) {
 	err := clipboard.WriteAll(str)
 	if err != nil {
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/sdreger/lib-file-processor-go/config"
@@ -20,6 +22,7 @@ func TestCore_PrepareBook(t *testing.T) {
 	t.Run("Book does not exist in the DB, and book files present", testWithoutBookAndWithFiles)
 	t.Run("Book exists in the DB, and book files absent", testWithBookAndWithoutFiles)
 	t.Run("Book does not exist in the DB, and book files absent", testWithoutBookAndWithoutFiles)
+	t.Run("Book data can not be scrapped", testWithScrapperError)
 	t.Logf("\t%s\tShould successfully prepare book files", succeed)
 }
 func testWithBookAndWithFiles(t *testing.T) {
@@ -35,15 +38,16 @@ func testWithBookAndWithFiles(t *testing.T) {
 
 	mockBookDataScrapper := scrapper.NewMockBookDataScrapper(ctrl)
 	testParsedData := getTestParsedData()
-	mockBookDataScrapper.EXPECT().GetBookData(testBookID).Return(testParsedData, nil).Times(1)
+	mockBookDataScrapper.EXPECT().GetBookData(gomock.Any(), testBookID).Return(testParsedData, nil).Times(1)
 
 	mockBookDBStore := book.NewMockStore(ctrl)
 	searchRequest := book.SearchRequest{
-		Title:   testParsedData.Title,
-		Edition: testParsedData.Edition,
-		ISBN10:  testParsedData.ISBN10,
-		ISBN13:  testParsedData.ISBN13,
-		ASIN:    testParsedData.ASIN,
+		Title:     testParsedData.Title,
+		Edition:   testParsedData.Edition.Number,
+		ISBN10:    testParsedData.ISBN10,
+		ISBN13:    testParsedData.ISBN13,
+		ASIN:      testParsedData.ASIN,
+		Publisher: testParsedData.Publisher,
 	}
 	testStoredData := getTestStoredData()
 	// book found
@@ -58,7 +62,10 @@ func testWithBookAndWithFiles(t *testing.T) {
 
 	coreApp := NewCore(appConfig, mockBookDBStore, mockBlobStore, mockDiskStore, mockBookDataScrapper, log.Default())
 
-	updatedParsedData, storedData, tempFilesData := coreApp.PrepareBook(testBookID)
+	updatedParsedData, storedData, tempFilesData, err := coreApp.PrepareBook(context.Background(), testBookID)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould prepare the book without an error: %v", failed, err)
+	}
 
 	if updatedParsedData.BookFileSize != testBookFileSize {
 		t.Fatalf("\t\t%s\tShould get %d book file size: %d", failed, testBookFileSize, updatedParsedData.BookFileSize)
@@ -89,15 +96,16 @@ func testWithoutBookAndWithFiles(t *testing.T) {
 
 	mockBookDataScrapper := scrapper.NewMockBookDataScrapper(ctrl)
 	testParsedData := getTestParsedData()
-	mockBookDataScrapper.EXPECT().GetBookData(testBookID).Return(testParsedData, nil).Times(1)
+	mockBookDataScrapper.EXPECT().GetBookData(gomock.Any(), testBookID).Return(testParsedData, nil).Times(1)
 
 	mockBookDBStore := book.NewMockStore(ctrl)
 	searchRequest := book.SearchRequest{
-		Title:   testParsedData.Title,
-		Edition: testParsedData.Edition,
-		ISBN10:  testParsedData.ISBN10,
-		ISBN13:  testParsedData.ISBN13,
-		ASIN:    testParsedData.ASIN,
+		Title:     testParsedData.Title,
+		Edition:   testParsedData.Edition.Number,
+		ISBN10:    testParsedData.ISBN10,
+		ISBN13:    testParsedData.ISBN13,
+		ASIN:      testParsedData.ASIN,
+		Publisher: testParsedData.Publisher,
 	}
 
 	// book not found
@@ -112,7 +120,10 @@ func testWithoutBookAndWithFiles(t *testing.T) {
 
 	coreApp := NewCore(appConfig, mockBookDBStore, mockBlobStore, mockDiskStore, mockBookDataScrapper, log.Default())
 
-	updatedParsedData, storedData, tempFilesData := coreApp.PrepareBook(testBookID)
+	updatedParsedData, storedData, tempFilesData, err := coreApp.PrepareBook(context.Background(), testBookID)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould prepare the book without an error: %v", failed, err)
+	}
 
 	if updatedParsedData.BookFileSize != testBookFileSize {
 		t.Fatalf("\t\t%s\tShould get %d book file size: %d", failed, testBookFileSize, updatedParsedData.BookFileSize)
@@ -143,15 +154,16 @@ func testWithBookAndWithoutFiles(t *testing.T) {
 
 	mockBookDataScrapper := scrapper.NewMockBookDataScrapper(ctrl)
 	testParsedData := getTestParsedData()
-	mockBookDataScrapper.EXPECT().GetBookData(testBookID).Return(testParsedData, nil).Times(1)
+	mockBookDataScrapper.EXPECT().GetBookData(gomock.Any(), testBookID).Return(testParsedData, nil).Times(1)
 
 	mockBookDBStore := book.NewMockStore(ctrl)
 	searchRequest := book.SearchRequest{
-		Title:   testParsedData.Title,
-		Edition: testParsedData.Edition,
-		ISBN10:  testParsedData.ISBN10,
-		ISBN13:  testParsedData.ISBN13,
-		ASIN:    testParsedData.ASIN,
+		Title:     testParsedData.Title,
+		Edition:   testParsedData.Edition.Number,
+		ISBN10:    testParsedData.ISBN10,
+		ISBN13:    testParsedData.ISBN13,
+		ASIN:      testParsedData.ASIN,
+		Publisher: testParsedData.Publisher,
 	}
 	testStoredData := getTestStoredData()
 	// book found
@@ -163,7 +175,10 @@ func testWithBookAndWithoutFiles(t *testing.T) {
 
 	coreApp := NewCore(appConfig, mockBookDBStore, mockBlobStore, mockDiskStore, mockBookDataScrapper, log.Default())
 
-	updatedParsedData, storedData, tempFilesData := coreApp.PrepareBook(testBookID)
+	updatedParsedData, storedData, tempFilesData, err := coreApp.PrepareBook(context.Background(), testBookID)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould prepare the book without an error: %v", failed, err)
+	}
 
 	if updatedParsedData.BookFileSize != 0 {
 		t.Fatalf("\t\t%s\tShould get 0 book file size: %d", failed, updatedParsedData.BookFileSize)
@@ -194,15 +209,16 @@ func testWithoutBookAndWithoutFiles(t *testing.T) {
 
 	mockBookDataScrapper := scrapper.NewMockBookDataScrapper(ctrl)
 	testParsedData := getTestParsedData()
-	mockBookDataScrapper.EXPECT().GetBookData(testBookID).Return(testParsedData, nil).Times(1)
+	mockBookDataScrapper.EXPECT().GetBookData(gomock.Any(), testBookID).Return(testParsedData, nil).Times(1)
 
 	mockBookDBStore := book.NewMockStore(ctrl)
 	searchRequest := book.SearchRequest{
-		Title:   testParsedData.Title,
-		Edition: testParsedData.Edition,
-		ISBN10:  testParsedData.ISBN10,
-		ISBN13:  testParsedData.ISBN13,
-		ASIN:    testParsedData.ASIN,
+		Title:     testParsedData.Title,
+		Edition:   testParsedData.Edition.Number,
+		ISBN10:    testParsedData.ISBN10,
+		ISBN13:    testParsedData.ISBN13,
+		ASIN:      testParsedData.ASIN,
+		Publisher: testParsedData.Publisher,
 	}
 	// book not found
 	mockBookDBStore.EXPECT().Find(gomock.Any(), gomock.Eq(searchRequest)).Return(nil, nil).Times(1)
@@ -213,7 +229,10 @@ func testWithoutBookAndWithoutFiles(t *testing.T) {
 
 	coreApp := NewCore(appConfig, mockBookDBStore, mockBlobStore, mockDiskStore, mockBookDataScrapper, log.Default())
 
-	updatedParsedData, storedData, tempFilesData := coreApp.PrepareBook(testBookID)
+	updatedParsedData, storedData, tempFilesData, err := coreApp.PrepareBook(context.Background(), testBookID)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould prepare the book without an error: %v", failed, err)
+	}
 
 	if updatedParsedData.BookFileSize != 0 {
 		t.Fatalf("\t\t%s\tShould get 0 book file size: %d", failed, updatedParsedData.BookFileSize)
@@ -231,6 +250,32 @@ func testWithoutBookAndWithoutFiles(t *testing.T) {
 	}
 }
 
+func testWithScrapperError(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appConfig := config.GetAppConfig()
+
+	mockBookDataScrapper := scrapper.NewMockBookDataScrapper(ctrl)
+	mockBookDataScrapper.EXPECT().GetBookData(gomock.Any(), testBookID).Return(book.ParsedData{}, scrapper.ErrNotFound).Times(1)
+
+	// neither the DB nor the disk store is called
+	mockBookDBStore := book.NewMockStore(ctrl)
+	mockBlobStore := filestore.NewMockBlobStore(ctrl)
+	mockDiskStore := filestore.NewMockDiskStore(ctrl)
+
+	coreApp := NewCore(appConfig, mockBookDBStore, mockBlobStore, mockDiskStore, mockBookDataScrapper, log.Default())
+
+	updatedParsedData, storedData, tempFilesData, err := coreApp.PrepareBook(context.Background(), testBookID)
+	if !errors.Is(err, scrapper.ErrNotFound) {
+		t.Fatalf("\t\t%s\tShould get the %v error: %v", failed, scrapper.ErrNotFound, err)
+	}
+	if updatedParsedData != nil || storedData != nil || tempFilesData != nil {
+		t.Fatalf("\t\t%s\tShould get no book data", failed)
+	}
+}
+
 func TestCore_StoreBook(t *testing.T) {
 	t.Log("Given the need to test book files store.")
 	t.Run("There is an existing book data", testWithExistingData)
@@ -313,3 +358,124 @@ func testWithoutExistingData(t *testing.T) {
 	coreApp := NewCore(appConfig, mockBookDBStore, mockBlobStore, mockDiskStore, nil, log.Default())
 	coreApp.StoreBook(&testParsedData, nil, &testTempFilesData)
 }
+
+func TestCore_ImportBookPage(t *testing.T) {
+	t.Log("Given the need to test saved book page importing.")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appConfig := config.GetAppConfig()
+	appConfig.DBAvailable = true
+	appConfig.BlobStoreAvailable = true
+
+	testPagePath := "in_page/book.html"
+	testParsedData := getTestParsedData()
+	testParsedData.Subtitle = ""
+	mockBookPageParser := scrapper.NewMockBookPageParser(ctrl)
+	mockBookPageParser.EXPECT().ParseBookPageFile(testPagePath).Return(testParsedData, nil).Times(1)
+
+	mockBookDBStore := book.NewMockStore(ctrl)
+	mockBookDBStore.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+	mockDiskStore := filestore.NewMockDiskStore(ctrl)
+	mockDiskStore.EXPECT().IsFolderEmpty(appConfig.BookInputFolder).Return(false, nil).Times(1)
+	testTempFilesData := getTestTempFilesData()
+	mockDiskStore.EXPECT().PrepareBookFiles(testParsedData, appConfig.BookInputFolder, appConfig.TempInputFolder).
+		Return(testTempFilesData, nil).Times(1)
+
+	coreApp := NewCore(appConfig, mockBookDBStore, filestore.NewMockBlobStore(ctrl), mockDiskStore,
+		scrapper.NewMockBookDataScrapper(ctrl), log.Default())
+	coreApp.BookPageParser = mockBookPageParser
+
+	parsedData, _, tempFilesData, missingFields, err :=
+		coreApp.ImportBookPage(context.Background(), testPagePath)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to import the book page: %v", failed, err)
+	}
+	if parsedData.Title != testParsedData.Title || tempFilesData == nil {
+		t.Fatalf("\t\t%s\tShould prepare the imported book: %+v", failed, parsedData)
+	}
+	if !reflect.DeepEqual(missingFields, []string{scrapper.FieldSubtitle}) {
+		t.Fatalf("\t\t%s\tShould report the missing subtitle field, but got: %v", failed, missingFields)
+	}
+	t.Logf("\t\t%s\tShould import the book page, and report the missing fields.", succeed)
+
+	t.Logf("\t\tWhen the book page can not be parsed\n")
+	{
+		mockBookPageParser.EXPECT().ParseBookPageFile(testPagePath).
+			Return(book.ParsedData{}, scrapper.ErrBlocked).Times(1)
+		if _, _, _, _, err := coreApp.ImportBookPage(context.Background(), testPagePath); err == nil {
+			t.Fatalf("\t\t%s\tShould get an error for the unparsable book page", failed)
+		}
+		t.Logf("\t\t%s\tShould get an error for the unparsable book page.", succeed)
+	}
+}
+
+func TestCore_ImportBookURL(t *testing.T) {
+	t.Log("Given the need to test book product page URL importing.")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	appConfig := config.GetAppConfig()
+	appConfig.DBAvailable = true
+	appConfig.BlobStoreAvailable = true
+
+	testPageURL := "https://publisher.test/books/test-title"
+	testParsedData := getTestParsedData()
+	testParsedData.Subtitle = ""
+	mockBookURLScrapper := scrapper.NewMockBookDataScrapper(ctrl)
+	mockBookURLScrapper.EXPECT().GetBookData(gomock.Any(), testPageURL).Return(testParsedData, nil).Times(1)
+
+	mockBookDBStore := book.NewMockStore(ctrl)
+	mockBookDBStore.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+	mockDiskStore := filestore.NewMockDiskStore(ctrl)
+	mockDiskStore.EXPECT().IsFolderEmpty(appConfig.BookInputFolder).Return(false, nil).Times(1)
+	testTempFilesData := getTestTempFilesData()
+	mockDiskStore.EXPECT().PrepareBookFiles(testParsedData, appConfig.BookInputFolder, appConfig.TempInputFolder).
+		Return(testTempFilesData, nil).Times(1)
+
+	coreApp := NewCore(appConfig, mockBookDBStore, filestore.NewMockBlobStore(ctrl), mockDiskStore,
+		scrapper.NewMockBookDataScrapper(ctrl), log.Default())
+
+	t.Logf("\t\tWhen the book URL import is not configured\n")
+	{
+		if _, _, _, _, err := coreApp.ImportBookURL(context.Background(), testPageURL); err == nil {
+			t.Fatalf("\t\t%s\tShould get an error for the missing URL scrapper", failed)
+		}
+		t.Logf("\t\t%s\tShould get an error for the missing URL scrapper.", succeed)
+	}
+
+	coreApp.BookURLScrapper = mockBookURLScrapper
+	parsedData, _, tempFilesData, missingFields, err := coreApp.ImportBookURL(context.Background(), testPageURL)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to import the book page URL: %v", failed, err)
+	}
+	if parsedData.Title != testParsedData.Title || tempFilesData == nil {
+		t.Fatalf("\t\t%s\tShould prepare the imported book: %+v", failed, parsedData)
+	}
+	if !reflect.DeepEqual(missingFields, []string{scrapper.FieldSubtitle}) {
+		t.Fatalf("\t\t%s\tShould report the missing subtitle field, but got: %v", failed, missingFields)
+	}
+	t.Logf("\t\t%s\tShould import the book page URL, and report the missing fields.", succeed)
+}
+
+func TestCore_SearchBooksDefaultSources(t *testing.T) {
+	t.Log("Given the need to test the free text book search with the default sources.")
+	appConfig := config.GetAppConfig()
+	bookDataScrapper, err := newBookDataScrapper(appConfig, log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create the default book data sources: %v", failed, err)
+	}
+	coreApp := NewCore(appConfig, nil, nil, nil, bookDataScrapper, log.Default())
+
+	// The search is cancelled before any request is sent, only the searchable source presence is checked
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = coreApp.SearchBooks(ctx, "test title")
+	if errors.Is(err, scrapper.ErrSearchNotSupported) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("\t\t%s\tShould have a searchable source in the %v default sources: %v", failed,
+			appConfig.ScrapperSources, err)
+	}
+	t.Logf("\t\t%s\tShould be able to search with the default sources.", succeed)
+}
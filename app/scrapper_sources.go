@@ -0,0 +1,111 @@
+package app
+
+import (
+	"fmt"
+	"github.com/sdreger/lib-file-processor-go/config"
+	"github.com/sdreger/lib-file-processor-go/parser"
+	"github.com/sdreger/lib-file-processor-go/scrapper"
+	"log"
+)
+
+// newBookDataScrapper creates a scrapper registry with all book data sources enabled in the config,
+// and applies the configured field precedence.
+func newBookDataScrapper(appConfig config.AppConfig, logger *log.Logger) (scrapper.BookDataScrapper, error) {
+	registry := scrapper.NewRegistry(logger)
+	for _, sourceName := range appConfig.ScrapperSources {
+		source, err := newBookDataSource(sourceName, appConfig, logger)
+		if err != nil {
+			return nil, err
+		}
+		registry.Register(sourceName, source)
+	}
+	for field, sourceNames := range appConfig.ScrapperFieldPrecedence {
+		registry.SetFieldPrecedence(field, sourceNames...)
+	}
+
+	return registry, nil
+}
+
+func newBookDataSource(sourceName string, appConfig config.AppConfig,
+	logger *log.Logger) (scrapper.BookDataScrapper, error) {
+	switch sourceName {
+	case scrapper.AmazonSourceName:
+		return newAmazonScrapper(appConfig, logger)
+	case scrapper.OpenLibrarySourceName:
+		return scrapper.NewOpenLibraryScrapper("", "", logger), nil
+	case scrapper.GoogleBooksSourceName:
+		return scrapper.NewGoogleBooksScrapper("", appConfig.GoogleBooksAPIKey, logger), nil
+	case scrapper.CrossrefSourceName:
+		return scrapper.NewCrossrefScrapper("", logger), nil
+	default:
+		return nil, fmt.Errorf("unknown book data source: %q", sourceName)
+	}
+}
+
+// newAmazonScrapper creates an Amazon scrapper for the configured storefronts, with the configured retry policy,
+// request delay and identities (user agents and proxies). The selector set is loaded from a file, if configured.
+// The page snapshot cache is enabled, if the cache folder is configured. The author pages are visited, if enabled.
+func newAmazonScrapper(appConfig config.AppConfig, logger *log.Logger) (*scrapper.AmazonScrapper, error) {
+	amazonScrapper, err := scrapper.NewAmazonStorefrontScrapper(appConfig.AmazonStorefronts, logger)
+	if err != nil {
+		return nil, err
+	}
+	amazonScrapper.SetRetryPolicy(appConfig.ScrapperMaxRetries, appConfig.ScrapperRetryDelay)
+	amazonScrapper.SetMinRequestDelay(appConfig.ScrapperMinRequestDelay)
+	amazonScrapper.SetFetchAuthorPages(appConfig.ScrapperAuthorPages)
+	if err := amazonScrapper.SetIdentities(appConfig.ScrapperUserAgents, appConfig.ScrapperProxies,
+		appConfig.ScrapperCookieDir); err != nil {
+		return nil, err
+	}
+	amazonScrapper.SetProxyBackoff(appConfig.ScrapperProxyBackoff)
+	if appConfig.ScrapperSelectorsFile != "" {
+		selectors, err := getSelectorSet(appConfig)
+		if err != nil {
+			return nil, err
+		}
+		amazonScrapper.SetSelectorSet(selectors)
+	}
+	if appConfig.ScrapperCacheDir == "" {
+		return amazonScrapper, nil
+	}
+
+	snapshotCache, err := scrapper.NewSnapshotCache(appConfig.ScrapperCacheDir, appConfig.ScrapperCacheTTL, logger)
+	if err != nil {
+		return nil, err
+	}
+	amazonScrapper.EnableSnapshotCache(snapshotCache, appConfig.ScrapperOffline)
+
+	return amazonScrapper, nil
+}
+
+// newBookPageParser creates a parser for the saved Amazon product pages, using the configured selector set.
+func newBookPageParser(appConfig config.AppConfig, logger *log.Logger) (scrapper.BookPageParser, error) {
+	selectors, err := getSelectorSet(appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return scrapper.NewAmazonPageParser(appConfig.AmazonStorefronts, selectors, logger)
+}
+
+// getSelectorSet loads the configured selector set file, or returns the built-in selector set.
+func getSelectorSet(appConfig config.AppConfig) (scrapper.SelectorSet, error) {
+	if appConfig.ScrapperSelectorsFile == "" {
+		return scrapper.DefaultSelectorSet(), nil
+	}
+
+	return scrapper.LoadSelectorSet(appConfig.ScrapperSelectorsFile)
+}
+
+// loadPublisherRules adds the rules from the configured publisher rules file to the built-in publisher string rules.
+func loadPublisherRules(appConfig config.AppConfig) error {
+	if appConfig.ParserPublisherRulesFile == "" {
+		return nil
+	}
+	rules, err := parser.LoadPublisherRules(appConfig.ParserPublisherRulesFile)
+	if err != nil {
+		return err
+	}
+
+	return parser.ExtendPublisherRules(rules)
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"github.com/sdreger/lib-file-processor-go/domain/book"
 	"github.com/sdreger/lib-file-processor-go/filestore"
+	"github.com/sdreger/lib-file-processor-go/parser"
 	"time"
 )
 
@@ -57,9 +58,9 @@ func getTestParsedData() book.ParsedData {
 		Language:      testBookLanguage,
 		Publisher:     testBookPublisher,
 		PublisherURL:  testBookPublisherURL,
-		Edition:       testBookEdition,
+		Edition:       parser.Edition{Number: testBookEdition},
 		PubDate:       testPublishDate,
-		Authors:       []string{testBookAuthorName},
+		Contributors:  book.NewAuthors([]string{testBookAuthorName}),
 		Categories:    []string{testBookCategoryName},
 		Tags:          []string{testBookTagName},
 		Formats:       nil,
@@ -83,14 +84,14 @@ func getTestStoredData() book.StoredData {
 		Language:      testBookLanguage,
 		Publisher:     testBookPublisher,
 		PublisherURL:  testBookPublisherURL,
-		Edition:       testBookEdition,
+		Edition:       parser.Edition{Number: testBookEdition},
 		PubDate:       testPublishDate,
 		BookFileName:  testBookFileName,
 		BookFileSize:  testBookFileSize,
 		CoverFileName: testBookCoverFileName,
 		CreatedAt:     testCreateDate,
 		UpdatedAt:     testCreateDate,
-		Authors:       []string{testBookAuthorName},
+		Contributors:  book.NewAuthors([]string{testBookAuthorName}),
 		Categories:    []string{testBookCategoryName},
 		Tags:          []string{testBookTagName},
 		Formats:       []string{testBookFileTypeName},
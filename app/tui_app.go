@@ -1,7 +1,9 @@
 package app
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -12,8 +14,11 @@ import (
 	"github.com/sdreger/lib-file-processor-go/domain/filetype"
 	"github.com/sdreger/lib-file-processor-go/domain/lang"
 	"github.com/sdreger/lib-file-processor-go/domain/publisher"
+	"github.com/sdreger/lib-file-processor-go/domain/series"
 	"github.com/sdreger/lib-file-processor-go/domain/tag"
 	"github.com/sdreger/lib-file-processor-go/filestore"
+	"github.com/sdreger/lib-file-processor-go/isbn"
+	"github.com/sdreger/lib-file-processor-go/parser"
 	"github.com/sdreger/lib-file-processor-go/scrapper"
 	"log"
 	"strconv"
@@ -22,7 +27,18 @@ import (
 )
 
 const (
-	dateLayout = "_2 Jan 2006"
+	dateLayout      = "_2 Jan 2006"
+	monthDateLayout = "Jan 2006"
+	yearDateLayout  = "2006"
+
+	prepareBookTimeout = 5 * time.Minute
+	searchBooksTimeout = 1 * time.Minute
+
+	minSearchQueryLength = 3
+
+	mainPageName        = "main"
+	searchPageName      = "search"
+	diagnosticsPageName = "diagnostics"
 )
 
 type TuiApp struct {
@@ -30,6 +46,7 @@ type TuiApp struct {
 	bookIDChan <-chan string
 
 	tuiApp        *tview.Application
+	pages         *tview.Pages
 	grid          *tview.Grid
 	bookIDInput   *tview.InputField
 	parsedForm    *tview.Form
@@ -52,7 +69,14 @@ func NewTuiApp(config config.AppConfig, db *sql.DB, blobStore filestore.BlobStor
 	downloadService := filestore.NewDownloadService(logger)
 	diskStoreService := filestore.NewDiskStoreService(compressionService, downloadService, logger)
 
-	bookDataScrapper, err := scrapper.NewAmazonScrapper("", logger)
+	if err := loadPublisherRules(config); err != nil {
+		return nil, err
+	}
+	bookDataScrapper, err := newBookDataScrapper(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	bookPageParser, err := newBookPageParser(config, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -64,13 +88,19 @@ func NewTuiApp(config config.AppConfig, db *sql.DB, blobStore filestore.BlobStor
 	tagStore := tag.NewPostgresStore(db, logger)
 	publisherStore := publisher.NewPostgresStore(db, logger)
 	languageStore := lang.NewPostgresStore(db, logger)
-	bookDBStore := book.
-		NewPostgresStore(db, publisherStore, languageStore, authorStore, categoryStore, fileTypeStore, tagStore, logger)
+	seriesStore := series.NewPostgresStore(db, logger)
+	bookDBStore := book.NewPostgresStore(db, publisherStore, languageStore, authorStore, categoryStore, fileTypeStore,
+		tagStore, seriesStore, logger)
+
+	coreApp := NewCore(config, bookDBStore, blobStore, diskStoreService, bookDataScrapper, logger)
+	coreApp.BookPageParser = bookPageParser
+	coreApp.BookURLScrapper = scrapper.NewStructuredDataScrapper(logger)
 
 	return &TuiApp{
-		core:          NewCore(config, bookDBStore, blobStore, diskStoreService, bookDataScrapper, logger),
+		core:          coreApp,
 		bookIDChan:    bookIDChan,
 		tuiApp:        tview.NewApplication(),
+		pages:         tview.NewPages(),
 		grid:          tview.NewGrid(),
 		bookIDInput:   tview.NewInputField(),
 		parsedForm:    tview.NewForm().SetItemPadding(0).SetFieldBackgroundColor(tcell.ColorBlack),
@@ -84,7 +114,8 @@ func NewTuiApp(config config.AppConfig, db *sql.DB, blobStore filestore.BlobStor
 func (t *TuiApp) Run() error {
 	t.initBookIDInput(t.bookIDInput)
 	t.initGrid(t.grid)
-	if err := t.tuiApp.SetRoot(t.grid, true).SetFocus(t.bookIDInput).Run(); err != nil {
+	t.pages.AddPage(mainPageName, t.grid, true, true)
+	if err := t.tuiApp.SetRoot(t.pages, true).SetFocus(t.bookIDInput).Run(); err != nil {
 		return err
 	}
 
@@ -92,8 +123,8 @@ func (t *TuiApp) Run() error {
 }
 
 func (t *TuiApp) initBookIDInput(input *tview.InputField) {
-	input.SetLabel("Enter ISBN10/ASIN: ").
-		SetFieldWidth(13).
+	input.SetLabel("Enter ISBN/ASIN, page file path or URL, or search by title/author: ").
+		SetFieldWidth(60).
 		SetChangedFunc(func(text string) {
 			t.bookIDString = text
 		}).
@@ -102,27 +133,163 @@ func (t *TuiApp) initBookIDInput(input *tview.InputField) {
 	go func() {
 		for bookID := range t.bookIDChan {
 			t.bookIDInput.SetText(bookID)
-			t.appendFooterText(fmt.Sprintf("A new file extracted with ID: %s", bookID))
+			if filestore.IsBookPageFile(bookID) {
+				t.appendFooterText(fmt.Sprintf("A new book page file: %s", bookID))
+			} else {
+				t.appendFooterText(fmt.Sprintf("A new file extracted with ID: %s", bookID))
+			}
 			t.tuiApp.Draw()
 		}
 	}()
 }
 
+// bookIDInputHandler prepares the book, if the input is a book ID (an ISBN10, ISBN13 or ASIN, possibly hyphenated
+// or spaced), imports the book page, if the input is a saved page file path or a product page URL, otherwise
+// searches books by the input text. Invalid ISBNs are reported, instead of being searched for.
 func (t *TuiApp) bookIDInputHandler(key tcell.Key) {
 	if key != tcell.KeyEnter {
 		return
 	}
-	if len(t.bookIDString) != 10 {
-		t.appendFooterText(fmt.Sprintf("The book ID must be of size 10: %q", t.bookIDString))
+	input := strings.TrimSpace(t.bookIDString)
+	if filestore.IsBookPageFile(input) {
+		t.importBookPage(input)
+		return
+	}
+	if scrapper.IsProductURL(input) {
+		t.importBookURL(input)
+		return
+	}
+	if isBookIDString(input) {
+		bookID, kind, err := isbn.ParseBookID(input)
+		if err != nil {
+			t.appendFooterText(fmt.Sprintf("The book ID is not valid: %v", err))
+			return
+		}
+		t.Logger.Printf("[INFO] - Preparing the book by %s: %q", kind, bookID)
+		t.prepareBook(bookID)
+		return
+	}
+	if len(input) < minSearchQueryLength {
+		t.appendFooterText(fmt.Sprintf("The search query must be at least %d characters long: %q",
+			minSearchQueryLength, t.bookIDString))
+		return
+	}
+	t.searchBooks(input)
+}
+
+// searchBooks shows the book candidates picker, the chosen candidate ID is passed to the book preparing flow.
+func (t *TuiApp) searchBooks(query string) {
+	ctx, cancel := context.WithTimeout(context.Background(), searchBooksTimeout)
+	defer cancel()
+	candidates, err := t.SearchBooks(ctx, query)
+	if errors.Is(err, scrapper.ErrSearchNotSupported) {
+		t.appendFooterText(fmt.Sprintf("The input is not a valid book ID, and the book search is not available "+
+			"for the %q sources: add 'openlibrary' or 'googlebooks' to the %s environment variable",
+			strings.Join(t.Config.ScrapperSources, ","), config.EnvVarScrapperSources))
+		return
+	}
+	if err != nil {
+		t.Logger.Printf("[ERROR] - Can not search books: %v", err)
+		t.appendFooterText(fmt.Sprintf("Can not search books: %v", err))
+		return
+	}
+	if len(candidates) == 0 {
+		t.appendFooterText(fmt.Sprintf("No books found for: %q", query))
 		return
 	}
-	parsedData, existingData, tempFilesData := t.PrepareBook(t.bookIDString)
+
+	list := tview.NewList().SetSecondaryTextColor(tcell.ColorGray)
+	for _, candidate := range candidates {
+		bookID := candidate.BookID
+		list.AddItem(getCandidateMainText(candidate), getCandidateSecondaryText(candidate), 0, func() {
+			t.pages.RemovePage(searchPageName)
+			t.bookIDInput.SetText(bookID)
+			t.prepareBook(bookID)
+		})
+	}
+	list.SetDoneFunc(func() {
+		t.pages.RemovePage(searchPageName)
+		t.tuiApp.SetFocus(t.bookIDInput)
+	})
+	list.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Search results: %q (Enter - select, Esc - cancel) ", query)).
+		SetTitleColor(tcell.ColorYellow)
+
+	modal := tview.NewGrid().
+		SetColumns(0, 120, 0).
+		SetRows(0, 2*len(candidates)+2, 0).
+		AddItem(list, 1, 1, 1, 1, 0, 0, true)
+	t.pages.AddPage(searchPageName, modal, true, true)
+	t.tuiApp.SetFocus(list)
+}
+
+func (t *TuiApp) prepareBook(bookID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), prepareBookTimeout)
+	defer cancel()
+	parsedData, existingData, tempFilesData, err := t.PrepareBook(ctx, bookID)
+	if err != nil {
+		t.Logger.Printf("[ERROR] - %v", err)
+		t.appendFooterText(fmt.Sprintf("Can not get the book data: %v%s", err, getScrapeErrorHint(err)))
+		return
+	}
+	t.showPreparedBook(parsedData, existingData, tempFilesData)
+}
+
+// getScrapeErrorHint returns a hint for the book data scrapping error, which could be handled by the user.
+func getScrapeErrorHint(err error) string {
+	switch {
+	case errors.Is(err, scrapper.ErrBlocked), errors.Is(err, scrapper.ErrRateLimited):
+		return "\r\nThe book data source limits the requests, try again later, or import a saved book page."
+	case errors.Is(err, scrapper.ErrNotFound):
+		return "\r\nThe book is not found, check the book ID."
+	case errors.Is(err, context.DeadlineExceeded):
+		return "\r\nThe book data source does not respond, try again later."
+	default:
+		return ""
+	}
+}
+
+// importBookPage prepares the book from a saved page file, and reports the fields not extracted from the page.
+func (t *TuiApp) importBookPage(pagePath string) {
+	ctx, cancel := context.WithTimeout(context.Background(), prepareBookTimeout)
+	defer cancel()
+	parsedData, existingData, tempFilesData, missingFields, err := t.ImportBookPage(ctx, pagePath)
+	if err != nil {
+		t.Logger.Printf("[ERROR] - %v", err)
+		t.appendFooterText(fmt.Sprintf("Can not import the book page: %v", err))
+		return
+	}
+	t.showPreparedBook(parsedData, existingData, tempFilesData)
+	if len(missingFields) > 0 {
+		t.appendFooterText(fmt.Sprintf("\r\nFields not extracted from the page: %s", strings.Join(missingFields, ", ")))
+	}
+}
+
+// importBookURL prepares the book from the product page structured data, and reports the fields not found there.
+func (t *TuiApp) importBookURL(pageURL string) {
+	ctx, cancel := context.WithTimeout(context.Background(), prepareBookTimeout)
+	defer cancel()
+	parsedData, existingData, tempFilesData, missingFields, err := t.ImportBookURL(ctx, pageURL)
+	if err != nil {
+		t.Logger.Printf("[ERROR] - %v", err)
+		t.appendFooterText(fmt.Sprintf("Can not import the book page URL: %v", err))
+		return
+	}
+	t.showPreparedBook(parsedData, existingData, tempFilesData)
+	if len(missingFields) > 0 {
+		t.appendFooterText(fmt.Sprintf("\r\nFields not found on the page: %s", strings.Join(missingFields, ", ")))
+	}
+}
+
+func (t *TuiApp) showPreparedBook(parsedData *book.ParsedData, existingData *book.StoredData,
+	tempFilesData *filestore.TempFilesData) {
 	t.parsedData = parsedData
 	t.existingData = existingData
 	t.tempFilesData = tempFilesData
 
 	t.clearForms()
 	t.fillParsedForm(t.parsedForm, parsedData)
+	flagDiagnosticFields(t.parsedForm, parsedData.Diagnostics)
 	if existingData != nil {
 		t.fillCheckboxTable(t.equalityTable, parsedData, existingData)
 		t.fillExisingTable(t.existingTable, parsedData, existingData)
@@ -134,6 +301,32 @@ func (t *TuiApp) bookIDInputHandler(key tcell.Key) {
 			parsedData.GetBookFileNameWithoutExtension())).SetTextColor(tcell.ColorOrange)
 		t.restartFlow(false)
 	}
+	if missingRequired := parsedData.Diagnostics.MissingRequired; len(missingRequired) > 0 {
+		t.appendFooterText(fmt.Sprintf("\r\nRequired fields are missing: %s", strings.Join(missingRequired, ", ")))
+	}
+	if lowConfidence := parsedData.Diagnostics.LowConfidenceFields(); len(lowConfidence) > 0 {
+		t.appendFooterText(fmt.Sprintf("\r\nFields to double-check: %s", strings.Join(lowConfidence, ", ")))
+	}
+}
+
+// showDiagnostics shows the book data diagnostics report: the source selector, the raw value and the parsing rule
+// of every field, and the warnings.
+func (t *TuiApp) showDiagnostics(diagnostics book.Diagnostics) {
+	report := tview.NewTextView().SetText(diagnostics.String()).SetScrollable(true)
+	report.SetDoneFunc(func(key tcell.Key) {
+		t.pages.RemovePage(diagnosticsPageName)
+		t.tuiApp.SetFocus(t.parsedForm)
+	})
+	report.SetBorder(true).
+		SetTitle(" Book data diagnostics (Esc - close) ").
+		SetTitleColor(tcell.ColorYellow)
+
+	modal := tview.NewGrid().
+		SetColumns(0, 120, 0).
+		SetRows(0, len(diagnostics.Fields)+len(diagnostics.Warnings)+len(diagnostics.MissingRequired)+2, 0).
+		AddItem(report, 1, 1, 1, 1, 0, 0, true)
+	t.pages.AddPage(diagnosticsPageName, modal, true, true)
+	t.tuiApp.SetFocus(report)
 }
 
 func (t *TuiApp) initGrid(grid *tview.Grid) {
@@ -179,34 +372,41 @@ func (t *TuiApp) fillParsedForm(form *tview.Form, parsedData *book.ParsedData) {
 
 	form.AddInputField("Title:", parsedData.Title, 0, nil, func(text string) {
 		parsedData.Title = text
-		bookFileNameInputField.SetText(t.parsedData.GetBookFileName())
+		bookFileNameInputField.SetText(t.parsedData.GetBookFileNameWithRules(t.core.getFileNameRules()))
 	})
 	form.AddInputField("Subtitle:", parsedData.Subtitle, 0, nil, func(text string) {
 		parsedData.Subtitle = text
 	})
 	form.AddInputField("Description:", parsedData.Description, 0, nil, func(text string) {
-		parsedData.Description = text
+		parsedData.SetDescription(text)
 	})
 	form.AddInputField("ISBN10:", parsedData.ISBN10, 0, nil, func(text string) {
-		parsedData.ISBN10 = text
-		bookFileNameInputField.SetText(t.parsedData.GetBookFileName())
+		isbn10 := isbn.Normalize(text)
+		if isbn10 != "" {
+			if err := isbn.ValidateISBN10(isbn10); err != nil {
+				t.editErrorMap["ISBN10"] = err
+				return
+			}
+		}
+		delete(t.editErrorMap, "ISBN10")
+		parsedData.ISBN10 = isbn10
+		bookFileNameInputField.SetText(t.parsedData.GetBookFileNameWithRules(t.core.getFileNameRules()))
 	})
 	form.AddInputField("ISBN13:", strconv.FormatInt(parsedData.ISBN13, 10), 0, nil, func(text string) {
-		if len(text) != 13 {
-			t.editErrorMap["ISBN13"] = fmt.Errorf("ISBN13 length is invalid: %d", len(text))
-			return
-		}
-		isbn13, convErr := strconv.Atoi(text)
-		if convErr != nil {
-			t.editErrorMap["ISBN13"] = convErr
+		isbn13, parseErr := isbn.ParseISBN13(text)
+		if parseErr != nil {
+			t.editErrorMap["ISBN13"] = parseErr
 			return
 		}
 		delete(t.editErrorMap, "ISBN13")
-		parsedData.ISBN13 = int64(isbn13)
+		parsedData.ISBN13 = isbn13
 	})
 	form.AddInputField("ASIN:", parsedData.ASIN, 0, nil, func(text string) {
-		parsedData.ASIN = text
-		bookFileNameInputField.SetText(t.parsedData.GetBookFileName())
+		parsedData.ASIN = isbn.Normalize(text)
+		bookFileNameInputField.SetText(t.parsedData.GetBookFileNameWithRules(t.core.getFileNameRules()))
+	})
+	form.AddInputField("DOI:", parsedData.DOI, 0, nil, func(text string) {
+		parsedData.DOI = text
 	})
 	form.AddInputField("Pages:", strconv.FormatUint(uint64(parsedData.Pages), 10), 0, nil, func(text string) {
 		pages, convErr := strconv.Atoi(text)
@@ -222,39 +422,54 @@ func (t *TuiApp) fillParsedForm(form *tview.Form, parsedData *book.ParsedData) {
 	})
 	form.AddInputField("Publisher:", parsedData.Publisher, 0, nil, func(text string) {
 		parsedData.Publisher = text
-		bookFileNameInputField.SetText(t.parsedData.GetBookFileName())
+		bookFileNameInputField.SetText(t.parsedData.GetBookFileNameWithRules(t.core.getFileNameRules()))
 	})
 	form.AddInputField("PublisherURL:", parsedData.PublisherURL, 0, nil, func(text string) {
 		parsedData.PublisherURL = text
 	})
-	form.AddInputField("Edition:", strconv.FormatUint(uint64(parsedData.Edition), 10), 0, nil, func(text string) {
-		edition, convErr := strconv.Atoi(text)
-		if convErr != nil {
-			t.editErrorMap["Edition"] = convErr
+	form.AddInputField("Edition:", parsedData.Edition.String(), 0, nil, func(text string) {
+		edition, editionErr := parseEdition(text)
+		if editionErr != nil {
+			t.editErrorMap["Edition"] = editionErr
 			return
 		}
 		delete(t.editErrorMap, "Edition")
-		parsedData.Edition = uint8(edition)
-		bookFileNameInputField.SetText(t.parsedData.GetBookFileName())
+		parsedData.Edition = edition
+		bookFileNameInputField.SetText(t.parsedData.GetBookFileNameWithRules(t.core.getFileNameRules()))
 	})
-	form.AddInputField("PubDate:", parsedData.PubDate.Format(dateLayout), 0, nil, func(text string) {
-		parsedDate, dateErr := time.Parse(dateLayout, text)
-		if dateErr != nil {
-			t.editErrorMap["PubDate"] = dateErr
-			return
-		}
-		delete(t.editErrorMap, "PubDate")
-		parsedData.PubDate = parsedDate
-		bookFileNameInputField.SetText(t.parsedData.GetBookFileName())
+	form.AddInputField("PubDate:", formatPubDate(parsedData.PubDate, parsedData.PubDatePrecision), 0, nil,
+		func(text string) {
+			parsedDate, precision, dateErr := parsePubDate(text)
+			if dateErr != nil {
+				t.editErrorMap["PubDate"] = dateErr
+				return
+			}
+			delete(t.editErrorMap, "PubDate")
+			parsedData.PubDate, parsedData.PubDatePrecision = parsedDate, precision
+			bookFileNameInputField.SetText(t.parsedData.GetBookFileNameWithRules(t.core.getFileNameRules()))
+		})
+	form.AddInputField("Series:", parsedData.Series, 0, nil, func(text string) {
+		parsedData.Series = strings.TrimSpace(text)
 	})
-	form.AddInputField("Authors:", strings.Join(parsedData.Authors, ";"), 0, nil, func(text string) {
+	form.AddInputField("SeriesVolume:", strconv.FormatUint(uint64(parsedData.SeriesVolume), 10), 0, nil,
+		func(text string) {
+			volume, convErr := strconv.ParseUint(text, 10, 16)
+			if convErr != nil {
+				t.editErrorMap["SeriesVolume"] = convErr
+				return
+			}
+			delete(t.editErrorMap, "SeriesVolume")
+			parsedData.SeriesVolume = uint16(volume)
+		})
+	// The contributor roles are edited as the name suffixes, like: 'Jane Doe (Editor)'
+	form.AddInputField("Authors:", strings.Join(parsedData.GetContributors(), ";"), 0, nil, func(text string) {
 		newValues := getNewSliceData(text)
 		if len(newValues) == 0 {
 			t.editErrorMap["Authors"] = fmt.Errorf("authors slice length is 0")
 			return
 		}
 		delete(t.editErrorMap, "Authors")
-		parsedData.Authors = newValues
+		parsedData.SetContributors(newValues)
 	})
 	form.AddInputField("Categories:", strings.Join(parsedData.Categories, ";"), 0, nil, func(text string) {
 		newValues := getNewSliceData(text)
@@ -314,22 +529,27 @@ func (t *TuiApp) fillParsedForm(form *tview.Form, parsedData *book.ParsedData) {
 			t.validateCategories(parsedData)
 			t.saveBook()
 		})
-		form.SetFocus(21) // Update Button
+		form.SetFocus(form.GetFormItemCount() + 1) // Update Button
 	} else {
-		form.SetFocus(20) // Add Button
+		form.SetFocus(form.GetFormItemCount()) // Add Button
 	}
 	form.AddButton("Quit", func() {
 		t.tuiApp.Stop()
 	})
+	if !parsedData.Diagnostics.IsEmpty() {
+		form.AddButton("Diagnostics", func() {
+			t.showDiagnostics(parsedData.Diagnostics)
+		})
+	}
 	form.SetButtonsAlign(tview.AlignCenter)
 
 	bookFileNameInputField = form.GetFormItemByLabel("BookFileName:").(*tview.InputField)
 }
 
 func (t *TuiApp) validateAuthors(parsedData *book.ParsedData) {
-	if strings.Contains(strings.Join(parsedData.Authors, ";"), "author") {
+	if strings.Contains(strings.Join(parsedData.GetAuthorNames(), ";"), "author") {
 		t.editErrorMap["AuthorName"] = fmt.Errorf("the 'author' word should not be present")
-	} else if isContainOneWordItem(parsedData.Authors) {
+	} else if isContainOneWordItem(parsedData.GetAuthorNames()) {
 		t.editErrorMap["AuthorName"] = fmt.Errorf("an author name should contain at least 2 words")
 	} else {
 		delete(t.editErrorMap, "AuthorName")
@@ -372,20 +592,24 @@ func (t *TuiApp) fillCheckboxTable(table *tview.Table, parsedData *book.ParsedDa
 	table.SetCell(4, 0, equalCell(parsedData.ISBN10, existingData.ISBN10))
 	table.SetCell(5, 0, equalCell(parsedData.ISBN13, existingData.ISBN13))
 	table.SetCell(6, 0, equalCell(parsedData.ASIN, existingData.ASIN))
-	table.SetCell(7, 0, equalCell(parsedData.Pages, existingData.Pages))
-	table.SetCell(8, 0, equalCell(parsedData.Language, existingData.Language))
-	table.SetCell(9, 0, equalCell(parsedData.Publisher, existingData.Publisher))
-	table.SetCell(10, 0, equalCell(parsedData.PublisherURL, existingData.PublisherURL))
-	table.SetCell(11, 0, equalCell(parsedData.Edition, existingData.Edition))
-	table.SetCell(12, 0, equalCell(parsedData.PubDate.Format(dateLayout), existingData.PubDate.Format(dateLayout)))
-	table.SetCell(13, 0, equalCell(strings.Join(parsedData.Authors, ";"), strings.Join(existingData.Authors, ";")))
-	table.SetCell(14, 0,
+	table.SetCell(7, 0, equalCell(parsedData.DOI, existingData.DOI))
+	table.SetCell(8, 0, equalCell(parsedData.Pages, existingData.Pages))
+	table.SetCell(9, 0, equalCell(parsedData.Language, existingData.Language))
+	table.SetCell(10, 0, equalCell(parsedData.Publisher, existingData.Publisher))
+	table.SetCell(11, 0, equalCell(parsedData.PublisherURL, existingData.PublisherURL))
+	table.SetCell(12, 0, equalCell(parsedData.Edition, existingData.Edition))
+	table.SetCell(13, 0, equalCell(parsedData.PubDate.Format(dateLayout), existingData.PubDate.Format(dateLayout)))
+	table.SetCell(14, 0, equalCell(parsedData.Series, existingData.Series))
+	table.SetCell(15, 0, equalCell(parsedData.SeriesVolume, existingData.SeriesVolume))
+	table.SetCell(16, 0,
+		equalCell(strings.Join(parsedData.GetContributors(), ";"), strings.Join(existingData.GetContributors(), ";")))
+	table.SetCell(17, 0,
 		equalCell(strings.Join(parsedData.Categories, ";"), strings.Join(existingData.Categories, ";")))
-	table.SetCell(15, 0, equalCell(strings.Join(parsedData.Tags, ";"), strings.Join(existingData.Tags, ";")))
-	table.SetCell(16, 0, equalCell(strings.Join(parsedData.Formats, ";"), strings.Join(existingData.Formats, ";")))
-	table.SetCell(17, 0, equalCell(parsedData.BookFileName, existingData.BookFileName))
-	table.SetCell(18, 0, equalCell(parsedData.BookFileSize, existingData.BookFileSize))
-	table.SetCell(19, 0, equalCell(parsedData.CoverFileName, existingData.CoverFileName))
+	table.SetCell(18, 0, equalCell(strings.Join(parsedData.Tags, ";"), strings.Join(existingData.Tags, ";")))
+	table.SetCell(19, 0, equalCell(strings.Join(parsedData.Formats, ";"), strings.Join(existingData.Formats, ";")))
+	table.SetCell(20, 0, equalCell(parsedData.BookFileName, existingData.BookFileName))
+	table.SetCell(21, 0, equalCell(parsedData.BookFileSize, existingData.BookFileSize))
+	table.SetCell(22, 0, equalCell(parsedData.CoverFileName, existingData.CoverFileName))
 }
 
 func (t *TuiApp) fillExisingTable(table *tview.Table, parsedData *book.ParsedData, existingData *book.StoredData) {
@@ -395,7 +619,7 @@ func (t *TuiApp) fillExisingTable(table *tview.Table, parsedData *book.ParsedDat
 	table.SetCell(2, 0, tview.NewTableCell(existingData.Subtitle).
 		SetTextColor(equalColor(parsedData.Subtitle, existingData.Subtitle)).
 		SetAlign(tview.AlignLeft))
-	table.SetCell(3, 0, tview.NewTableCell(existingData.Description).
+	table.SetCell(3, 0, tview.NewTableCell(existingData.GetDescriptionText()).
 		SetTextColor(equalColor(parsedData.Description, existingData.Description)).
 		SetAlign(tview.AlignLeft))
 	table.SetCell(4, 0, tview.NewTableCell(existingData.ISBN10).
@@ -407,51 +631,60 @@ func (t *TuiApp) fillExisingTable(table *tview.Table, parsedData *book.ParsedDat
 	table.SetCell(6, 0, tview.NewTableCell(existingData.ASIN).
 		SetTextColor(equalColor(parsedData.ASIN, existingData.ASIN)).
 		SetAlign(tview.AlignLeft))
-	table.SetCell(7, 0, tview.NewTableCell(strconv.FormatUint(uint64(existingData.Pages), 10)).
+	table.SetCell(7, 0, tview.NewTableCell(existingData.DOI).
+		SetTextColor(equalColor(parsedData.DOI, existingData.DOI)).
+		SetAlign(tview.AlignLeft))
+	table.SetCell(8, 0, tview.NewTableCell(strconv.FormatUint(uint64(existingData.Pages), 10)).
 		SetTextColor(equalColor(parsedData.Pages, existingData.Pages)).
 		SetAlign(tview.AlignLeft))
-	table.SetCell(8, 0, tview.NewTableCell(existingData.Language).
+	table.SetCell(9, 0, tview.NewTableCell(existingData.Language).
 		SetTextColor(equalColor(parsedData.Language, existingData.Language)).
 		SetAlign(tview.AlignLeft))
-	table.SetCell(9, 0, tview.NewTableCell(existingData.Publisher).
+	table.SetCell(10, 0, tview.NewTableCell(existingData.Publisher).
 		SetTextColor(equalColor(parsedData.Publisher, existingData.Publisher)).
 		SetAlign(tview.AlignLeft))
-	table.SetCell(10, 0, tview.NewTableCell(existingData.PublisherURL).
+	table.SetCell(11, 0, tview.NewTableCell(existingData.PublisherURL).
 		SetTextColor(equalColor(parsedData.PublisherURL, existingData.PublisherURL)).
 		SetAlign(tview.AlignLeft))
-	table.SetCell(11, 0, tview.NewTableCell(strconv.FormatUint(uint64(existingData.Edition), 10)).
+	table.SetCell(12, 0, tview.NewTableCell(existingData.Edition.String()).
 		SetTextColor(equalColor(parsedData.Edition, existingData.Edition)).
 		SetAlign(tview.AlignLeft))
-	table.SetCell(12, 0, tview.NewTableCell(existingData.PubDate.Format(dateLayout)).
+	table.SetCell(13, 0, tview.NewTableCell(existingData.PubDate.Format(dateLayout)).
 		SetTextColor(equalColor(parsedData.PubDate.Format(dateLayout), existingData.PubDate.Format(dateLayout))).
 		SetAlign(tview.AlignLeft))
-	existingAuthors := strings.Join(existingData.Authors, ";")
-	parsedAuthors := strings.Join(parsedData.Authors, ";")
-	table.SetCell(13, 0, tview.NewTableCell(existingAuthors).
+	table.SetCell(14, 0, tview.NewTableCell(existingData.Series).
+		SetTextColor(equalColor(parsedData.Series, existingData.Series)).
+		SetAlign(tview.AlignLeft))
+	table.SetCell(15, 0, tview.NewTableCell(strconv.FormatUint(uint64(existingData.SeriesVolume), 10)).
+		SetTextColor(equalColor(parsedData.SeriesVolume, existingData.SeriesVolume)).
+		SetAlign(tview.AlignLeft))
+	existingAuthors := strings.Join(existingData.GetContributors(), ";")
+	parsedAuthors := strings.Join(parsedData.GetContributors(), ";")
+	table.SetCell(16, 0, tview.NewTableCell(existingAuthors).
 		SetTextColor(equalColor(parsedAuthors, existingAuthors)).
 		SetAlign(tview.AlignLeft))
 	existingCategories := strings.Join(existingData.Categories, ";")
 	parsedCategories := strings.Join(parsedData.Categories, ";")
-	table.SetCell(14, 0, tview.NewTableCell(existingCategories).
+	table.SetCell(17, 0, tview.NewTableCell(existingCategories).
 		SetTextColor(equalColor(parsedCategories, existingCategories)).
 		SetAlign(tview.AlignLeft))
 	existingTags := strings.Join(existingData.Tags, ";")
 	parsedTags := strings.Join(parsedData.Tags, ";")
-	table.SetCell(15, 0, tview.NewTableCell(existingTags).
+	table.SetCell(18, 0, tview.NewTableCell(existingTags).
 		SetTextColor(equalColor(parsedTags, existingTags)).
 		SetAlign(tview.AlignLeft))
 	existingFormats := strings.Join(existingData.Formats, ";")
 	parsedFormats := strings.Join(parsedData.Formats, ";")
-	table.SetCell(16, 0, tview.NewTableCell(existingFormats).
+	table.SetCell(19, 0, tview.NewTableCell(existingFormats).
 		SetTextColor(equalColor(parsedFormats, existingFormats)).
 		SetAlign(tview.AlignLeft))
-	table.SetCell(17, 0, tview.NewTableCell(existingData.BookFileName).
+	table.SetCell(20, 0, tview.NewTableCell(existingData.BookFileName).
 		SetTextColor(equalColor(parsedData.BookFileName, existingData.BookFileName)).
 		SetAlign(tview.AlignLeft))
-	table.SetCell(18, 0, tview.NewTableCell(strconv.FormatInt(existingData.BookFileSize, 10)).
+	table.SetCell(21, 0, tview.NewTableCell(strconv.FormatInt(existingData.BookFileSize, 10)).
 		SetTextColor(equalColor(parsedData.BookFileSize, existingData.BookFileSize)).
 		SetAlign(tview.AlignLeft))
-	table.SetCell(19, 0, tview.NewTableCell(existingData.CoverFileName).
+	table.SetCell(22, 0, tview.NewTableCell(existingData.CoverFileName).
 		SetTextColor(equalColor(parsedData.CoverFileName, existingData.CoverFileName)).
 		SetAlign(tview.AlignLeft))
 }
@@ -485,6 +718,77 @@ func (t *TuiApp) clearForms() {
 	t.equalityTable.Clear()
 }
 
+// flagDiagnosticFields highlights the labels of the missing required fields, and the low confidence fields,
+// which should be double-checked.
+func flagDiagnosticFields(form *tview.Form, diagnostics book.Diagnostics) {
+	flag := func(fields []string, labelFormat string) {
+		for _, field := range fields {
+			if inputField, ok := form.GetFormItemByLabel(field + ":").(*tview.InputField); ok {
+				inputField.SetLabel(fmt.Sprintf(labelFormat, field))
+			}
+		}
+	}
+	flag(diagnostics.LowConfidenceFields(), "[orange]%s?:")
+	flag(diagnostics.MissingRequired, "[red]%s!:")
+}
+
+// formatPubDate formats the publication date according to its precision, like: ' 6 Apr 2022', 'Apr 2022' or '2022'.
+func formatPubDate(date time.Time, precision parser.DatePrecision) string {
+	switch precision {
+	case parser.DatePrecisionMonth:
+		return date.Format(monthDateLayout)
+	case parser.DatePrecisionYear:
+		return date.Format(yearDateLayout)
+	}
+
+	return date.Format(dateLayout)
+}
+
+// parsePubDate parses the edited publication date: a full one, or a partial one, like: 'Apr 2022' or '2022'.
+func parsePubDate(text string) (time.Time, parser.DatePrecision, error) {
+	if date, err := time.Parse(dateLayout, text); err == nil {
+		return date, parser.DatePrecisionDay, nil
+	}
+
+	return parser.ParsePartialDateString(text, parser.LanguageEnglish)
+}
+
+// parseEdition parses the edited edition, like: '3rd Revised Edition', '2023 Edition' or just '3rd'.
+// An empty text clears the edition.
+func parseEdition(text string) (parser.Edition, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return parser.Edition{}, nil
+	}
+	if !strings.HasSuffix(strings.ToLower(text), "edition") {
+		text += " Edition"
+	}
+
+	return parser.ParseEdition(text)
+}
+
+// isBookIDString returns true if the input is an ASIN, or looks like an ISBN (maybe a mistyped one).
+func isBookIDString(input string) bool {
+	return isbn.IsASIN(isbn.Normalize(input)) || isbn.LooksLikeISBN(input)
+}
+
+func getCandidateMainText(candidate scrapper.SearchCandidate) string {
+	if candidate.Year == 0 {
+		return candidate.Title
+	}
+
+	return fmt.Sprintf("%s (%d)", candidate.Title, candidate.Year)
+}
+
+func getCandidateSecondaryText(candidate scrapper.SearchCandidate) string {
+	parts := []string{candidate.BookID, strings.Join(candidate.Authors, ", ")}
+	if candidate.CoverURL != "" {
+		parts = append(parts, candidate.CoverURL)
+	}
+
+	return strings.Join(parts, " | ")
+}
+
 func getErrorText(errorMap map[string]error) string {
 	builder := strings.Builder{}
 	for key, val := range errorMap {
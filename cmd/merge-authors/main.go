@@ -0,0 +1,132 @@
+// Command merge-authors finds the duplicate authors, like: 'John Smith', 'Smith, John' and 'J. Smith', and merges them.
+// Without arguments, it lists the duplicate candidates. The '-into' flag merges the given duplicate author IDs into
+// the canonical author. The '-auto' flag merges all the authors with the same normalized name into the lowest ID.
+// The book-author relations are re-pointed to the canonical author, and the duplicate names become its aliases.
+//
+//	go run ./cmd/merge-authors
+//	go run ./cmd/merge-authors -into 1 5 7
+//	go run ./cmd/merge-authors -auto
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	_ "github.com/lib/pq"
+	"github.com/sdreger/lib-file-processor-go/config"
+	"github.com/sdreger/lib-file-processor-go/domain/author"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+func main() {
+	canonicalID := flag.Int64("into", 0, "the canonical author ID to merge the duplicate author IDs into")
+	auto := flag.Bool("auto", false, "merge all the authors with the same normalized name into the lowest ID")
+	flag.Parse()
+
+	db, err := sql.Open("postgres", config.GetAppConfig().DBConnectionString)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "can not connect to DB: %v\n", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	store := author.NewPostgresStore(db, log.New(os.Stdout, "", log.Ldate|log.Ltime))
+	ctx := context.Background()
+	switch {
+	case *canonicalID != 0:
+		err = merge(ctx, store, *canonicalID, flag.Args())
+	case *auto:
+		err = mergeByName(ctx, store)
+	default:
+		err = list(ctx, store)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+// list prints the duplicate author candidates.
+func list(ctx context.Context, store author.PostgresStore) error {
+	groups, err := findDuplicates(ctx, store)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "GROUP\tREASON\tID\tNAME\tSOURCE\n")
+	for i, group := range groups {
+		for _, profile := range group.Authors {
+			fmt.Fprintf(writer, "%d\t%s\t%d\t%s\t%s\n", i+1, group.Reason, profile.ID, profile.Name, profile.Source)
+		}
+	}
+	writer.Flush()
+	fmt.Printf("%d duplicate groups found ('name' - safe to merge with -auto, 'initials' - check manually)\n",
+		len(groups))
+
+	return nil
+}
+
+// merge merges the duplicate author IDs from the command arguments into the canonical author.
+func merge(ctx context.Context, store author.PostgresStore, canonicalID int64, args []string) error {
+	duplicateIDs := make([]int64, 0, len(args))
+	for _, arg := range args {
+		duplicateID, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("can not parse author ID %q: %w", arg, err)
+		}
+		duplicateIDs = append(duplicateIDs, duplicateID)
+	}
+
+	return store.MergeAuthors(ctx, canonicalID, duplicateIDs)
+}
+
+// mergeByName merges the authors with the same normalized name into the lowest ID. The authors with the same
+// initials only are not merged, since 'J. Smith' could be either 'John Smith' or 'Jane Smith'. The authors with
+// different profiles are not merged either, since the book data source tells them apart.
+func mergeByName(ctx context.Context, store author.PostgresStore) error {
+	groups, err := findDuplicates(ctx, store)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		if group.Reason != "name" || countProfiles(group.Authors) > 1 {
+			continue
+		}
+		duplicateIDs := make([]int64, 0, len(group.Authors)-1)
+		for _, profile := range group.Authors[1:] {
+			duplicateIDs = append(duplicateIDs, profile.ID)
+		}
+		if err := store.MergeAuthors(ctx, group.Authors[0].ID, duplicateIDs); err != nil {
+			return fmt.Errorf("can not merge authors %d into author %d: %w", duplicateIDs, group.Authors[0].ID,
+				err)
+		}
+	}
+
+	return nil
+}
+
+func findDuplicates(ctx context.Context, store author.PostgresStore) ([]author.DuplicateGroup, error) {
+	authors, err := store.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can not find authors: %w", err)
+	}
+
+	return author.GroupDuplicates(authors), nil
+}
+
+func countProfiles(authors []author.Profile) int {
+	count := 0
+	for _, profile := range authors {
+		if profile.HasExternalID() {
+			count++
+		}
+	}
+
+	return count
+}
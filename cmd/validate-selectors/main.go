@@ -0,0 +1,82 @@
+// Command validate-selectors runs the saved Amazon product pages against one or more selector set files,
+// and reports which fields each selector set extracts from each page. The built-in selector set is used,
+// if no files are given.
+//
+//	go run ./cmd/validate-selectors -fixtures scrapper/testdata selectors_v1.yaml selectors_v2.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/sdreger/lib-file-processor-go/scrapper"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+const defaultFixtureDir = "scrapper/testdata"
+
+func main() {
+	fixtureDir := flag.String("fixtures", defaultFixtureDir, "folder with the saved product pages (*.html)")
+	flag.Parse()
+
+	failed := false
+	selectorFiles := flag.Args()
+	if len(selectorFiles) == 0 {
+		failed = !validate("built-in", scrapper.DefaultSelectorSet(), *fixtureDir)
+	}
+	for _, selectorFile := range selectorFiles {
+		selectors, err := scrapper.LoadSelectorSet(selectorFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			failed = true
+			continue
+		}
+		if !validate(selectorFile, selectors, *fixtureDir) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// validate prints the selector set report, and returns false if the fixture pages could not be processed.
+func validate(name string, selectors scrapper.SelectorSet, fixtureDir string) bool {
+	reports, err := scrapper.ValidateSelectorSet(selectors, fixtureDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return false
+	}
+
+	fmt.Printf("Selector set: %s (version %s)\n", name, selectors.Version)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "PAGE\t%s\n", strings.ToUpper(strings.Join(scrapper.SelectorFields, "\t")))
+	for _, report := range reports {
+		row := make([]string, 0, len(scrapper.SelectorFields))
+		for _, field := range scrapper.SelectorFields {
+			if selector, ok := report.Matched[field]; ok {
+				row = append(row, fmt.Sprintf("+%d", indexOf(selectors.Fields[field], selector)+1))
+			} else {
+				row = append(row, "-")
+			}
+		}
+		fmt.Fprintf(writer, "%s\t%s\n", report.Fixture, strings.Join(row, "\t"))
+	}
+	writer.Flush()
+	fmt.Println("('+N' - extracted with the N-th fallback selector, '-' - not extracted)")
+	fmt.Println()
+
+	return true
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+
+	return -1
+}
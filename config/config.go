@@ -5,6 +5,8 @@ import (
 	"os"
 	"runtime"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -27,6 +29,18 @@ const (
 
 	defaultLogFilePath = "lib_file_processor.log"
 
+	// The openlibrary source needs no API key, and supports the free text search, which amazon does not
+	defaultScrapperSources   = "amazon,openlibrary"
+	defaultAmazonStorefronts = "com"
+	defaultScrapperCacheTTL  = 24 * time.Hour
+	defaultScrapperOffline   = false
+	defaultScrapperRetries   = 3
+	defaultScrapperRetryWait = 2 * time.Second
+	defaultScrapperMinDelay  = time.Second
+	defaultScrapperAuthors   = false
+	defaultScrapperBackoff   = time.Minute
+	defaultFileNameEdition   = 2
+
 	EnvVarKeyDBHost     = "DB_HOST"
 	EnvVarKeyDBUser     = "DB_USER"
 	EnvVarKeyDBPassword = "DB_PASSWORD"
@@ -45,6 +59,29 @@ const (
 	EnvVarDirOutputCover   = "DIR_OUTPUT_COVER"
 
 	EnvVarLogFilePath = "LOG_FILE_PATH"
+
+	EnvVarScrapperSources         = "SCRAPPER_SOURCES"
+	EnvVarScrapperFieldPrecedence = "SCRAPPER_FIELD_PRECEDENCE"
+	EnvVarGoogleBooksAPIKey       = "GOOGLE_BOOKS_API_KEY"
+	EnvVarAmazonStorefronts       = "AMAZON_STOREFRONTS"
+	EnvVarScrapperCacheDir        = "SCRAPPER_CACHE_DIR"
+	EnvVarScrapperCacheTTL        = "SCRAPPER_CACHE_TTL"
+	EnvVarScrapperOffline         = "SCRAPPER_OFFLINE"
+	EnvVarScrapperMaxRetries      = "SCRAPPER_MAX_RETRIES"
+	EnvVarScrapperRetryDelay      = "SCRAPPER_RETRY_DELAY"
+	EnvVarScrapperMinRequestDelay = "SCRAPPER_MIN_REQUEST_DELAY"
+	EnvVarScrapperSelectorsFile   = "SCRAPPER_SELECTORS_FILE"
+	EnvVarScrapperAuthorPages     = "SCRAPPER_AUTHOR_PAGES"
+	EnvVarScrapperUserAgents      = "SCRAPPER_USER_AGENTS"
+	EnvVarScrapperProxies         = "SCRAPPER_PROXIES"
+	EnvVarScrapperProxyBackoff    = "SCRAPPER_PROXY_BACKOFF"
+	EnvVarScrapperCookieDir       = "SCRAPPER_COOKIE_DIR"
+
+	EnvVarParserPublisherRulesFile = "PARSER_PUBLISHER_RULES_FILE"
+
+	EnvVarBookFileNameMinEdition       = "BOOK_FILE_NAME_MIN_EDITION"
+	EnvVarBookFileNameEditionQualifier = "BOOK_FILE_NAME_EDITION_QUALIFIER"
+	EnvVarBookFileNameEditionYear      = "BOOK_FILE_NAME_EDITION_YEAR"
 )
 
 func GetAppConfig() AppConfig {
@@ -115,6 +152,120 @@ func GetAppConfig() AppConfig {
 		logFilePath = logFilePathVal
 	}
 
+	scrapperSources := defaultScrapperSources
+	scrapperFieldPrecedence := ""
+	if scrapperSourcesVal, scrapperSourcesValSet := os.LookupEnv(EnvVarScrapperSources); scrapperSourcesValSet {
+		scrapperSources = scrapperSourcesVal
+	}
+	if scrapperFieldPrecedenceVal, scrapperFieldPrecedenceValSet :=
+		os.LookupEnv(EnvVarScrapperFieldPrecedence); scrapperFieldPrecedenceValSet {
+		scrapperFieldPrecedence = scrapperFieldPrecedenceVal
+	}
+	googleBooksAPIKey := ""
+	if googleBooksAPIKeyVal, googleBooksAPIKeyValSet := os.LookupEnv(EnvVarGoogleBooksAPIKey); googleBooksAPIKeyValSet {
+		googleBooksAPIKey = googleBooksAPIKeyVal
+	}
+	amazonStorefronts := defaultAmazonStorefronts
+	if amazonStorefrontsVal, amazonStorefrontsValSet := os.LookupEnv(EnvVarAmazonStorefronts); amazonStorefrontsValSet {
+		amazonStorefronts = amazonStorefrontsVal
+	}
+	// The snapshot cache is disabled, unless the cache folder is set
+	scrapperCacheDir := ""
+	scrapperCacheTTL := defaultScrapperCacheTTL
+	scrapperOffline := defaultScrapperOffline
+	if scrapperCacheDirVal, scrapperCacheDirValSet := os.LookupEnv(EnvVarScrapperCacheDir); scrapperCacheDirValSet {
+		scrapperCacheDir = scrapperCacheDirVal
+	}
+	if scrapperCacheTTLVal, scrapperCacheTTLValSet := os.LookupEnv(EnvVarScrapperCacheTTL); scrapperCacheTTLValSet {
+		if cacheTTL, err := time.ParseDuration(scrapperCacheTTLVal); err == nil {
+			scrapperCacheTTL = cacheTTL
+		}
+	}
+	if scrapperOfflineVal, scrapperOfflineValSet := os.LookupEnv(EnvVarScrapperOffline); scrapperOfflineValSet {
+		if offline, err := strconv.ParseBool(scrapperOfflineVal); err == nil {
+			scrapperOffline = offline
+		}
+	}
+	scrapperMaxRetries := defaultScrapperRetries
+	scrapperRetryDelay := defaultScrapperRetryWait
+	scrapperMinRequestDelay := defaultScrapperMinDelay
+	if scrapperMaxRetriesVal, scrapperMaxRetriesValSet := os.LookupEnv(EnvVarScrapperMaxRetries); scrapperMaxRetriesValSet {
+		if maxRetries, err := strconv.Atoi(scrapperMaxRetriesVal); err == nil && maxRetries >= 0 {
+			scrapperMaxRetries = maxRetries
+		}
+	}
+	if scrapperRetryDelayVal, scrapperRetryDelayValSet := os.LookupEnv(EnvVarScrapperRetryDelay); scrapperRetryDelayValSet {
+		if retryDelay, err := time.ParseDuration(scrapperRetryDelayVal); err == nil {
+			scrapperRetryDelay = retryDelay
+		}
+	}
+	if scrapperMinRequestDelayVal, scrapperMinRequestDelayValSet :=
+		os.LookupEnv(EnvVarScrapperMinRequestDelay); scrapperMinRequestDelayValSet {
+		if minRequestDelay, err := time.ParseDuration(scrapperMinRequestDelayVal); err == nil {
+			scrapperMinRequestDelay = minRequestDelay
+		}
+	}
+	scrapperSelectorsFile := ""
+	if scrapperSelectorsFileVal, scrapperSelectorsFileValSet :=
+		os.LookupEnv(EnvVarScrapperSelectorsFile); scrapperSelectorsFileValSet {
+		scrapperSelectorsFile = scrapperSelectorsFileVal
+	}
+	parserPublisherRulesFile := ""
+	if parserPublisherRulesFileVal, parserPublisherRulesFileValSet :=
+		os.LookupEnv(EnvVarParserPublisherRulesFile); parserPublisherRulesFileValSet {
+		parserPublisherRulesFile = parserPublisherRulesFileVal
+	}
+	scrapperAuthorPages := defaultScrapperAuthors
+	if scrapperAuthorPagesVal, scrapperAuthorPagesValSet := os.LookupEnv(EnvVarScrapperAuthorPages); scrapperAuthorPagesValSet {
+		if authorPages, err := strconv.ParseBool(scrapperAuthorPagesVal); err == nil {
+			scrapperAuthorPages = authorPages
+		}
+	}
+
+	// The user agents contain commas, so they are separated by the '|' character. The empty user agents
+	// and cookie folder mean the scrapper defaults.
+	scrapperUserAgents := ""
+	scrapperProxies := ""
+	scrapperProxyBackoff := defaultScrapperBackoff
+	scrapperCookieDir := ""
+	if scrapperUserAgentsVal, scrapperUserAgentsValSet := os.LookupEnv(EnvVarScrapperUserAgents); scrapperUserAgentsValSet &&
+		strings.TrimSpace(scrapperUserAgentsVal) != "" {
+		scrapperUserAgents = scrapperUserAgentsVal
+	}
+	if scrapperProxiesVal, scrapperProxiesValSet := os.LookupEnv(EnvVarScrapperProxies); scrapperProxiesValSet {
+		scrapperProxies = scrapperProxiesVal
+	}
+	if scrapperProxyBackoffVal, scrapperProxyBackoffValSet :=
+		os.LookupEnv(EnvVarScrapperProxyBackoff); scrapperProxyBackoffValSet {
+		if proxyBackoff, err := time.ParseDuration(scrapperProxyBackoffVal); err == nil && proxyBackoff > 0 {
+			scrapperProxyBackoff = proxyBackoff
+		}
+	}
+	if scrapperCookieDirVal, scrapperCookieDirValSet := os.LookupEnv(EnvVarScrapperCookieDir); scrapperCookieDirValSet {
+		scrapperCookieDir = scrapperCookieDirVal
+	}
+
+	// The edition parts shown in the book file names, like: '3rd.Revised.Edition' / '2023.Edition'
+	bookFileNameMinEdition := uint8(defaultFileNameEdition)
+	bookFileNameEditionQualifier := false
+	bookFileNameEditionYear := false
+	if minEditionVal, minEditionValSet := os.LookupEnv(EnvVarBookFileNameMinEdition); minEditionValSet {
+		if minEdition, err := strconv.ParseUint(minEditionVal, 10, 8); err == nil {
+			bookFileNameMinEdition = uint8(minEdition)
+		}
+	}
+	if editionQualifierVal, editionQualifierValSet :=
+		os.LookupEnv(EnvVarBookFileNameEditionQualifier); editionQualifierValSet {
+		if editionQualifier, err := strconv.ParseBool(editionQualifierVal); err == nil {
+			bookFileNameEditionQualifier = editionQualifier
+		}
+	}
+	if editionYearVal, editionYearValSet := os.LookupEnv(EnvVarBookFileNameEditionYear); editionYearValSet {
+		if editionYear, err := strconv.ParseBool(editionYearVal); err == nil {
+			bookFileNameEditionYear = editionYear
+		}
+	}
+
 	return AppConfig{
 		ZipInputFolder:       bookZipFolder,
 		BookInputFolder:      bookInputFolder,
@@ -130,6 +281,29 @@ func GetAppConfig() AppConfig {
 		DBAvailable:          false,
 		BlobStoreAvailable:   false,
 		LogFilePath:          logFilePath,
+
+		ScrapperSources:         getListValue(scrapperSources),
+		ScrapperFieldPrecedence: getFieldPrecedence(scrapperFieldPrecedence),
+		GoogleBooksAPIKey:       googleBooksAPIKey,
+		AmazonStorefronts:       getListValue(amazonStorefronts),
+		ScrapperCacheDir:        scrapperCacheDir,
+		ScrapperCacheTTL:        scrapperCacheTTL,
+		ScrapperOffline:         scrapperOffline,
+		ScrapperMaxRetries:      scrapperMaxRetries,
+		ScrapperRetryDelay:      scrapperRetryDelay,
+		ScrapperMinRequestDelay: scrapperMinRequestDelay,
+		ScrapperSelectorsFile:   scrapperSelectorsFile,
+		ScrapperAuthorPages:     scrapperAuthorPages,
+		ScrapperUserAgents:      getSeparatedListValue(scrapperUserAgents, "|"),
+		ScrapperProxies:         getListValue(scrapperProxies),
+		ScrapperProxyBackoff:    scrapperProxyBackoff,
+		ScrapperCookieDir:       scrapperCookieDir,
+
+		ParserPublisherRulesFile: parserPublisherRulesFile,
+
+		BookFileNameMinEdition:       bookFileNameMinEdition,
+		BookFileNameEditionQualifier: bookFileNameEditionQualifier,
+		BookFileNameEditionYear:      bookFileNameEditionYear,
 	}
 }
 
@@ -146,3 +320,40 @@ func getNewLineDelimiter() byte {
 
 	return delimiter
 }
+
+// getListValue splits a comma-separated string into a slice of trimmed non-empty values.
+func getListValue(value string) []string {
+	return getSeparatedListValue(value, ",")
+}
+
+// getSeparatedListValue splits a string by the separator into a slice of trimmed non-empty values.
+func getSeparatedListValue(value, separator string) []string {
+	values := make([]string, 0)
+	for _, item := range strings.Split(value, separator) {
+		trimmed := strings.TrimSpace(item)
+		if trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+
+	return values
+}
+
+// getFieldPrecedence parses a field precedence string, like: 'ISBN13:googlebooks,amazon;Publisher:openlibrary'
+// into a map of field names to ordered source names.
+func getFieldPrecedence(value string) map[string][]string {
+	precedence := make(map[string][]string)
+	for _, entry := range strings.Split(value, ";") {
+		fieldParts := strings.SplitN(entry, ":", 2)
+		if len(fieldParts) != 2 {
+			continue
+		}
+		field := strings.TrimSpace(fieldParts[0])
+		sources := getListValue(fieldParts[1])
+		if field != "" && len(sources) > 0 {
+			precedence[field] = sources
+		}
+	}
+
+	return precedence
+}
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type AppConfig struct {
 	ZipInputFolder    string
 	BookInputFolder   string
@@ -19,6 +21,29 @@ type AppConfig struct {
 	BlobStoreAvailable bool
 
 	LogFilePath string
+
+	ScrapperSources         []string
+	ScrapperFieldPrecedence map[string][]string
+	GoogleBooksAPIKey       string
+	AmazonStorefronts       []string
+	ScrapperCacheDir        string
+	ScrapperCacheTTL        time.Duration
+	ScrapperOffline         bool
+	ScrapperMaxRetries      int
+	ScrapperRetryDelay      time.Duration
+	ScrapperMinRequestDelay time.Duration
+	ScrapperSelectorsFile   string
+	ScrapperAuthorPages     bool
+	ScrapperUserAgents      []string
+	ScrapperProxies         []string
+	ScrapperProxyBackoff    time.Duration
+	ScrapperCookieDir       string
+
+	ParserPublisherRulesFile string
+
+	BookFileNameMinEdition       uint8
+	BookFileNameEditionQualifier bool
+	BookFileNameEditionYear      bool
 }
 
 func (a AppConfig) IsStatelessMode() bool {
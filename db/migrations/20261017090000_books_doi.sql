@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ebook.books
+    ADD COLUMN doi VARCHAR(255) DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ebook.books
+    DROP COLUMN IF EXISTS doi;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SEQUENCE ebook.series_id_seq AS BIGINT;
+
+CREATE TABLE ebook.series
+(
+    id         BIGINT    default nextval('ebook.series_id_seq'::regclass) NOT NULL,
+    name       VARCHAR(255)                                               NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+ALTER TABLE ebook.books
+    ADD COLUMN series_id     BIGINT DEFAULT NULL REFERENCES ebook.series (id),
+    ADD COLUMN series_volume SMALLINT DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS books_series_id_idx ON ebook.books (series_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ebook.books_series_id_idx;
+ALTER TABLE ebook.books
+    DROP COLUMN IF EXISTS series_volume,
+    DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS ebook.series;
+DROP SEQUENCE IF EXISTS ebook.series_id_seq;
+-- +goose StatementEnd
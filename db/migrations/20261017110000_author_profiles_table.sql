@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ebook.author_profiles
+(
+    author_id   BIGINT        NOT NULL REFERENCES ebook.authors (id) ON DELETE CASCADE,
+    source      VARCHAR(32)   NOT NULL,
+    external_id VARCHAR(64)   NOT NULL,
+    bio         TEXT          DEFAULT NULL,
+    photo_url   VARCHAR(1024) DEFAULT NULL,
+    page_url    VARCHAR(1024) DEFAULT NULL,
+    created_at  TIMESTAMP     DEFAULT now(),
+    updated_at  TIMESTAMP     DEFAULT now(),
+    PRIMARY KEY (author_id),
+    UNIQUE (source, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS authors_name_idx ON ebook.authors (name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ebook.authors_name_idx;
+DROP TABLE IF EXISTS ebook.author_profiles;
+-- +goose StatementEnd
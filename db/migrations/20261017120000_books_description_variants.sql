@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ebook.books
+    ADD COLUMN description_markdown TEXT DEFAULT NULL,
+    ADD COLUMN description_text     TEXT DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ebook.books
+    DROP COLUMN IF EXISTS description_text,
+    DROP COLUMN IF EXISTS description_markdown;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ebook.books
+    ADD COLUMN edition_qualifier VARCHAR DEFAULT NULL,
+    ADD COLUMN edition_year      SMALLINT DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ebook.books
+    DROP COLUMN IF EXISTS edition_year,
+    DROP COLUMN IF EXISTS edition_qualifier;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ebook.book_author
+    ADD COLUMN role         VARCHAR  NOT NULL DEFAULT 'author',
+    ADD COLUMN author_order SMALLINT NOT NULL DEFAULT 0;
+
+-- The same person could be both an author and an editor of a book
+ALTER TABLE ebook.book_author
+    DROP CONSTRAINT book_author_pkey,
+    ADD PRIMARY KEY (book_id, author_id, role);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE
+FROM ebook.book_author
+WHERE role <> 'author';
+
+ALTER TABLE ebook.book_author
+    DROP CONSTRAINT book_author_pkey,
+    ADD PRIMARY KEY (book_id, author_id);
+
+ALTER TABLE ebook.book_author
+    DROP COLUMN IF EXISTS author_order,
+    DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ebook.author_aliases
+(
+    alias_key  VARCHAR(255) NOT NULL,
+    alias      VARCHAR(255) NOT NULL,
+    author_id  BIGINT       NOT NULL REFERENCES ebook.authors (id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (alias_key)
+);
+
+CREATE INDEX IF NOT EXISTS author_aliases_author_id_idx ON ebook.author_aliases (author_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS ebook.author_aliases_author_id_idx;
+DROP TABLE IF EXISTS ebook.author_aliases;
+-- +goose StatementEnd
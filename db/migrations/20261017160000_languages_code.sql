@@ -0,0 +1,108 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ebook.languages
+    ADD COLUMN code VARCHAR(3) DEFAULT NULL;
+
+-- The ISO 639 codes and the language display names, the same as in the 'parser/language.go' mapping
+CREATE TEMPORARY TABLE language_names
+(
+    code VARCHAR(3)   NOT NULL,
+    name VARCHAR(255) NOT NULL
+) ON COMMIT DROP;
+
+INSERT INTO language_names(code, name)
+VALUES ('ar', 'Arabic'),
+       ('cs', 'Czech'),
+       ('da', 'Danish'),
+       ('de', 'German'),
+       ('el', 'Greek'),
+       ('en', 'English'),
+       ('es', 'Spanish'),
+       ('fi', 'Finnish'),
+       ('fr', 'French'),
+       ('grc', 'Ancient Greek'),
+       ('he', 'Hebrew'),
+       ('hu', 'Hungarian'),
+       ('it', 'Italian'),
+       ('ja', 'Japanese'),
+       ('ko', 'Korean'),
+       ('la', 'Latin'),
+       ('nl', 'Dutch'),
+       ('no', 'Norwegian'),
+       ('pl', 'Polish'),
+       ('pt', 'Portuguese'),
+       ('ru', 'Russian'),
+       ('sv', 'Swedish'),
+       ('tr', 'Turkish'),
+       ('uk', 'Ukrainian'),
+       ('zh', 'Chinese');
+
+CREATE TEMPORARY TABLE language_aliases
+(
+    alias VARCHAR(255) NOT NULL,
+    code  VARCHAR(3)   NOT NULL
+) ON COMMIT DROP;
+
+INSERT INTO language_aliases(alias, code)
+VALUES ('ar', 'ar'), ('arabic', 'ar'), ('arabisch', 'ar'), ('arabe', 'ar'), ('árabe', 'ar'), ('arabo', 'ar'), ('ara', 'ar'),
+       ('cs', 'cs'), ('czech', 'cs'), ('tschechisch', 'cs'), ('tchèque', 'cs'), ('checo', 'cs'), ('ceco', 'cs'), ('ces', 'cs'), ('cze', 'cs'),
+       ('da', 'da'), ('danish', 'da'), ('dänisch', 'da'), ('danois', 'da'), ('danés', 'da'), ('danese', 'da'), ('dan', 'da'),
+       ('de', 'de'), ('german', 'de'), ('deutsch', 'de'), ('allemand', 'de'), ('alemán', 'de'), ('tedesco', 'de'), ('deu', 'de'), ('ger', 'de'),
+       ('el', 'el'), ('greek', 'el'), ('griechisch', 'el'), ('grec', 'el'), ('griego', 'el'), ('greco', 'el'), ('ell', 'el'), ('gre', 'el'),
+       ('en', 'en'), ('english', 'en'), ('englisch', 'en'), ('anglais', 'en'), ('inglés', 'en'), ('inglese', 'en'), ('eng', 'en'),
+       ('es', 'es'), ('spanish', 'es'), ('spanisch', 'es'), ('espagnol', 'es'), ('español', 'es'), ('spagnolo', 'es'), ('spa', 'es'),
+       ('fi', 'fi'), ('finnish', 'fi'), ('finnisch', 'fi'), ('finnois', 'fi'), ('finlandés', 'fi'), ('finlandese', 'fi'), ('fin', 'fi'),
+       ('fr', 'fr'), ('french', 'fr'), ('französisch', 'fr'), ('français', 'fr'), ('francés', 'fr'), ('francese', 'fr'), ('fra', 'fr'), ('fre', 'fr'),
+       ('grc', 'grc'), ('ancient greek', 'grc'), ('altgriechisch', 'grc'), ('grec ancien', 'grc'), ('griego antiguo', 'grc'), ('greco antico', 'grc'),
+       ('he', 'he'), ('hebrew', 'he'), ('hebräisch', 'he'), ('hébreu', 'he'), ('hebreo', 'he'), ('ebraico', 'he'), ('heb', 'he'),
+       ('hu', 'hu'), ('hungarian', 'hu'), ('ungarisch', 'hu'), ('hongrois', 'hu'), ('húngaro', 'hu'), ('ungherese', 'hu'), ('hun', 'hu'),
+       ('it', 'it'), ('italian', 'it'), ('italienisch', 'it'), ('italien', 'it'), ('italiano', 'it'), ('ita', 'it'),
+       ('ja', 'ja'), ('japanese', 'ja'), ('japanisch', 'ja'), ('japonais', 'ja'), ('japonés', 'ja'), ('giapponese', 'ja'), ('jpn', 'ja'),
+       ('ko', 'ko'), ('korean', 'ko'), ('koreanisch', 'ko'), ('coréen', 'ko'), ('coreano', 'ko'), ('kor', 'ko'),
+       ('la', 'la'), ('latin', 'la'), ('latein', 'la'), ('latín', 'la'), ('latino', 'la'), ('lat', 'la'),
+       ('nl', 'nl'), ('dutch', 'nl'), ('niederländisch', 'nl'), ('néerlandais', 'nl'), ('neerlandés', 'nl'), ('olandese', 'nl'), ('nld', 'nl'), ('dut', 'nl'),
+       ('no', 'no'), ('norwegian', 'no'), ('norwegisch', 'no'), ('norvégien', 'no'), ('noruego', 'no'), ('norvegese', 'no'), ('nor', 'no'),
+       ('pl', 'pl'), ('polish', 'pl'), ('polnisch', 'pl'), ('polonais', 'pl'), ('polaco', 'pl'), ('polacco', 'pl'), ('pol', 'pl'),
+       ('pt', 'pt'), ('portuguese', 'pt'), ('portugiesisch', 'pt'), ('portugais', 'pt'), ('portugués', 'pt'), ('portoghese', 'pt'), ('por', 'pt'),
+       ('ru', 'ru'), ('russian', 'ru'), ('russisch', 'ru'), ('russe', 'ru'), ('ruso', 'ru'), ('russo', 'ru'), ('rus', 'ru'),
+       ('sv', 'sv'), ('swedish', 'sv'), ('schwedisch', 'sv'), ('suédois', 'sv'), ('sueco', 'sv'), ('svedese', 'sv'), ('swe', 'sv'),
+       ('tr', 'tr'), ('turkish', 'tr'), ('türkisch', 'tr'), ('turc', 'tr'), ('turco', 'tr'), ('tur', 'tr'),
+       ('uk', 'uk'), ('ukrainian', 'uk'), ('ukrainisch', 'uk'), ('ukrainien', 'uk'), ('ucraniano', 'uk'), ('ucraino', 'uk'), ('ukr', 'uk'),
+       ('zh', 'zh'), ('chinese', 'zh'), ('chinesisch', 'zh'), ('chinois', 'zh'), ('chino', 'zh'), ('cinese', 'zh'), ('zho', 'zh'), ('chi', 'zh');
+
+UPDATE ebook.languages l
+SET code = a.code
+FROM language_aliases a
+WHERE a.alias = lower(trim(l.name));
+
+-- The duplicate languages are merged into the one with the lowest ID
+UPDATE ebook.books b
+SET language_id = d.canonical_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY code) AS canonical_id
+      FROM ebook.languages
+      WHERE code IS NOT NULL) d
+WHERE b.language_id = d.id
+  AND d.id <> d.canonical_id;
+
+DELETE
+FROM ebook.languages l
+    USING ebook.languages c
+WHERE l.code = c.code
+  AND l.id > c.id;
+
+UPDATE ebook.languages l
+SET name = n.name
+FROM language_names n
+WHERE l.code = n.code;
+
+CREATE UNIQUE INDEX IF NOT EXISTS languages_code_idx ON ebook.languages (code);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The merged duplicate languages are not restored
+DROP INDEX IF EXISTS ebook.languages_code_idx;
+
+ALTER TABLE ebook.languages
+    DROP COLUMN IF EXISTS code;
+-- +goose StatementEnd
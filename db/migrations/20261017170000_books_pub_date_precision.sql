@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The publication date precision: 0 - day, 1 - month, 2 - year. A partial date, like '2020', is stored
+-- as the first day of the period.
+ALTER TABLE ebook.books
+    ADD COLUMN pub_date_precision SMALLINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ebook.books
+    DROP COLUMN IF EXISTS pub_date_precision;
+-- +goose StatementEnd
@@ -0,0 +1,214 @@
+package author
+
+import (
+	"fmt"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maxAcronymLength is the longest upper case word, which could be an acronym, like: 'OECD' or 'NASA'
+const maxAcronymLength = 4
+
+var (
+	// J.R.R. / J.R.R / J.
+	initialsRegex = regexp.MustCompile(`^(\p{Lu}\.?)+$`)
+	// OECD / NASA / IBM
+	acronymRegex = regexp.MustCompile(fmt.Sprintf(`^\p{Lu}{2,%d}$`, maxAcronymLength))
+	// John / J.R.R. / O'Brien / Jean-Luc / van
+	personalNameWordRegex = regexp.MustCompile(`^\p{L}[\p{L}.'’\-]*$`)
+	// The punctuation, which separates the name key words: 'J.Smith' -> 'j smith', 'Jean-Luc' -> 'jean luc'
+	nameKeySeparatorRegex = regexp.MustCompile(`[.,()\-]`)
+	// The punctuation, which is removed from the name key: 'O'Brien' -> 'obrien'
+	nameKeyCleanupRegex = regexp.MustCompile(`['’"]`)
+
+	// nameSuffixes are not inverted, like: 'John Smith, Jr.'
+	nameSuffixes = map[string]bool{"jr": true, "sr": true, "ii": true, "iii": true, "iv": true, "phd": true,
+		"md": true}
+	// organizationWords mark the corporate authors, like: 'O'Reilly Media, Inc.', which are kept as is
+	organizationWords = map[string]bool{"inc": true, "ltd": true, "llc": true, "llp": true, "gmbh": true, "ag": true,
+		"sa": true, "plc": true, "corp": true, "corporation": true, "co": true, "company": true, "limited": true,
+		"press": true, "media": true, "publishing": true, "publishers": true, "publications": true, "books": true,
+		"group": true, "verlag": true, "editions": true, "university": true, "institute": true, "association": true,
+		"society": true, "foundation": true, "council": true, "committee": true, "organization": true}
+
+	diacriticsRemover = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+)
+
+// DuplicateGroup is a group of authors, which are likely the same person. The reason is either the 'name'
+// (the same normalized name, like: 'Smith, John' and 'John Smith'), or the 'initials' (like: 'J. Smith' and
+// 'John Smith'), which should be checked manually.
+type DuplicateGroup struct {
+	Reason  string
+	Authors []Profile
+}
+
+// NormalizeName returns the canonical form of an author name: the 'Last, First' names are inverted, the all upper
+// or lower case names are capitalized, and the initials are separated, like: 'TOLKIEN, J.R.R.' -> 'J. R. R. Tolkien'.
+// The corporate authors, like: 'O'Reilly Media, Inc.', and the acronyms, like: 'OECD', are kept as is.
+func NormalizeName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if isOrganizationName(name) {
+		return name
+	}
+	if parts := strings.Split(name, ","); len(parts) == 2 && !isNameSuffix(parts[1]) &&
+		isPersonalName(parts[0]) && isPersonalName(parts[1]) {
+		name = strings.TrimSpace(parts[1]) + " " + strings.TrimSpace(parts[0])
+	}
+	if (name == strings.ToUpper(name) && !isAcronymName(name)) || name == strings.ToLower(name) {
+		name = capitalizeName(name)
+	}
+
+	words := strings.Fields(name)
+	normalized := make([]string, 0, len(words))
+	for i, word := range words {
+		// The last word is a family name, even a single letter one
+		if i < len(words)-1 && initialsRegex.MatchString(word) {
+			for _, initial := range strings.ReplaceAll(word, ".", "") {
+				normalized = append(normalized, string(initial)+".")
+			}
+			continue
+		}
+		normalized = append(normalized, word)
+	}
+
+	return strings.Join(normalized, " ")
+}
+
+// NameKey returns the name matching key: the normalized name in lower case, without diacritics and punctuation,
+// like: 'Smith, José' -> 'jose smith'. The name variants with the same key are the aliases of the same author.
+func NameKey(name string) string {
+	key, _, err := transform.String(diacriticsRemover, NormalizeName(name))
+	if err != nil {
+		key = NormalizeName(name)
+	}
+	key = nameKeySeparatorRegex.ReplaceAllString(strings.ToLower(key), " ")
+	key = nameKeyCleanupRegex.ReplaceAllString(key, "")
+
+	return strings.Join(strings.Fields(key), " ")
+}
+
+// InitialsKey returns the name key with the given names reduced to the initials, like: 'John Smith' -> 'j smith'.
+// The names with the same initials key are only the candidates for the aliases, like: 'J. Smith' and 'Jane Smith'.
+func InitialsKey(name string) string {
+	words := strings.Fields(NameKey(name))
+	for i := 0; i < len(words)-1; i++ {
+		words[i] = string([]rune(words[i])[0])
+	}
+
+	return strings.Join(words, " ")
+}
+
+// GroupDuplicates returns the groups of the authors, which are likely the same person. The authors with the same
+// name key are grouped first, the rest of them are grouped by the initials key. The authors are sorted by IDs.
+func GroupDuplicates(authors []Profile) []DuplicateGroup {
+	groups := make([]DuplicateGroup, 0)
+	grouped := make(map[int64]bool)
+	for _, group := range groupAuthors(authors, NameKey) {
+		groups = append(groups, DuplicateGroup{Reason: "name", Authors: group})
+		for _, author := range group {
+			grouped[author.ID] = true
+		}
+	}
+
+	// The initials groups should have at least one not grouped author, otherwise they are already merged by name
+	for _, group := range groupAuthors(authors, InitialsKey) {
+		for _, author := range group {
+			if !grouped[author.ID] {
+				groups = append(groups, DuplicateGroup{Reason: "initials", Authors: group})
+				break
+			}
+		}
+	}
+
+	return groups
+}
+
+// groupAuthors groups the authors by the key, the groups of a single author are skipped.
+func groupAuthors(authors []Profile, getKey func(string) string) [][]Profile {
+	keys := make([]string, 0)
+	authorsByKey := make(map[string][]Profile)
+	for _, author := range authors {
+		key := getKey(author.Name)
+		if _, ok := authorsByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		authorsByKey[key] = append(authorsByKey[key], author)
+	}
+
+	groups := make([][]Profile, 0)
+	for _, key := range keys {
+		group := authorsByKey[key]
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+func isNameSuffix(value string) bool {
+	return nameSuffixes[strings.ToLower(strings.Trim(strings.TrimSpace(value), "."))]
+}
+
+// isOrganizationName checks if any of the name words is a company suffix, or an organization word, like: 'Press'.
+func isOrganizationName(name string) bool {
+	for _, word := range strings.Fields(name) {
+		if organizationWords[strings.ToLower(strings.Trim(word, ".,()"))] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPersonalName checks if a part of the 'Last, First' name looks like a personal name: a few words of letters,
+// like: 'John', 'J.R.R.' or 'van Beethoven'.
+func isPersonalName(value string) bool {
+	words := strings.Fields(value)
+	if len(words) == 0 || len(words) > 4 {
+		return false
+	}
+	for _, word := range words {
+		if !personalNameWordRegex.MatchString(word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isAcronymName checks if all the name words could be acronyms, like: 'OECD' or 'NASA'. Such names could not be
+// told apart from the short upper case personal names, so they are not capitalized.
+func isAcronymName(name string) bool {
+	for _, word := range strings.Fields(name) {
+		if !acronymRegex.MatchString(word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// capitalizeName capitalizes every name part, including the hyphenated ones and the ones after an apostrophe,
+// like: 'JEAN-LUC O'BRIEN' -> 'Jean-Luc O'Brien'.
+func capitalizeName(name string) string {
+	capitalizeNext := true
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) {
+			capitalizeNext = true
+			return r
+		}
+		if capitalizeNext {
+			capitalizeNext = false
+			return unicode.ToUpper(r)
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
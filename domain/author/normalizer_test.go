@@ -0,0 +1,93 @@
+package author
+
+import (
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		input      string
+		name       string
+		key        string
+		initialKey string
+	}{
+		{input: "John Smith", name: "John Smith", key: "john smith", initialKey: "j smith"},
+		{input: " Smith,  John ", name: "John Smith", key: "john smith", initialKey: "j smith"},
+		{input: "JOHN SMITH", name: "John Smith", key: "john smith", initialKey: "j smith"},
+		{input: "J. Smith", name: "J. Smith", key: "j smith", initialKey: "j smith"},
+		{input: "J Smith", name: "J. Smith", key: "j smith", initialKey: "j smith"},
+		{input: "TOLKIEN, J.R.R.", name: "J. R. R. Tolkien", key: "j r r tolkien", initialKey: "j r r tolkien"},
+		{input: "José Müller", name: "José Müller", key: "jose muller", initialKey: "j muller"},
+		{input: "jean-luc o'brien", name: "Jean-Luc O'Brien", key: "jean luc obrien", initialKey: "j l obrien"},
+		{input: "John Smith, Jr.", name: "John Smith, Jr.", key: "john smith jr", initialKey: "j s jr"},
+		{input: "Ludwig van Beethoven", name: "Ludwig van Beethoven", key: "ludwig van beethoven",
+			initialKey: "l v beethoven"},
+		{input: "van Beethoven, Ludwig", name: "Ludwig van Beethoven", key: "ludwig van beethoven",
+			initialKey: "l v beethoven"},
+		{input: "O'Reilly Media, Inc.", name: "O'Reilly Media, Inc.", key: "oreilly media inc",
+			initialKey: "o m inc"},
+		{input: "Packt Publishing, Limited", name: "Packt Publishing, Limited", key: "packt publishing limited",
+			initialKey: "p p limited"},
+		{input: "IBM Press", name: "IBM Press", key: "ibm press", initialKey: "i press"},
+		{input: "OECD", name: "OECD", key: "oecd", initialKey: "oecd"},
+		{input: "NASA", name: "NASA", key: "nasa", initialKey: "nasa"},
+		{input: "Smith, John 2nd", name: "Smith, John 2nd", key: "smith john 2nd", initialKey: "s j 2nd"},
+	}
+
+	t.Log("Given the need to test author name normalization.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for author name %q\n", i, tt.input, tt.name)
+		if name := NormalizeName(tt.input); name != tt.name {
+			t.Errorf("\t\t%s\tShould get a %q normalized name: %q", failed, tt.name, name)
+			continue
+		}
+		if key := NameKey(tt.input); key != tt.key {
+			t.Errorf("\t\t%s\tShould get a %q name key: %q", failed, tt.key, key)
+			continue
+		}
+		if initialsKey := InitialsKey(tt.input); initialsKey != tt.initialKey {
+			t.Errorf("\t\t%s\tShould get a %q initials key: %q", failed, tt.initialKey, initialsKey)
+			continue
+		}
+		t.Logf("\t\t%s\tShould be able to get correct normalized name.", succeed)
+	}
+}
+
+func TestGroupDuplicates(t *testing.T) {
+	authors := []Profile{
+		{ID: 5, Name: "Smith, John"},
+		{ID: 1, Name: "John Smith"},
+		{ID: 3, Name: "J. Smith"},
+		{ID: 4, Name: "Jane Doe"},
+		{ID: 2, Name: "José Müller"},
+		{ID: 6, Name: "Jose Muller"},
+	}
+
+	t.Log("Given the need to test author duplicates grouping.")
+	groups := GroupDuplicates(authors)
+	if len(groups) != 3 {
+		t.Fatalf("\t\t%s\tShould get 3 duplicate groups: %+v", failed, groups)
+	}
+	expected := []struct {
+		reason string
+		ids    []int64
+	}{
+		{reason: "name", ids: []int64{1, 5}},
+		{reason: "name", ids: []int64{2, 6}},
+		{reason: "initials", ids: []int64{1, 3, 5}},
+	}
+	for i, group := range groups {
+		if group.Reason != expected[i].reason || len(group.Authors) != len(expected[i].ids) {
+			t.Fatalf("\t\t%s\tShould get a %q group of %v authors: %+v", failed, expected[i].reason,
+				expected[i].ids, group)
+		}
+		for j, author := range group.Authors {
+			if author.ID != expected[i].ids[j] {
+				t.Fatalf("\t\t%s\tShould get a %q group of %v authors: %+v", failed, expected[i].reason,
+					expected[i].ids, group)
+			}
+		}
+	}
+
+	t.Logf("\t\t%s\tShould be able to group author duplicates", succeed)
+}
@@ -3,13 +3,18 @@ package author
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/lib/pq"
 	"github.com/sdreger/lib-file-processor-go/db/transaction"
+	"github.com/sdreger/lib-file-processor-go/parser"
 	"io"
 	"log"
 )
 
+const insertAliasQuery = `INSERT INTO ebook.author_aliases(alias_key, alias, author_id) VALUES ($1, $2, $3)
+	ON CONFLICT (alias_key) DO NOTHING`
+
 type PostgresStore struct {
 	db     *sql.DB
 	logger *log.Logger
@@ -22,72 +27,354 @@ func NewPostgresStore(db *sql.DB, logger *log.Logger) PostgresStore {
 	}
 }
 
-// UpsertAll adds new authors from the input slice, existing authors are ignored.
+// UpsertAll adds new authors from the input slice, existing authors are ignored. The author names are normalized
+// and matched by the name key through the author aliases, so 'Smith, John' and 'John Smith' get the same ID.
+// The authors stored before the aliases were introduced are matched by the name, and get an alias.
 // Returns both new and existing IDs for all authors from the input slice.
 func (s PostgresStore) UpsertAll(ctx context.Context, authors []string) ([]int64, error) {
 	if len(authors) == 0 {
 		return []int64{}, nil
 	}
 
+	names := make([]string, 0, len(authors))
+	keys := make([]string, 0, len(authors))
+	lookupNames := make([]string, 0, len(authors))
+	for _, author := range authors {
+		name := NormalizeName(author)
+		names = append(names, name)
+		keys = append(keys, NameKey(name))
+		lookupNames = append(lookupNames, name)
+		if author != name {
+			lookupNames = append(lookupNames, author)
+		}
+	}
+
+	var ret []int64
+	err := transaction.WithTransaction(ctx, s.db, func(txCtx context.Context, tx *sql.Tx) error {
+		aliasedAuthors, err := s.findAuthorIDs(txCtx, tx,
+			"SELECT author_id, alias_key FROM ebook.author_aliases WHERE alias_key = ANY ($1)", keys)
+		if err != nil {
+			return err
+		}
+		namedAuthors, err := s.findAuthorIDs(txCtx, tx,
+			"SELECT id, name FROM ebook.authors WHERE name = ANY ($1) ORDER BY id", lookupNames)
+		if err != nil {
+			return err
+		}
+
+		insertStmt, err := tx.PrepareContext(txCtx, "INSERT INTO ebook.authors(name) VALUES ($1) RETURNING id")
+		if err != nil {
+			return err
+		}
+		defer s.closeResource(insertStmt)
+
+		aliasStmt, err := tx.PrepareContext(txCtx, insertAliasQuery)
+		if err != nil {
+			return err
+		}
+		defer s.closeResource(aliasStmt)
+
+		for i, key := range keys {
+			if existingID, ok := aliasedAuthors[key]; ok {
+				ret = append(ret, existingID)
+				continue
+			}
+			authorID, ok := namedAuthors[key]
+			if !ok {
+				if err := insertStmt.QueryRowContext(txCtx, names[i]).Scan(&authorID); err != nil {
+					return err
+				}
+			}
+			if _, err := aliasStmt.ExecContext(txCtx, key, names[i], authorID); err != nil {
+				return err
+			}
+			aliasedAuthors[key] = authorID
+			ret = append(ret, authorID)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Printf("[INFO] - Stored author IDs: %d", ret)
+
+	return ret, nil
+}
+
+// findAuthorIDs runs the author IDs query with the values array, and returns the author IDs by the name keys.
+// The query should select an author ID and a name (or a name key). The first ID is taken for the same name key.
+func (s PostgresStore) findAuthorIDs(txCtx context.Context, tx *sql.Tx, query string,
+	values []string) (map[string]int64, error) {
+
+	stmt, err := tx.PrepareContext(txCtx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer s.closeResource(stmt)
+
+	rows, err := stmt.QueryContext(txCtx, pq.Array(values))
+	if err != nil {
+		return nil, err
+	}
+	defer s.closeResource(rows)
+
+	authorIDs := make(map[string]int64)
+	for rows.Next() {
+		var ID int64
+		var name string
+		if err := rows.Scan(&ID, &name); err != nil {
+			return nil, err
+		}
+		if _, ok := authorIDs[NameKey(name)]; !ok {
+			authorIDs[NameKey(name)] = ID
+		}
+	}
+
+	return authorIDs, rows.Err()
+}
+
+// UpsertProfiles adds new authors with their profiles, and updates existing profiles.
+// Authors with an external ID are matched by the source and the external ID, so different authors with the same name
+// get different IDs. A new profile is attached to an existing author with the same name, which has no profile yet.
+// Authors without an external ID are matched by the name aliases, and then by the name.
+// Returns IDs for all authors from the input slice.
+func (s PostgresStore) UpsertProfiles(ctx context.Context, profiles []Profile) ([]int64, error) {
+	if len(profiles) == 0 {
+		return []int64{}, nil
+	}
+
 	var ret []int64
 	err := transaction.WithTransaction(ctx, s.db, func(txCtx context.Context, tx *sql.Tx) error {
-		existingAuthors := make(map[string]int64)
-		stmt, err := tx.PrepareContext(txCtx, "SELECT id, name FROM ebook.authors WHERE name = ANY ($1)")
+		for _, profile := range profiles {
+			authorID, err := s.upsertProfile(txCtx, tx, profile)
+			if err != nil {
+				return fmt.Errorf("can not upsert author %q: %w", profile.Name, err)
+			}
+			ret = append(ret, authorID)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Printf("[INFO] - Stored author IDs: %d", ret)
+
+	return ret, nil
+}
+
+func (s PostgresStore) upsertProfile(txCtx context.Context, tx *sql.Tx, profile Profile) (int64, error) {
+	profile.Name = NormalizeName(profile.Name)
+	if profile.Name == "" {
+		return 0, fmt.Errorf("the author name should not be blank")
+	}
+
+	var authorID int64
+	selectQuery := "SELECT id FROM ebook.authors WHERE name = $1 ORDER BY id LIMIT 1"
+	if profile.HasExternalID() {
+		err := tx.QueryRowContext(txCtx,
+			"SELECT author_id FROM ebook.author_profiles WHERE source = $1 AND external_id = $2",
+			profile.Source, profile.ExternalID).Scan(&authorID)
+		if err == nil {
+			_, err = tx.ExecContext(txCtx, `UPDATE ebook.author_profiles
+				SET bio = $2, photo_url = $3, page_url = $4, updated_at = NOW()::timestamp WHERE author_id = $1`,
+				authorID, getNullableString(profile.Bio), getNullableString(profile.PhotoURL),
+				getNullableString(profile.PageURL))
+			return authorID, err
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+		selectQuery = `SELECT a.id FROM ebook.authors a LEFT JOIN ebook.author_profiles p ON p.author_id = a.id
+			WHERE a.name = $1 AND p.author_id IS NULL ORDER BY a.id LIMIT 1`
+	} else {
+		err := tx.QueryRowContext(txCtx, "SELECT author_id FROM ebook.author_aliases WHERE alias_key = $1",
+			NameKey(profile.Name)).Scan(&authorID)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return authorID, err
+		}
+	}
+
+	err := tx.QueryRowContext(txCtx, selectQuery, profile.Name).Scan(&authorID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = tx.QueryRowContext(txCtx, "INSERT INTO ebook.authors(name) VALUES ($1) RETURNING id", profile.Name).
+			Scan(&authorID)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(txCtx, insertAliasQuery, profileAliasKey(profile), profile.Name, authorID); err != nil {
+		return 0, err
+	}
+	if !profile.HasExternalID() {
+		return authorID, nil
+	}
+
+	_, err = tx.ExecContext(txCtx, `INSERT INTO ebook.author_profiles(author_id, source, external_id, bio, photo_url,
+		page_url) VALUES ($1, $2, $3, $4, $5, $6)`, authorID, profile.Source, profile.ExternalID,
+		getNullableString(profile.Bio), getNullableString(profile.PhotoURL), getNullableString(profile.PageURL))
+
+	return authorID, err
+}
+
+// profileAliasKey returns the alias key of the author profile. The key of an author with an external ID is qualified
+// by the source and the external ID: 'john smith|amazon:B000000001', so the same name authors from different profiles
+// do not share an alias. The name key alone is left for the authors without an external ID.
+func profileAliasKey(profile Profile) string {
+	if !profile.HasExternalID() {
+		return NameKey(profile.Name)
+	}
+
+	return fmt.Sprintf("%s|%s:%s", NameKey(profile.Name), profile.Source, profile.ExternalID)
+}
+
+// FindByName returns all authors with the name or its alias, along with their profiles (if any).
+// The profile aliases are matched by the name key part, which precedes the '|' separator.
+func (s PostgresStore) FindByName(ctx context.Context, name string) ([]Profile, error) {
+	return s.findProfiles(ctx, `SELECT a.id, a.name, p.source, p.external_id, p.bio, p.photo_url, p.page_url
+		FROM ebook.authors a LEFT JOIN ebook.author_profiles p ON p.author_id = a.id
+		WHERE a.name = $1 OR a.id IN (SELECT author_id FROM ebook.author_aliases
+			WHERE split_part(alias_key, '|', 1) = $2)
+		ORDER BY a.id`, NormalizeName(name), NameKey(name))
+}
+
+// FindAll returns all authors, along with their profiles (if any).
+func (s PostgresStore) FindAll(ctx context.Context) ([]Profile, error) {
+	return s.findProfiles(ctx, `SELECT a.id, a.name, p.source, p.external_id, p.bio, p.photo_url, p.page_url
+		FROM ebook.authors a LEFT JOIN ebook.author_profiles p ON p.author_id = a.id ORDER BY a.id`)
+}
+
+func (s PostgresStore) findProfiles(ctx context.Context, query string, args ...any) ([]Profile, error) {
+	var ret []Profile
+	err := transaction.WithTransaction(ctx, s.db, func(txCtx context.Context, tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(txCtx, query)
 		if err != nil {
 			return err
 		}
 		defer s.closeResource(stmt)
 
-		rows, err := stmt.QueryContext(txCtx, pq.Array(authors))
+		rows, err := stmt.QueryContext(txCtx, args...)
 		if err != nil {
 			return err
 		}
 		defer s.closeResource(rows)
 
 		for rows.Next() {
-			var ID int64
-			var name string
-			err := rows.Scan(&ID, &name)
+			var profile Profile
+			var source, externalID, bio, photoURL, pageURL sql.NullString
+			err := rows.Scan(&profile.ID, &profile.Name, &source, &externalID, &bio, &photoURL, &pageURL)
 			if err != nil {
 				return err
 			}
-			existingAuthors[name] = ID
+			profile.Source, profile.ExternalID, profile.Bio = source.String, externalID.String, bio.String
+			profile.PhotoURL, profile.PageURL = photoURL.String, pageURL.String
+			ret = append(ret, profile)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// MergeAuthors merges the duplicate authors into the canonical one. The book-author relations and the aliases are
+// re-pointed to the canonical author, the duplicate names become its aliases, and the duplicate authors are removed.
+// The canonical author without a profile gets the first duplicate profile, the rest of the profiles are removed.
+func (s PostgresStore) MergeAuthors(ctx context.Context, canonicalID int64, duplicateIDs []int64) error {
+	if canonicalID == 0 || len(duplicateIDs) == 0 {
+		return fmt.Errorf("there is no canonicalID: %d or duplicateIDs: %v", canonicalID, duplicateIDs)
+	}
+	for _, duplicateID := range duplicateIDs {
+		if duplicateID == canonicalID {
+			return fmt.Errorf("the canonical author %d should not be a duplicate", canonicalID)
 		}
+	}
 
-		stmt, err = tx.PrepareContext(txCtx, "INSERT INTO ebook.authors(name) VALUES ($1) RETURNING id")
+	return transaction.WithTransaction(ctx, s.db, func(txCtx context.Context, tx *sql.Tx) error {
+		authorIDs := append([]int64{canonicalID}, duplicateIDs...)
+		names, err := s.findAuthorNames(txCtx, tx, authorIDs)
 		if err != nil {
 			return err
 		}
-		defer s.closeResource(stmt)
-
-		for _, author := range authors {
-			if existingID, ok := existingAuthors[author]; ok {
-				ret = append(ret, existingID)
-				continue
+		for _, authorID := range authorIDs {
+			if _, ok := names[authorID]; !ok {
+				return fmt.Errorf("can not find the author to merge: %d", authorID)
 			}
-			var lastInsertId int64
-			if err := stmt.QueryRowContext(txCtx, author).Scan(&lastInsertId); err != nil {
-				return err
+		}
+
+		// The canonical author could already have the same role in the same book
+		_, err = tx.ExecContext(txCtx, `INSERT INTO ebook.book_author(book_id, author_id, role, author_order)
+			SELECT book_id, $1, role, MIN(author_order) FROM ebook.book_author WHERE author_id = ANY ($2)
+			GROUP BY book_id, role ON CONFLICT DO NOTHING`, canonicalID, pq.Array(duplicateIDs))
+		if err != nil {
+			return fmt.Errorf("can not re-point book authors: %w", err)
+		}
+		_, err = tx.ExecContext(txCtx, "UPDATE ebook.author_aliases SET author_id = $1 WHERE author_id = ANY ($2)",
+			canonicalID, pq.Array(duplicateIDs))
+		if err != nil {
+			return fmt.Errorf("can not re-point author aliases: %w", err)
+		}
+		for _, authorID := range authorIDs {
+			_, err = tx.ExecContext(txCtx, `INSERT INTO ebook.author_aliases(alias_key, alias, author_id)
+				VALUES ($1, $2, $3) ON CONFLICT (alias_key) DO UPDATE SET author_id = EXCLUDED.author_id`,
+				NameKey(names[authorID]), names[authorID], canonicalID)
+			if err != nil {
+				return fmt.Errorf("can not add author alias %q: %w", names[authorID], err)
 			}
-			ret = append(ret, lastInsertId)
 		}
+		_, err = tx.ExecContext(txCtx, `UPDATE ebook.author_profiles SET author_id = $1
+			WHERE author_id = (SELECT MIN(author_id) FROM ebook.author_profiles WHERE author_id = ANY ($2))
+			AND NOT EXISTS (SELECT 1 FROM ebook.author_profiles WHERE author_id = $1)`,
+			canonicalID, pq.Array(duplicateIDs))
+		if err != nil {
+			return fmt.Errorf("can not move author profile: %w", err)
+		}
+		// The rest of the duplicate book-author relations and profiles are removed in cascade
+		_, err = tx.ExecContext(txCtx, "DELETE FROM ebook.authors WHERE id = ANY ($1)", pq.Array(duplicateIDs))
+		if err != nil {
+			return fmt.Errorf("can not remove duplicate authors: %w", err)
+		}
+		s.logger.Printf("[INFO] - Merged authors %d into author %d", duplicateIDs, canonicalID)
 
 		return nil
 	})
+}
+
+func (s PostgresStore) findAuthorNames(txCtx context.Context, tx *sql.Tx, authorIDs []int64) (map[int64]string,
+	error) {
 
+	rows, err := tx.QueryContext(txCtx, "SELECT id, name FROM ebook.authors WHERE id = ANY ($1)",
+		pq.Array(authorIDs))
 	if err != nil {
 		return nil, err
 	}
-	s.logger.Printf("[INFO] - Stored author IDs: %d", ret)
+	defer s.closeResource(rows)
 
-	return ret, nil
+	names := make(map[int64]string)
+	for rows.Next() {
+		var ID int64
+		var name string
+		if err := rows.Scan(&ID, &name); err != nil {
+			return nil, err
+		}
+		names[ID] = name
+	}
+
+	return names, rows.Err()
 }
 
 // ReplaceBookAuthors removes all records from the book-author join table for the particular book.
-// And adds new records for all authors from the input slice.
-func (s PostgresStore) ReplaceBookAuthors(ctx context.Context, bookID int64, authorIDs []int64) error {
-	if bookID == 0 || len(authorIDs) == 0 {
-		return fmt.Errorf("there is no bookID: %q or authorIDs: %v", bookID, authorIDs)
+// And adds new records for all authors from the input slice, keeping their roles and the byline order.
+func (s PostgresStore) ReplaceBookAuthors(ctx context.Context, bookID int64, bookAuthors []BookAuthor) error {
+	if bookID == 0 || len(bookAuthors) == 0 {
+		return fmt.Errorf("there is no bookID: %q or bookAuthors: %v", bookID, bookAuthors)
 	}
 
 	return transaction.WithTransaction(ctx, s.db, func(txCtx context.Context, tx *sql.Tx) error {
@@ -102,14 +389,24 @@ func (s PostgresStore) ReplaceBookAuthors(ctx context.Context, bookID int64, aut
 			return err
 		}
 
-		insertStmt, err := tx.PrepareContext(txCtx, "INSERT INTO ebook.book_author(book_id, author_id) VALUES ($1, $2)")
+		insertStmt, err := tx.PrepareContext(txCtx,
+			"INSERT INTO ebook.book_author(book_id, author_id, role, author_order) VALUES ($1, $2, $3, $4)")
 		if err != nil {
 			return err
 		}
 		defer s.closeResource(insertStmt)
 
-		for _, authorID := range authorIDs {
-			_, err := insertStmt.ExecContext(txCtx, bookID, authorID)
+		stored := make(map[BookAuthor]bool)
+		for i, bookAuthor := range bookAuthors {
+			if bookAuthor.Role == "" {
+				bookAuthor.Role = parser.ContributorRoleAuthor
+			}
+			// The same person could be mentioned twice, like: 'Jane Doe (Author)' and 'Jane Doe (Author, Editor)'
+			if stored[bookAuthor] {
+				continue
+			}
+			stored[bookAuthor] = true
+			_, err := insertStmt.ExecContext(txCtx, bookID, bookAuthor.AuthorID, bookAuthor.Role, i)
 			if err != nil {
 				return err
 			}
@@ -125,3 +422,7 @@ func (s PostgresStore) closeResource(rows io.Closer) {
 		s.logger.Printf("[ERROR] - %v", err)
 	}
 }
+
+func getNullableString(val string) sql.NullString {
+	return sql.NullString{String: val, Valid: val != ""}
+}
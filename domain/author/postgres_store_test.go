@@ -16,8 +16,16 @@ const (
 	testBookID = 1
 )
 
+const (
+	selectAliasesQuery     = "SELECT author_id, alias_key FROM ebook.author_aliases WHERE alias_key = ANY \\(\\$1\\)"
+	selectNamedAuthorQuery = "SELECT id, name FROM ebook.authors WHERE name = ANY \\(\\$1\\) ORDER BY id"
+	insertAliasQueryRegex  = "INSERT INTO ebook.author_aliases\\(alias_key, alias, author_id\\) " +
+		"VALUES \\(\\$1, \\$2, \\$3\\)\\s+ON CONFLICT \\(alias_key\\) DO NOTHING"
+)
+
 var (
 	authorsToUpsert = []string{"Bob", "John"}
+	authorKeys      = []string{"bob", "john"}
 )
 
 func TestStore_UpsertAll(t *testing.T) {
@@ -25,6 +33,7 @@ func TestStore_UpsertAll(t *testing.T) {
 	t.Run("Upsert two new records", testUpsertAllBothNew)
 	t.Run("Upsert one new and one existing records", testUpsertAllOneNew)
 	t.Run("Upsert two existing records", testUpsertAllBothExisting)
+	t.Run("Upsert two variants of the same author name", testUpsertAllNameVariants)
 	t.Run("Do not upsert authors with empty author slice", testUpsertAllNoAuthors)
 }
 
@@ -34,7 +43,6 @@ func testUpsertAllBothNew(t *testing.T) {
 	var newAuthorID01 int64 = 1
 	var newAuthorID02 int64 = 2
 	expectedIDs := []int64{newAuthorID01, newAuthorID02}
-	rowsSelected := sqlmock.NewRows([]string{"id", "name"})
 	rowsInserted := sqlmock.NewRows([]string{"id"})
 
 	db, mock := initMockDB(t)
@@ -42,15 +50,18 @@ func testUpsertAllBothNew(t *testing.T) {
 	store := NewPostgresStore(db, log.Default())
 
 	mock.ExpectBegin()
-	selectPrepare := mock.ExpectPrepare("SELECT id, name FROM ebook.authors WHERE name = ANY \\(\\$1\\)").
-		WillBeClosed()
-	selectPrepare.ExpectQuery().WithArgs(pq.Array(authorsToUpsert)).WillReturnRows(rowsSelected).RowsWillBeClosed()
+	expectAuthorLookup(mock, authorKeys, sqlmock.NewRows([]string{"author_id", "alias_key"}),
+		authorsToUpsert, sqlmock.NewRows([]string{"id", "name"}))
 
 	// Two new inserts
-	insertPrepare := mock.ExpectPrepare("INSERT INTO ebook.authors\\(name\\) VALUES \\(\\$1\\) RETURNING id").
-		WillBeClosed()
+	insertPrepare := mock.ExpectPrepare(insertAuthorQuery).WillBeClosed()
+	aliasPrepare := mock.ExpectPrepare(insertAliasQueryRegex).WillBeClosed()
 	insertPrepare.ExpectQuery().WithArgs(authorsToUpsert[0]).WillReturnRows(rowsInserted.AddRow(newAuthorID01))
+	aliasPrepare.ExpectExec().WithArgs(authorKeys[0], authorsToUpsert[0], newAuthorID01).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	insertPrepare.ExpectQuery().WithArgs(authorsToUpsert[1]).WillReturnRows(rowsInserted.AddRow(newAuthorID02))
+	aliasPrepare.ExpectExec().WithArgs(authorKeys[1], authorsToUpsert[1], newAuthorID02).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	authorIDs, err := store.UpsertAll(context.Background(), authorsToUpsert)
@@ -70,7 +81,6 @@ func testUpsertAllOneNew(t *testing.T) {
 	var existingAuthorID int64 = 1
 	var newAuthorID int64 = 2
 	expectedIDs := []int64{existingAuthorID, newAuthorID}
-	rowsSelected := sqlmock.NewRows([]string{"id", "name"})
 	rowsInserted := sqlmock.NewRows([]string{"id"})
 
 	db, mock := initMockDB(t)
@@ -78,16 +88,16 @@ func testUpsertAllOneNew(t *testing.T) {
 	store := NewPostgresStore(db, log.Default())
 
 	mock.ExpectBegin()
-	selectPrepare := mock.ExpectPrepare("SELECT id, name FROM ebook.authors WHERE name = ANY \\(\\$1\\)").
-		WillBeClosed()
-	selectQuery := selectPrepare.ExpectQuery().WithArgs(pq.Array(authorsToUpsert))
-	rowToReturn := rowsSelected.AddRow(existingAuthorID, authorsToUpsert[0])
-	selectQuery.WillReturnRows(rowToReturn).RowsWillBeClosed()
+	expectAuthorLookup(mock, authorKeys,
+		sqlmock.NewRows([]string{"author_id", "alias_key"}).AddRow(existingAuthorID, authorKeys[0]),
+		authorsToUpsert, sqlmock.NewRows([]string{"id", "name"}))
 
 	// One new insert
-	insertPrepare := mock.ExpectPrepare("INSERT INTO ebook.authors\\(name\\) VALUES \\(\\$1\\) RETURNING id").
-		WillBeClosed()
+	insertPrepare := mock.ExpectPrepare(insertAuthorQuery).WillBeClosed()
+	aliasPrepare := mock.ExpectPrepare(insertAliasQueryRegex).WillBeClosed()
 	insertPrepare.ExpectQuery().WithArgs(authorsToUpsert[1]).WillReturnRows(rowsInserted.AddRow(newAuthorID))
+	aliasPrepare.ExpectExec().WithArgs(authorKeys[1], authorsToUpsert[1], newAuthorID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	authorIDs, err := store.UpsertAll(context.Background(), authorsToUpsert)
@@ -107,23 +117,25 @@ func testUpsertAllBothExisting(t *testing.T) {
 	var existingAuthorID01 int64 = 1
 	var existingAuthorID02 int64 = 2
 	expectedIDs := []int64{existingAuthorID01, existingAuthorID02}
-	rowsSelected := sqlmock.NewRows([]string{"id", "name"})
 
 	db, mock := initMockDB(t)
 	defer db.Close()
 	store := NewPostgresStore(db, log.Default())
 
+	// The first author has an alias, the second one is stored before the aliases were introduced
 	mock.ExpectBegin()
-	selectPrepare := mock.ExpectPrepare("SELECT id, name FROM ebook.authors WHERE name = ANY \\(\\$1\\)").
-		WillBeClosed()
-	selectQuery := selectPrepare.ExpectQuery().WithArgs(pq.Array(authorsToUpsert))
-	rowToReturn := rowsSelected.
-		AddRow(existingAuthorID01, authorsToUpsert[0]).
-		AddRow(existingAuthorID02, authorsToUpsert[1])
-	selectQuery.WillReturnRows(rowToReturn).RowsWillBeClosed()
-
-	// No new inserts
-	mock.ExpectPrepare("INSERT INTO ebook.authors\\(name\\) VALUES \\(\\$1\\) RETURNING id").WillBeClosed()
+	expectAuthorLookup(mock, authorKeys,
+		sqlmock.NewRows([]string{"author_id", "alias_key"}).AddRow(existingAuthorID01, authorKeys[0]),
+		authorsToUpsert,
+		sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(existingAuthorID01, authorsToUpsert[0]).
+			AddRow(existingAuthorID02, authorsToUpsert[1]))
+
+	// No new inserts, one new alias
+	mock.ExpectPrepare(insertAuthorQuery).WillBeClosed()
+	aliasPrepare := mock.ExpectPrepare(insertAliasQueryRegex).WillBeClosed()
+	aliasPrepare.ExpectExec().WithArgs(authorKeys[1], authorsToUpsert[1], existingAuthorID02).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	authorIDs, err := store.UpsertAll(context.Background(), authorsToUpsert)
@@ -137,6 +149,41 @@ func testUpsertAllBothExisting(t *testing.T) {
 	t.Logf("\t\t%s\tShould be able to add authors", succeed)
 }
 
+func testUpsertAllNameVariants(t *testing.T) {
+	t.Logf("\t\tWhen checking for insertion of two variants of the same author name\n")
+
+	var newAuthorID int64 = 1
+	authors := []string{"Smith, John", "JOHN SMITH"}
+	lookupNames := []string{"John Smith", "Smith, John", "John Smith", "JOHN SMITH"}
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	expectAuthorLookup(mock, []string{"john smith", "john smith"},
+		sqlmock.NewRows([]string{"author_id", "alias_key"}), lookupNames, sqlmock.NewRows([]string{"id", "name"}))
+
+	// One new insert, the second variant gets the same ID
+	insertPrepare := mock.ExpectPrepare(insertAuthorQuery).WillBeClosed()
+	aliasPrepare := mock.ExpectPrepare(insertAliasQueryRegex).WillBeClosed()
+	insertPrepare.ExpectQuery().WithArgs("John Smith").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newAuthorID))
+	aliasPrepare.ExpectExec().WithArgs("john smith", "John Smith", newAuthorID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	authorIDs, err := store.UpsertAll(context.Background(), authors)
+	if err != nil {
+		t.Errorf("\t\t%s\tShould be able to get upserted author IDs: %v", failed, err)
+	}
+
+	assertAuthorIDs(t, []int64{newAuthorID, newAuthorID}, authorIDs)
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to add a single author for the name variants", succeed)
+}
+
 func testUpsertAllNoAuthors(t *testing.T) {
 	db, _ := initMockDB(t)
 	defer db.Close()
@@ -154,6 +201,360 @@ func testUpsertAllNoAuthors(t *testing.T) {
 	t.Logf("\t\t%s\tShould return an empty result when there are no authors", succeed)
 }
 
+const (
+	selectProfileQuery      = "SELECT author_id FROM ebook.author_profiles WHERE source = \\$1 AND external_id = \\$2"
+	updateProfileQuery      = "UPDATE ebook.author_profiles"
+	selectUnprofiledQuery   = "SELECT a.id FROM ebook.authors a LEFT JOIN ebook.author_profiles p"
+	selectAuthorByNameQuery = "SELECT id FROM ebook.authors WHERE name = \\$1 ORDER BY id LIMIT 1"
+	selectAliasQuery        = "SELECT author_id FROM ebook.author_aliases WHERE alias_key = \\$1"
+	insertAuthorQuery       = "INSERT INTO ebook.authors\\(name\\) VALUES \\(\\$1\\) RETURNING id"
+	insertProfileQuery      = "INSERT INTO ebook.author_profiles"
+	testAuthorSource        = "amazon"
+	testAuthorExternalID    = "B000000001"
+	testAuthorBio           = "Test author bio"
+	testAuthorPhotoURL      = "https://photo.com/1.jpg"
+	testAuthorPageURL       = "https://www.amazon.com/stores/author/B000000001"
+	testAuthorName          = "Bob"
+	testAuthorKey           = "bob"
+	testAuthorProfileKey    = "bob|amazon:B000000001"
+	testAuthorID            = int64(1)
+)
+
+var testProfile = Profile{
+	Name:       testAuthorName,
+	Source:     testAuthorSource,
+	ExternalID: testAuthorExternalID,
+	Bio:        testAuthorBio,
+	PhotoURL:   testAuthorPhotoURL,
+	PageURL:    testAuthorPageURL,
+}
+
+func TestStore_UpsertProfiles(t *testing.T) {
+	t.Log("Given the need to test author profiles upsert")
+	t.Run("Upsert a new author with a profile", testUpsertProfilesNew)
+	t.Run("Upsert an author with an existing profile", testUpsertProfilesExisting)
+	t.Run("Upsert a profile for an existing author without a profile", testUpsertProfilesUnprofiled)
+	t.Run("Upsert two authors with the same name and different profiles", testUpsertProfilesSameName)
+	t.Run("Upsert an existing author without an external ID", testUpsertProfilesNameOnly)
+	t.Run("Upsert an existing author without an external ID and an alias", testUpsertProfilesNameOnlyNoAlias)
+}
+
+func testUpsertProfilesNew(t *testing.T) {
+	t.Logf("\t\tWhen checking for insertion of a new author with a profile\n")
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectProfileQuery).WithArgs(testAuthorSource, testAuthorExternalID).
+		WillReturnRows(sqlmock.NewRows([]string{"author_id"}))
+	mock.ExpectQuery(selectUnprofiledQuery).WithArgs(testAuthorName).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(insertAuthorQuery).WithArgs(testAuthorName).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testAuthorID))
+	mock.ExpectExec(insertAliasQueryRegex).WithArgs(testAuthorProfileKey, testAuthorName, testAuthorID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertProfileQuery).WithArgs(testAuthorID, testAuthorSource, testAuthorExternalID, testAuthorBio,
+		testAuthorPhotoURL, testAuthorPageURL).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	authorIDs, err := store.UpsertProfiles(context.Background(), []Profile{testProfile})
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get upserted author IDs: %v", failed, err)
+	}
+
+	assertAuthorIDs(t, []int64{testAuthorID}, authorIDs)
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to add an author with a profile", succeed)
+}
+
+func testUpsertProfilesExisting(t *testing.T) {
+	t.Logf("\t\tWhen checking for update of an existing author profile\n")
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectProfileQuery).WithArgs(testAuthorSource, testAuthorExternalID).
+		WillReturnRows(sqlmock.NewRows([]string{"author_id"}).AddRow(testAuthorID))
+	mock.ExpectExec(updateProfileQuery).
+		WithArgs(testAuthorID, testAuthorBio, testAuthorPhotoURL, testAuthorPageURL).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	authorIDs, err := store.UpsertProfiles(context.Background(), []Profile{testProfile})
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get upserted author IDs: %v", failed, err)
+	}
+
+	assertAuthorIDs(t, []int64{testAuthorID}, authorIDs)
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to update an author profile", succeed)
+}
+
+func testUpsertProfilesUnprofiled(t *testing.T) {
+	t.Logf("\t\tWhen checking for a profile of an existing author without a profile\n")
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectProfileQuery).WithArgs(testAuthorSource, testAuthorExternalID).
+		WillReturnRows(sqlmock.NewRows([]string{"author_id"}))
+	mock.ExpectQuery(selectUnprofiledQuery).WithArgs(testAuthorName).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testAuthorID))
+	mock.ExpectExec(insertAliasQueryRegex).WithArgs(testAuthorProfileKey, testAuthorName, testAuthorID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertProfileQuery).WithArgs(testAuthorID, testAuthorSource, testAuthorExternalID, testAuthorBio,
+		testAuthorPhotoURL, testAuthorPageURL).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	authorIDs, err := store.UpsertProfiles(context.Background(), []Profile{testProfile})
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get upserted author IDs: %v", failed, err)
+	}
+
+	assertAuthorIDs(t, []int64{testAuthorID}, authorIDs)
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to attach a profile to an existing author", succeed)
+}
+
+func testUpsertProfilesSameName(t *testing.T) {
+	t.Logf("\t\tWhen checking for insertion of two authors with the same name and different profiles\n")
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	// 'Smith, John' is normalized to 'John Smith', so both profiles have the same name key
+	profiles := []Profile{
+		{Name: "John Smith", Source: testAuthorSource, ExternalID: "B000000001"},
+		{Name: "Smith, John", Source: testAuthorSource, ExternalID: "B000000002"},
+	}
+	aliasKeys := []string{"john smith|amazon:B000000001", "john smith|amazon:B000000002"}
+	mock.ExpectBegin()
+	for i, profile := range profiles {
+		authorID := testAuthorID + int64(i)
+		mock.ExpectQuery(selectProfileQuery).WithArgs(testAuthorSource, profile.ExternalID).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id"}))
+		mock.ExpectQuery(selectUnprofiledQuery).WithArgs("John Smith").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(insertAuthorQuery).WithArgs("John Smith").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(authorID))
+		mock.ExpectExec(insertAliasQueryRegex).WithArgs(aliasKeys[i], "John Smith", authorID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(insertProfileQuery).WithArgs(authorID, testAuthorSource, profile.ExternalID, nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	authorIDs, err := store.UpsertProfiles(context.Background(), profiles)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get upserted author IDs: %v", failed, err)
+	}
+
+	assertAuthorIDs(t, []int64{testAuthorID, testAuthorID + 1}, authorIDs)
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to add the same name authors with separate aliases", succeed)
+}
+
+func testUpsertProfilesNameOnly(t *testing.T) {
+	t.Logf("\t\tWhen checking for an existing author without an external ID\n")
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectAliasQuery).WithArgs(testAuthorKey).
+		WillReturnRows(sqlmock.NewRows([]string{"author_id"}).AddRow(testAuthorID))
+	mock.ExpectCommit()
+
+	authorIDs, err := store.UpsertProfiles(context.Background(), []Profile{{Name: testAuthorName}})
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get upserted author IDs: %v", failed, err)
+	}
+
+	assertAuthorIDs(t, []int64{testAuthorID}, authorIDs)
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to match an author by the alias", succeed)
+}
+
+func testUpsertProfilesNameOnlyNoAlias(t *testing.T) {
+	t.Logf("\t\tWhen checking for an existing author without an external ID and an alias\n")
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectAliasQuery).WithArgs(testAuthorKey).WillReturnRows(sqlmock.NewRows([]string{"author_id"}))
+	mock.ExpectQuery(selectAuthorByNameQuery).WithArgs(testAuthorName).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testAuthorID))
+	mock.ExpectExec(insertAliasQueryRegex).WithArgs(testAuthorKey, testAuthorName, testAuthorID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	authorIDs, err := store.UpsertProfiles(context.Background(), []Profile{{Name: testAuthorName}})
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get upserted author IDs: %v", failed, err)
+	}
+
+	assertAuthorIDs(t, []int64{testAuthorID}, authorIDs)
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to match an author by the name", succeed)
+}
+
+func TestStore_FindByName(t *testing.T) {
+	t.Log("Given the need to test authors search by name")
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	rows := sqlmock.NewRows([]string{"id", "name", "source", "external_id", "bio", "photo_url", "page_url"}).
+		AddRow(testAuthorID, testAuthorName, testAuthorSource, testAuthorExternalID, testAuthorBio,
+			testAuthorPhotoURL, testAuthorPageURL).
+		AddRow(testAuthorID+1, testAuthorName, nil, nil, nil, nil, nil)
+	mock.ExpectBegin()
+	selectPrepare := mock.ExpectPrepare("SELECT a.id, a.name, p.source, p.external_id").WillBeClosed()
+	selectPrepare.ExpectQuery().WithArgs(testAuthorName, testAuthorKey).WillReturnRows(rows).RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	profiles, err := store.FindByName(context.Background(), testAuthorName)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to find authors: %v", failed, err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("\t\t%s\tShould find %d authors with the same name: %d", failed, 2, len(profiles))
+	}
+	expectedProfile := testProfile
+	expectedProfile.ID = testAuthorID
+	if profiles[0] != expectedProfile {
+		t.Fatalf("\t\t%s\tShould get a %+v author profile: %+v", failed, expectedProfile, profiles[0])
+	}
+	if profiles[1].HasExternalID() {
+		t.Fatalf("\t\t%s\tShould get an author without a profile: %+v", failed, profiles[1])
+	}
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to find authors by name", succeed)
+}
+
+func TestStore_FindAll(t *testing.T) {
+	t.Log("Given the need to test all authors search")
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	rows := sqlmock.NewRows([]string{"id", "name", "source", "external_id", "bio", "photo_url", "page_url"}).
+		AddRow(testAuthorID, testAuthorName, nil, nil, nil, nil, nil).
+		AddRow(testAuthorID+1, "John", nil, nil, nil, nil, nil)
+	mock.ExpectBegin()
+	selectPrepare := mock.ExpectPrepare("SELECT a.id, a.name, p.source, p.external_id").WillBeClosed()
+	selectPrepare.ExpectQuery().WithArgs().WillReturnRows(rows).RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	profiles, err := store.FindAll(context.Background())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to find authors: %v", failed, err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("\t\t%s\tShould find %d authors: %d", failed, 2, len(profiles))
+	}
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to find all authors", succeed)
+}
+
+func TestStore_MergeAuthors(t *testing.T) {
+	t.Log("Given the need to test authors merge")
+	t.Run("Successfully merge duplicate authors", testMergeAuthors)
+	t.Run("Failed to merge missing authors", testMergeAuthorsErrorNotFound)
+	t.Run("Failed to merge the canonical author into itself", testMergeAuthorsErrorSameAuthor)
+}
+
+func testMergeAuthors(t *testing.T) {
+	t.Logf("\t\tWhen checking for merge of two duplicate authors\n")
+
+	var canonicalID, duplicateID01, duplicateID02 int64 = 1, 2, 3
+	duplicateIDs := []int64{duplicateID01, duplicateID02}
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, name FROM ebook.authors WHERE id = ANY \\(\\$1\\)").
+		WithArgs(pq.Array([]int64{canonicalID, duplicateID01, duplicateID02})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(canonicalID, "John Smith").
+			AddRow(duplicateID01, "Smith, John").
+			AddRow(duplicateID02, "J. Smith"))
+	mock.ExpectExec("INSERT INTO ebook.book_author").WithArgs(canonicalID, pq.Array(duplicateIDs)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("UPDATE ebook.author_aliases SET author_id = \\$1").WithArgs(canonicalID, pq.Array(duplicateIDs)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO ebook.author_aliases").WithArgs("john smith", "John Smith", canonicalID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO ebook.author_aliases").WithArgs("john smith", "Smith, John", canonicalID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO ebook.author_aliases").WithArgs("j smith", "J. Smith", canonicalID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE ebook.author_profiles SET author_id = \\$1").
+		WithArgs(canonicalID, pq.Array(duplicateIDs)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM ebook.authors WHERE id = ANY \\(\\$1\\)").WithArgs(pq.Array(duplicateIDs)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	if err := store.MergeAuthors(context.Background(), canonicalID, duplicateIDs); err != nil {
+		t.Fatalf("\t\t%s\tShould be able to merge authors: %v", failed, err)
+	}
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to merge authors", succeed)
+}
+
+func testMergeAuthorsErrorNotFound(t *testing.T) {
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, name FROM ebook.authors WHERE id = ANY \\(\\$1\\)").
+		WithArgs(pq.Array([]int64{testAuthorID, testAuthorID + 1})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(testAuthorID, testAuthorName))
+	mock.ExpectRollback()
+
+	if err := store.MergeAuthors(context.Background(), testAuthorID, []int64{testAuthorID + 1}); err == nil {
+		t.Fatalf("\t\t%s\tAn error is expected when the author is not found", failed)
+	}
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould return an error when the author is not found", succeed)
+}
+
+func testMergeAuthorsErrorSameAuthor(t *testing.T) {
+	db, _ := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	if err := store.MergeAuthors(context.Background(), testAuthorID, []int64{testAuthorID}); err == nil {
+		t.Fatalf("\t\t%s\tAn error is expected when the canonical author is a duplicate", failed)
+	}
+
+	t.Logf("\t\t%s\tShould return an error when the canonical author is a duplicate", succeed)
+}
+
 func TestStore_ReplaceBookAuthors(t *testing.T) {
 	t.Logf("\t\tWhen checking for book-author relations replacement\n")
 	t.Run("Successfully replace book-author relations", testReplaceBookAuthors)
@@ -164,7 +565,8 @@ func TestStore_ReplaceBookAuthors(t *testing.T) {
 func testReplaceBookAuthors(t *testing.T) {
 	var newAuthorID01 int64 = 1
 	var newAuthorID02 int64 = 2
-	authorIDs := []int64{newAuthorID01, newAuthorID02}
+	bookAuthors := []BookAuthor{{AuthorID: newAuthorID01}, {AuthorID: newAuthorID02, Role: "editor"},
+		{AuthorID: newAuthorID02, Role: "editor"}}
 
 	db, mock := initMockDB(t)
 	defer db.Close()
@@ -174,13 +576,16 @@ func testReplaceBookAuthors(t *testing.T) {
 	deletePrepare := mock.ExpectPrepare("DELETE FROM ebook.book_author WHERE book_id = \\$1").WillBeClosed()
 	deletePrepare.ExpectExec().WithArgs(testBookID).WillReturnResult(sqlmock.NewResult(0, 1))
 	insertPrepare := mock.
-		ExpectPrepare("INSERT INTO ebook.book_author\\(book_id, author_id\\) VALUES \\(\\$1, \\$2\\)").
+		ExpectPrepare("INSERT INTO ebook.book_author\\(book_id, author_id, role, author_order\\) " +
+			"VALUES \\(\\$1, \\$2, \\$3, \\$4\\)").
 		WillBeClosed()
-	insertPrepare.ExpectExec().WithArgs(testBookID, newAuthorID01).WillReturnResult(sqlmock.NewResult(0, 1))
-	insertPrepare.ExpectExec().WithArgs(testBookID, newAuthorID02).WillReturnResult(sqlmock.NewResult(0, 1))
+	insertPrepare.ExpectExec().WithArgs(testBookID, newAuthorID01, "author", 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	insertPrepare.ExpectExec().WithArgs(testBookID, newAuthorID02, "editor", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	err := store.ReplaceBookAuthors(context.Background(), testBookID, authorIDs)
+	err := store.ReplaceBookAuthors(context.Background(), testBookID, bookAuthors)
 	if err != nil {
 		t.Errorf("\t\t%s\tShould be able to add new book-author relatons: %v", failed, err)
 	}
@@ -194,7 +599,7 @@ func testReplaceBookAuthorsErrorNoAuthors(t *testing.T) {
 	defer db.Close()
 	store := NewPostgresStore(db, log.Default())
 
-	err := store.ReplaceBookAuthors(context.Background(), testBookID, []int64{})
+	err := store.ReplaceBookAuthors(context.Background(), testBookID, []BookAuthor{})
 	if err == nil {
 		t.Fatalf("\t\t%s\tAn error is expected when there are no authors", failed)
 	}
@@ -207,7 +612,7 @@ func testReplaceBookAuthorsErrorNoBookID(t *testing.T) {
 	defer db.Close()
 	store := NewPostgresStore(db, log.Default())
 
-	err := store.ReplaceBookAuthors(context.Background(), 0, []int64{1, 2})
+	err := store.ReplaceBookAuthors(context.Background(), 0, []BookAuthor{{AuthorID: 1}, {AuthorID: 2}})
 	if err == nil {
 		t.Fatalf("\t\t%s\tAn error is expected when there is no book ID", failed)
 	}
@@ -215,6 +620,15 @@ func testReplaceBookAuthorsErrorNoBookID(t *testing.T) {
 	t.Logf("\t\t%s\tShould return an error when there is no book ID", succeed)
 }
 
+func expectAuthorLookup(mock sqlmock.Sqlmock, keys []string, aliasRows *sqlmock.Rows, names []string,
+	nameRows *sqlmock.Rows) {
+
+	aliasPrepare := mock.ExpectPrepare(selectAliasesQuery).WillBeClosed()
+	aliasPrepare.ExpectQuery().WithArgs(pq.Array(keys)).WillReturnRows(aliasRows).RowsWillBeClosed()
+	namePrepare := mock.ExpectPrepare(selectNamedAuthorQuery).WillBeClosed()
+	namePrepare.ExpectQuery().WithArgs(pq.Array(names)).WillReturnRows(nameRows).RowsWillBeClosed()
+}
+
 func initMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -34,18 +34,62 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// FindAll mocks base method.
+func (m *MockStore) FindAll(ctx context.Context) ([]Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockStoreMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockStore)(nil).FindAll), ctx)
+}
+
+// FindByName mocks base method.
+func (m *MockStore) FindByName(ctx context.Context, name string) ([]Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].([]Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockStoreMockRecorder) FindByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockStore)(nil).FindByName), ctx, name)
+}
+
+// MergeAuthors mocks base method.
+func (m *MockStore) MergeAuthors(ctx context.Context, canonicalID int64, duplicateIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeAuthors", ctx, canonicalID, duplicateIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeAuthors indicates an expected call of MergeAuthors.
+func (mr *MockStoreMockRecorder) MergeAuthors(ctx, canonicalID, duplicateIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeAuthors", reflect.TypeOf((*MockStore)(nil).MergeAuthors), ctx, canonicalID, duplicateIDs)
+}
+
 // ReplaceBookAuthors mocks base method.
-func (m *MockStore) ReplaceBookAuthors(ctx context.Context, bookID int64, authorIDs []int64) error {
+func (m *MockStore) ReplaceBookAuthors(ctx context.Context, bookID int64, bookAuthors []BookAuthor) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReplaceBookAuthors", ctx, bookID, authorIDs)
+	ret := m.ctrl.Call(m, "ReplaceBookAuthors", ctx, bookID, bookAuthors)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ReplaceBookAuthors indicates an expected call of ReplaceBookAuthors.
-func (mr *MockStoreMockRecorder) ReplaceBookAuthors(ctx, bookID, authorIDs interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) ReplaceBookAuthors(ctx, bookID, bookAuthors interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceBookAuthors", reflect.TypeOf((*MockStore)(nil).ReplaceBookAuthors), ctx, bookID, authorIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceBookAuthors", reflect.TypeOf((*MockStore)(nil).ReplaceBookAuthors), ctx, bookID, bookAuthors)
 }
 
 // UpsertAll mocks base method.
@@ -62,3 +106,18 @@ func (mr *MockStoreMockRecorder) UpsertAll(ctx, authors interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAll", reflect.TypeOf((*MockStore)(nil).UpsertAll), ctx, authors)
 }
+
+// UpsertProfiles mocks base method.
+func (m *MockStore) UpsertProfiles(ctx context.Context, profiles []Profile) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProfiles", ctx, profiles)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertProfiles indicates an expected call of UpsertProfiles.
+func (mr *MockStoreMockRecorder) UpsertProfiles(ctx, profiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProfiles", reflect.TypeOf((*MockStore)(nil).UpsertProfiles), ctx, profiles)
+}
@@ -5,5 +5,33 @@ import "context"
 //go:generate mockgen -destination=./store_mock.go -package=author github.com/sdreger/lib-file-processor-go/domain/author Store
 type Store interface {
 	UpsertAll(ctx context.Context, authors []string) ([]int64, error)
-	ReplaceBookAuthors(ctx context.Context, bookID int64, authorIDs []int64) error
+	UpsertProfiles(ctx context.Context, profiles []Profile) ([]int64, error)
+	FindByName(ctx context.Context, name string) ([]Profile, error)
+	FindAll(ctx context.Context) ([]Profile, error)
+	MergeAuthors(ctx context.Context, canonicalID int64, duplicateIDs []int64) error
+	ReplaceBookAuthors(ctx context.Context, bookID int64, bookAuthors []BookAuthor) error
+}
+
+// Profile is an author with the extended information from the author page of a book data source.
+// The source and the external ID identify the author, so different authors with the same name are kept apart.
+type Profile struct {
+	ID         int64
+	Name       string
+	Source     string
+	ExternalID string
+	Bio        string
+	PhotoURL   string
+	PageURL    string
+}
+
+// BookAuthor is a contributor of a particular book: an author, an editor, a translator, etc.
+// An empty role means the book author.
+type BookAuthor struct {
+	AuthorID int64
+	Role     string
+}
+
+// HasExternalID checks if the author is identified by the book data source.
+func (p Profile) HasExternalID() bool {
+	return p.Source != "" && p.ExternalID != ""
 }
@@ -0,0 +1,105 @@
+package book
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDiagnosticRawLength is the number of characters (not bytes) of a raw value, shown in the diagnostics report
+const maxDiagnosticRawLength = 60
+
+// FieldDiagnostic describes where a parsed field value comes from, and how it was parsed.
+type FieldDiagnostic struct {
+	Field         string // the ParsedData field name, like: 'ISBN13'
+	Source        string // the book data source, like: 'amazon.com'
+	Selector      string // the page selector, the details key or the API field the raw value is taken from
+	Raw           string // the raw value, before parsing
+	Rule          string // the parsing rule applied to the raw value
+	LowConfidence bool   // the value is taken from a fallback, or the raw value could not be parsed cleanly
+}
+
+// Diagnostics is the book data scrape report: the provenance of every parsed field,
+// the parsing warnings and the missing required fields.
+type Diagnostics struct {
+	Fields          []FieldDiagnostic
+	Warnings        []string
+	MissingRequired []string
+}
+
+// AddField adds the field diagnostic, replacing the existing one for the same field.
+func (d *Diagnostics) AddField(diagnostic FieldDiagnostic) {
+	for i := range d.Fields {
+		if d.Fields[i].Field == diagnostic.Field {
+			d.Fields[i] = diagnostic
+			return
+		}
+	}
+	d.Fields = append(d.Fields, diagnostic)
+}
+
+// AddWarning adds a parsing warning.
+func (d *Diagnostics) AddWarning(format string, args ...interface{}) {
+	d.Warnings = append(d.Warnings, fmt.Sprintf(format, args...))
+}
+
+// SetSource sets the data source for the field diagnostics without one.
+func (d *Diagnostics) SetSource(source string) {
+	for i := range d.Fields {
+		if d.Fields[i].Source == "" {
+			d.Fields[i].Source = source
+		}
+	}
+}
+
+// GetField returns the diagnostic of the field, if any.
+func (d Diagnostics) GetField(field string) (FieldDiagnostic, bool) {
+	for _, diagnostic := range d.Fields {
+		if diagnostic.Field == field {
+			return diagnostic, true
+		}
+	}
+
+	return FieldDiagnostic{}, false
+}
+
+// LowConfidenceFields returns the names of the fields, which should be double-checked.
+func (d Diagnostics) LowConfidenceFields() []string {
+	fields := make([]string, 0)
+	for _, diagnostic := range d.Fields {
+		if diagnostic.LowConfidence {
+			fields = append(fields, diagnostic.Field)
+		}
+	}
+
+	return fields
+}
+
+func (d Diagnostics) IsEmpty() bool {
+	return len(d.Fields) == 0 && len(d.Warnings) == 0 && len(d.MissingRequired) == 0
+}
+
+func (d Diagnostics) String() string {
+	var b strings.Builder
+	for _, diagnostic := range d.Fields {
+		raw := diagnostic.Raw
+		if runes := []rune(raw); len(runes) > maxDiagnosticRawLength {
+			raw = string(runes[:maxDiagnosticRawLength]) + "..."
+		}
+		b.WriteString(fmt.Sprintf("%s: %s %q -> %q", diagnostic.Field, diagnostic.Source, diagnostic.Selector, raw))
+		if diagnostic.Rule != "" {
+			b.WriteString(fmt.Sprintf(" (%s)", diagnostic.Rule))
+		}
+		if diagnostic.LowConfidence {
+			b.WriteString(" [low confidence]")
+		}
+		b.WriteString("\n")
+	}
+	for _, warning := range d.Warnings {
+		b.WriteString(fmt.Sprintf("Warning: %s\n", warning))
+	}
+	for _, field := range d.MissingRequired {
+		b.WriteString(fmt.Sprintf("Missing required field: %s\n", field))
+	}
+
+	return b.String()
+}
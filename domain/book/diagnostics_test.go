@@ -0,0 +1,62 @@
+package book
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiagnostics(t *testing.T) {
+	t.Log("Given the need to test the book data diagnostics.")
+	var diagnostics Diagnostics
+	diagnostics.AddField(FieldDiagnostic{Field: "Title", Selector: "title", Raw: "Test title"})
+	diagnostics.AddField(FieldDiagnostic{Field: "Pages", Source: "amazon.de", Selector: "details[Paperback]",
+		Raw: "355 pages", LowConfidence: true})
+	diagnostics.AddField(FieldDiagnostic{Field: "Title", Selector: "title", Raw: "Test title: Test subtitle",
+		Rule: "ParseTitleString"})
+	diagnostics.AddWarning("can not parse %q", "Test publisher")
+	diagnostics.SetSource("amazon.com")
+
+	t.Logf("\t\tWhen checking for the field diagnostics\n")
+	{
+		if len(diagnostics.Fields) != 2 {
+			t.Fatalf("\t\t%s\tShould replace the existing field diagnostic: %+v", failed, diagnostics.Fields)
+		}
+		title, ok := diagnostics.GetField("Title")
+		if !ok || title.Rule != "ParseTitleString" || title.Source != "amazon.com" {
+			t.Fatalf("\t\t%s\tShould get the title diagnostic: %+v", failed, title)
+		}
+		pages, _ := diagnostics.GetField("Pages")
+		if pages.Source != "amazon.de" {
+			t.Fatalf("\t\t%s\tShould keep the pages diagnostic source: %q", failed, pages.Source)
+		}
+		if lowConfidence := diagnostics.LowConfidenceFields(); !reflect.DeepEqual(lowConfidence, []string{"Pages"}) {
+			t.Fatalf("\t\t%s\tShould get the low confidence fields: %v", failed, lowConfidence)
+		}
+		t.Logf("\t\t%s\tShould be able to get the field diagnostics", succeed)
+	}
+
+	t.Logf("\t\tWhen checking for the diagnostics report\n")
+	{
+		report := diagnostics.String()
+		if !strings.Contains(report, `Pages: amazon.de "details[Paperback]" -> "355 pages" [low confidence]`) {
+			t.Fatalf("\t\t%s\tShould get the low confidence field in the report: %s", failed, report)
+		}
+		if !strings.Contains(report, `Warning: can not parse "Test publisher"`) {
+			t.Fatalf("\t\t%s\tShould get the warning in the report: %s", failed, report)
+		}
+		t.Logf("\t\t%s\tShould be able to get the diagnostics report", succeed)
+	}
+
+	t.Logf("\t\tWhen checking for a long non-ASCII raw value in the diagnostics report\n")
+	{
+		var longDiagnostics Diagnostics
+		longDiagnostics.AddField(FieldDiagnostic{Field: "Title", Selector: "title",
+			Raw: strings.Repeat("Ü", maxDiagnosticRawLength+1)})
+		report := longDiagnostics.String()
+		if !strings.Contains(report, `"`+strings.Repeat("Ü", maxDiagnosticRawLength)+`..."`) {
+			t.Fatalf("\t\t%s\tShould truncate the raw value by characters: %s", failed, report)
+		}
+		t.Logf("\t\t%s\tShould be able to truncate the raw value by characters", succeed)
+	}
+}
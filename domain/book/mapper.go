@@ -1,5 +1,7 @@
 package book
 
+import "github.com/sdreger/lib-file-processor-go/parser"
+
 // mapToStoredData map an SQL dot product row to a StoredData struct. Collection fields are appended.
 func mapToStoredData(rowData dotProductRow, storedData *StoredData) {
 	if storedData.ID == 0 {
@@ -14,6 +16,12 @@ func mapToStoredData(rowData dotProductRow, storedData *StoredData) {
 	if storedData.Description == "" {
 		storedData.Description = rowData.Description
 	}
+	if storedData.DescriptionMarkdown == "" && rowData.DescriptionMarkdown.Valid {
+		storedData.DescriptionMarkdown = rowData.DescriptionMarkdown.String
+	}
+	if storedData.DescriptionText == "" && rowData.DescriptionText.Valid {
+		storedData.DescriptionText = rowData.DescriptionText.String
+	}
 	if storedData.ISBN10 == "" && rowData.ISBN10.Valid {
 		storedData.ISBN10 = rowData.ISBN10.String
 	}
@@ -23,6 +31,9 @@ func mapToStoredData(rowData dotProductRow, storedData *StoredData) {
 	if storedData.ASIN == "" && rowData.ASIN.Valid {
 		storedData.ASIN = rowData.ASIN.String
 	}
+	if storedData.DOI == "" && rowData.DOI.Valid {
+		storedData.DOI = rowData.DOI.String
+	}
 	if storedData.Pages == 0 {
 		storedData.Pages = rowData.Pages
 	}
@@ -35,11 +46,20 @@ func mapToStoredData(rowData dotProductRow, storedData *StoredData) {
 	if storedData.PublisherURL == "" {
 		storedData.PublisherURL = rowData.PublisherURL
 	}
-	if storedData.Edition == 0 {
-		storedData.Edition = rowData.Edition
+	if storedData.Edition.IsEmpty() {
+		storedData.Edition = parser.Edition{Number: rowData.Edition, Qualifier: rowData.EditionQualifier.String,
+			Year: uint16(rowData.EditionYear.Int32)}
 	}
+	// The publication date and its precision are kept together
 	if storedData.PubDate.IsZero() {
 		storedData.PubDate = rowData.PubDate
+		storedData.PubDatePrecision = rowData.PubDatePrecision
+	}
+	if storedData.Series == "" && rowData.Series.Valid {
+		storedData.Series = rowData.Series.String
+	}
+	if storedData.SeriesVolume == 0 && rowData.SeriesVolume.Valid {
+		storedData.SeriesVolume = uint16(rowData.SeriesVolume.Int32)
 	}
 	if storedData.BookFileName == "" {
 		storedData.BookFileName = rowData.BookFileName
@@ -56,8 +76,10 @@ func mapToStoredData(rowData dotProductRow, storedData *StoredData) {
 	if storedData.UpdatedAt.IsZero() {
 		storedData.UpdatedAt = rowData.UpdatedAt
 	}
+	// The joined categories, file types and tags repeat the contributor rows, the repeated ones are skipped
 	if rowData.AuthorName.Valid {
-		storedData.Authors = append(storedData.Authors, rowData.AuthorName.String)
+		storedData.Contributors = appendContributor(storedData.Contributors,
+			Contributor{Name: rowData.AuthorName.String, Role: rowData.AuthorRole.String})
 	}
 	if rowData.CategoryName.Valid {
 		storedData.Categories = append(storedData.Categories, rowData.CategoryName.String)
@@ -78,8 +100,11 @@ func mapToParsedDate(existingData *StoredData, parsedData *ParsedData) {
 	if parsedData.Subtitle == "" {
 		parsedData.Subtitle = existingData.Subtitle
 	}
+	// The description variants are kept together
 	if parsedData.Description == "" {
 		parsedData.Description = existingData.Description
+		parsedData.DescriptionMarkdown = existingData.DescriptionMarkdown
+		parsedData.DescriptionText = existingData.DescriptionText
 	}
 	if parsedData.ISBN10 == "" {
 		parsedData.ISBN10 = existingData.ISBN10
@@ -90,17 +115,27 @@ func mapToParsedDate(existingData *StoredData, parsedData *ParsedData) {
 	if parsedData.ASIN == "" {
 		parsedData.ASIN = existingData.ASIN
 	}
+	if parsedData.DOI == "" {
+		parsedData.DOI = existingData.DOI
+	}
 	if parsedData.Pages == 0 {
 		parsedData.Pages = existingData.Pages
 	}
 	if parsedData.PublisherURL == "" {
 		parsedData.PublisherURL = existingData.PublisherURL
 	}
-	if parsedData.Edition == 0 {
+	if parsedData.Edition.IsEmpty() {
 		parsedData.Edition = existingData.Edition
 	}
+	// The publication date and its precision are kept together
 	if parsedData.PubDate.IsZero() {
 		parsedData.PubDate = existingData.PubDate
+		parsedData.PubDatePrecision = existingData.PubDatePrecision
+	}
+	// The series name and volume are kept together
+	if parsedData.Series == "" {
+		parsedData.Series = existingData.Series
+		parsedData.SeriesVolume = existingData.SeriesVolume
 	}
 	if parsedData.BookFileName == "" {
 		parsedData.BookFileName = existingData.BookFileName
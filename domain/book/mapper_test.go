@@ -32,6 +32,9 @@ func TestMapToStoredData(t *testing.T) {
 	if output.ASIN != input.ASIN.String {
 		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.ASIN.String, output.ASIN)
 	}
+	if output.DOI != input.DOI.String {
+		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.DOI.String, output.DOI)
+	}
 	if output.Pages != input.Pages {
 		t.Errorf("\t\t%s\tShould get a %d mapped value: %d", failed, input.Pages, output.Pages)
 	}
@@ -44,12 +47,17 @@ func TestMapToStoredData(t *testing.T) {
 	if output.PublisherURL != input.PublisherURL {
 		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.PublisherURL, output.PublisherURL)
 	}
-	if output.Edition != input.Edition {
-		t.Errorf("\t\t%s\tShould get a %d mapped value: %d", failed, input.Edition, output.Edition)
+	if output.Edition.Number != input.Edition || output.Edition.Qualifier != input.EditionQualifier.String {
+		t.Errorf("\t\t%s\tShould get a %d %q mapped value: %v", failed, input.Edition, input.EditionQualifier.String,
+			output.Edition)
 	}
 	if output.PubDate != input.PubDate {
 		t.Errorf("\t\t%s\tShould get a %v mapped value: %v", failed, input.PubDate, output.PubDate)
 	}
+	if output.PubDatePrecision != input.PubDatePrecision {
+		t.Errorf("\t\t%s\tShould get a %v mapped value: %v", failed, input.PubDatePrecision,
+			output.PubDatePrecision)
+	}
 	if output.BookFileName != input.BookFileName {
 		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.BookFileName, output.BookFileName)
 	}
@@ -65,14 +73,15 @@ func TestMapToStoredData(t *testing.T) {
 	if output.UpdatedAt != input.UpdatedAt {
 		t.Errorf("\t\t%s\tShould get a %v mapped value: %v", failed, input.UpdatedAt, output.UpdatedAt)
 	}
-	if output.Authors[0] != input.AuthorName.String {
-		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.AuthorName.String, output.Authors[0])
+	expectedContributor := Contributor{Name: input.AuthorName.String, Role: input.AuthorRole.String}
+	if output.Contributors[0] != expectedContributor {
+		t.Errorf("\t\t%s\tShould get a %+v mapped value: %+v", failed, expectedContributor, output.Contributors[0])
 	}
 	if output.Categories[0] != input.CategoryName.String {
 		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.CategoryName.String, output.Categories[0])
 	}
 	if output.Formats[0] != input.FileTypeName.String {
-		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.FileTypeName.String, output.Authors[0])
+		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.FileTypeName.String, output.Formats[0])
 	}
 	if output.Tags[0] != input.TagName.String {
 		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.TagName.String, output.Tags[0])
@@ -105,6 +114,9 @@ func TestMapToParsedDate(t *testing.T) {
 	if output.ASIN != input.ASIN {
 		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.ASIN, output.ASIN)
 	}
+	if output.DOI != input.DOI {
+		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.DOI, output.DOI)
+	}
 	if output.Pages != input.Pages {
 		t.Errorf("\t\t%s\tShould get a %d mapped value: %d", failed, input.Pages, output.Pages)
 	}
@@ -112,11 +124,15 @@ func TestMapToParsedDate(t *testing.T) {
 		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.PublisherURL, output.PublisherURL)
 	}
 	if output.Edition != input.Edition {
-		t.Errorf("\t\t%s\tShould get a %d mapped value: %d", failed, input.Edition, output.Edition)
+		t.Errorf("\t\t%s\tShould get a %v mapped value: %v", failed, input.Edition, output.Edition)
 	}
 	if output.PubDate != input.PubDate {
 		t.Errorf("\t\t%s\tShould get a %v mapped value: %v", failed, input.PubDate, output.PubDate)
 	}
+	if output.PubDatePrecision != input.PubDatePrecision {
+		t.Errorf("\t\t%s\tShould get a %v mapped value: %v", failed, input.PubDatePrecision,
+			output.PubDatePrecision)
+	}
 	if output.BookFileName != input.BookFileName {
 		t.Errorf("\t\t%s\tShould get a %q mapped value: %q", failed, input.BookFileName, output.BookFileName)
 	}
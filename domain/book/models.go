@@ -4,7 +4,10 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/mantidtech/wordnumber"
+	"github.com/sdreger/lib-file-processor-go/domain/author"
+	"github.com/sdreger/lib-file-processor-go/parser"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -16,27 +19,52 @@ var (
 	multiDotCleanupRegex     = regexp.MustCompile(`\.{2,}`)
 )
 
+// Contributor is a book contributor name with the contribution role, like: 'editor'. A person with several roles,
+// like an author and an editor, is listed once per role.
+type Contributor struct {
+	Name string
+	Role string
+}
+
+// NewAuthors returns the contributors with the author role for the author names, in the byline order.
+func NewAuthors(names []string) []Contributor {
+	contributors := make([]Contributor, 0, len(names))
+	for _, name := range names {
+		contributors = append(contributors, Contributor{Name: name, Role: parser.ContributorRoleAuthor})
+	}
+
+	return contributors
+}
+
 type ParsedData struct {
-	Title         string
-	Subtitle      string
-	Description   string
-	ISBN10        string
-	ISBN13        int64
-	ASIN          string
-	Pages         uint16
-	Language      string
-	Publisher     string
-	PublisherURL  string
-	Edition       uint8
-	PubDate       time.Time
-	Authors       []string
-	Categories    []string
-	Tags          []string
-	Formats       []string
-	BookFileName  string
-	BookFileSize  int64
-	CoverFileName string
-	CoverURL      string
+	Title               string
+	Subtitle            string
+	Description         string
+	DescriptionMarkdown string
+	DescriptionText     string
+	ISBN10              string
+	ISBN13              int64
+	ASIN                string
+	DOI                 string
+	Pages               uint16
+	Language            string
+	Publisher           string
+	PublisherURL        string
+	Edition             parser.Edition
+	PubDate             time.Time
+	PubDatePrecision    parser.DatePrecision
+	Series              string
+	SeriesVolume        uint16
+	Contributors        []Contributor
+	AuthorProfiles      []author.Profile
+	Categories          []string
+	Tags                []string
+	Formats             []string
+	BookFileName        string
+	BookFileSize        int64
+	CoverFileName       string
+	CoverURL            string
+	Diagnostics         Diagnostics
 }
 
 func (pd ParsedData) GetPrimaryId() string {
@@ -62,16 +90,23 @@ func (pd ParsedData) String() string {
 		description = pd.Description
 	}
 	b.WriteString(fmt.Sprintf("\tDescription: %q\n", description))
+	b.WriteString(fmt.Sprintf("\tDescriptionMarkdown: %d chars\n", len(pd.DescriptionMarkdown)))
+	b.WriteString(fmt.Sprintf("\tDescriptionText: %d chars\n", len(pd.DescriptionText)))
 	b.WriteString(fmt.Sprintf("\tISBN10: %q\n", pd.ISBN10))
 	b.WriteString(fmt.Sprintf("\tISBN13: %d\n", pd.ISBN13))
 	b.WriteString(fmt.Sprintf("\tASIN: %q\n", pd.ASIN))
+	b.WriteString(fmt.Sprintf("\tDOI: %q\n", pd.DOI))
 	b.WriteString(fmt.Sprintf("\tPages: %d\n", pd.Pages))
 	b.WriteString(fmt.Sprintf("\tLanguage: %q\n", pd.Language))
 	b.WriteString(fmt.Sprintf("\tPublisher: %q\n", pd.Publisher))
 	b.WriteString(fmt.Sprintf("\tPublisherURL: %q\n", pd.PublisherURL))
-	b.WriteString(fmt.Sprintf("\tEdition: %d\n", pd.Edition))
+	b.WriteString(fmt.Sprintf("\tEdition: %s\n", pd.Edition))
 	b.WriteString(fmt.Sprintf("\tPubDate: %q\n", pd.PubDate.Format("_2 Jan 2006")))
-	b.WriteString(fmt.Sprintf("\tAuthors: %q\n", strings.Join(pd.Authors, ",")))
+	b.WriteString(fmt.Sprintf("\tPubDatePrecision: %s\n", pd.PubDatePrecision))
+	b.WriteString(fmt.Sprintf("\tSeries: %q\n", pd.Series))
+	b.WriteString(fmt.Sprintf("\tSeriesVolume: %d\n", pd.SeriesVolume))
+	b.WriteString(fmt.Sprintf("\tAuthors: %q\n", strings.Join(pd.GetContributors(), ",")))
+	b.WriteString(fmt.Sprintf("\tAuthorProfiles: %d\n", len(pd.AuthorProfiles)))
 	b.WriteString(fmt.Sprintf("\tCategories: %q\n", strings.Join(pd.Categories, ",")))
 	b.WriteString(fmt.Sprintf("\tTags: %q\n", strings.Join(pd.Tags, ",")))
 	b.WriteString(fmt.Sprintf("\tFormats: %q\n", strings.Join(pd.Formats, ",")))
@@ -79,23 +114,124 @@ func (pd ParsedData) String() string {
 	b.WriteString(fmt.Sprintf("\tBookFileSize: %d\n", pd.BookFileSize))
 	b.WriteString(fmt.Sprintf("\tCoverFileName: %q\n", pd.CoverFileName))
 	b.WriteString(fmt.Sprintf("\tCoverURL: %q\n", pd.CoverURL))
+	b.WriteString(fmt.Sprintf("\tDiagnostics: %d fields, %d warnings, %d missing required fields\n",
+		len(pd.Diagnostics.Fields), len(pd.Diagnostics.Warnings), len(pd.Diagnostics.MissingRequired)))
 	b.WriteString(fmt.Sprintln("}"))
 
 	return b.String()
 }
 
+// SetDescription sanitizes the description HTML and updates its Markdown and plain text variants.
+func (pd *ParsedData) SetDescription(description string) {
+	pd.Description = parser.SanitizeDescription(description)
+	pd.DescriptionMarkdown = parser.DescriptionToMarkdown(pd.Description)
+	pd.DescriptionText = parser.DescriptionToText(pd.Description)
+}
+
+// GetAuthorNames returns the contributor names in the byline order, a person with several roles is listed once.
+func (pd ParsedData) GetAuthorNames() []string {
+	return getAuthorNames(pd.Contributors)
+}
+
+// GetAuthorRoles returns the contributor roles of the person, like: 'author' and 'editor', in the byline order.
+func (pd ParsedData) GetAuthorRoles(name string) []string {
+	return getAuthorRoles(pd.Contributors, name)
+}
+
+// GetContributors returns the contributor strings, like: 'Jane Doe (Author, Editor)', in the byline order.
+func (pd ParsedData) GetContributors() []string {
+	return getContributors(pd.Contributors)
+}
+
+// SetContributors sets the contributors from the contributor strings, like: 'Jane Doe (Editor)', or
+// 'Jane Doe (Author, Editor)'. The same person could be mentioned several times with different roles,
+// like: 'Jane Doe (Author)' and 'Jane Doe (Editor)', the repeated name and role pairs are skipped.
+func (pd *ParsedData) SetContributors(contributorStrings []string) {
+	pd.Contributors = make([]Contributor, 0, len(contributorStrings))
+	for _, contributorString := range contributorStrings {
+		name, roles := parser.ParseContributorString(contributorString)
+		for _, role := range roles {
+			pd.Contributors = appendContributor(pd.Contributors, Contributor{Name: name, Role: role})
+		}
+	}
+}
+
+// getBookAuthors returns the book-author relations for the stored author IDs, which are in the GetAuthorNames order.
+// A person with several roles gets a relation per role.
+func (pd ParsedData) getBookAuthors(authorIDs []int64) []author.BookAuthor {
+	nameIDs := make(map[string]int64, len(authorIDs))
+	for i, name := range pd.GetAuthorNames() {
+		if i < len(authorIDs) {
+			nameIDs[name] = authorIDs[i]
+		}
+	}
+	bookAuthors := make([]author.BookAuthor, 0, len(pd.Contributors))
+	for _, contributor := range pd.Contributors {
+		if authorID, ok := nameIDs[contributor.Name]; ok {
+			bookAuthors = append(bookAuthors, author.BookAuthor{AuthorID: authorID, Role: contributor.Role})
+		}
+	}
+
+	return bookAuthors
+}
+
+// GetAuthorProfiles returns a profile for every author. Authors without the extended information
+// (like the ones added manually) get a profile with the name only.
+func (pd ParsedData) GetAuthorProfiles() []author.Profile {
+	names := pd.GetAuthorNames()
+	profiles := make([]author.Profile, 0, len(names))
+	for _, name := range names {
+		profile := author.Profile{Name: name}
+		for _, authorProfile := range pd.AuthorProfiles {
+			if authorProfile.Name == name {
+				profile = authorProfile
+				break
+			}
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles
+}
+
+// hasAuthorProfiles checks if any of the authors is identified by a book data source.
+func (pd ParsedData) hasAuthorProfiles() bool {
+	for _, profile := range pd.GetAuthorProfiles() {
+		if profile.HasExternalID() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FileNameRules define which edition parts are shown in the book file name.
+type FileNameRules struct {
+	// MinEdition is the lowest edition number shown, like: '2nd.Edition'
+	MinEdition uint8
+	// EditionQualifier shows the edition qualifier, like: '3rd.Revised.Edition'
+	EditionQualifier bool
+	// EditionYear shows the year of a year-style edition, like: '2023.Edition'
+	EditionYear bool
+}
+
+// DefaultFileNameRules show the edition number only, starting from the 2nd edition.
+var DefaultFileNameRules = FileNameRules{MinEdition: 2}
+
 func (pd ParsedData) GetBookFileName() string {
+	return pd.GetBookFileNameWithRules(DefaultFileNameRules)
+}
+
+// GetBookFileNameWithRules returns the book file name, the edition part is shown according to the given rules.
+func (pd ParsedData) GetBookFileNameWithRules(rules FileNameRules) string {
 	separator := " "
 	builder := strings.Builder{}
 	builder.WriteString(pd.Publisher)
 	builder.WriteString(separator)
 	builder.WriteString(pd.Title)
 	builder.WriteString(separator)
-	if pd.Edition > 1 {
-		ordinalShort, _ := wordnumber.IntToOrdinalShort(int(pd.Edition))
-		builder.WriteString(ordinalShort)
-		builder.WriteString(separator)
-		builder.WriteString("Edition")
+	if editionPart := rules.getEditionPart(pd.Edition); editionPart != "" {
+		builder.WriteString(editionPart)
 		builder.WriteString(separator)
 	}
 	bookID := pd.GetPrimaryId()
@@ -103,7 +239,12 @@ func (pd ParsedData) GetBookFileName() string {
 		builder.WriteString(bookID)
 		builder.WriteString(separator)
 	}
-	builder.WriteString(pd.PubDate.Format("Jan 2006"))
+	// A year only date has no month to show
+	if pd.PubDatePrecision == parser.DatePrecisionYear {
+		builder.WriteString(pd.PubDate.Format("2006"))
+	} else {
+		builder.WriteString(pd.PubDate.Format("Jan 2006"))
+	}
 	builder.WriteString(".zip")
 	// Replace '&' symbols with 'and' word
 	result := strings.ReplaceAll(builder.String(), "&", "and")
@@ -117,6 +258,26 @@ func (pd ParsedData) GetBookFileName() string {
 	return multiDotCleanupRegex.ReplaceAllString(result, ".")
 }
 
+// getEditionPart returns the edition part of the book file name, or an empty string if nothing is shown.
+func (r FileNameRules) getEditionPart(edition parser.Edition) string {
+	parts := make([]string, 0, 3)
+	if edition.Number > 0 && edition.Number >= r.MinEdition {
+		ordinalShort, _ := wordnumber.IntToOrdinalShort(int(edition.Number))
+		parts = append(parts, ordinalShort)
+	}
+	if r.EditionQualifier && edition.Qualifier != "" {
+		parts = append(parts, edition.Qualifier)
+	}
+	if r.EditionYear && edition.IsYearStyle() {
+		parts = append(parts, strconv.Itoa(int(edition.Year)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return strings.Join(append(parts, "Edition"), " ")
+}
+
 func (pd ParsedData) GetBookFileNameWithoutExtension() string {
 	return pd.BookFileName[:strings.LastIndex(pd.BookFileName, ".")]
 }
@@ -124,7 +285,8 @@ func (pd ParsedData) GetBookFileNameWithoutExtension() string {
 type relationKeys struct {
 	publisherID int64
 	languageID  int64
-	authorIDs   []int64
+	seriesID    sql.NullInt64
+	bookAuthors []author.BookAuthor
 	categoryIDs []int64
 	fileTypeIDs []int64
 	tagIDs      []int64
@@ -140,28 +302,54 @@ type SearchRequest struct {
 }
 
 type StoredData struct {
-	ID            int64
-	Title         string
-	Subtitle      string
-	Description   string
-	ISBN10        string
-	ISBN13        int64
-	ASIN          string
-	Pages         uint16
-	Language      string
-	Publisher     string
-	PublisherURL  string
-	Edition       uint8
-	PubDate       time.Time
-	BookFileName  string
-	BookFileSize  int64
-	CoverFileName string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	Authors       []string
-	Categories    []string
-	Tags          []string
-	Formats       []string
+	ID                  int64
+	Title               string
+	Subtitle            string
+	Description         string
+	DescriptionMarkdown string
+	DescriptionText     string
+	ISBN10              string
+	ISBN13              int64
+	ASIN                string
+	DOI                 string
+	Pages               uint16
+	Language            string
+	Publisher           string
+	PublisherURL        string
+	Edition             parser.Edition
+	PubDate             time.Time
+	PubDatePrecision    parser.DatePrecision
+	Series              string
+	SeriesVolume        uint16
+	BookFileName        string
+	BookFileSize        int64
+	CoverFileName       string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	Contributors        []Contributor
+	Categories          []string
+	Tags                []string
+	Formats             []string
+}
+
+// GetAuthorNames returns the contributor names in the byline order, a person with several roles is listed once.
+func (sd StoredData) GetAuthorNames() []string {
+	return getAuthorNames(sd.Contributors)
+}
+
+// GetContributors returns the contributor strings, like: 'Jane Doe (Author, Editor)', in the byline order.
+func (sd StoredData) GetContributors() []string {
+	return getContributors(sd.Contributors)
+}
+
+// GetDescriptionText returns the plain text description. Books stored before the description variants
+// were introduced have the HTML description only, so it gets converted on the fly.
+func (sd StoredData) GetDescriptionText() string {
+	if sd.DescriptionText != "" {
+		return sd.DescriptionText
+	}
+
+	return parser.DescriptionToText(sd.Description)
 }
 
 func (sd StoredData) IsEmpty() bool {
@@ -173,26 +361,93 @@ func (sd StoredData) IsEmpty() bool {
 }
 
 type dotProductRow struct {
-	ID            int64
-	Title         string
-	Subtitle      sql.NullString
-	Description   string
-	ISBN10        sql.NullString
-	ISBN13        sql.NullInt64
-	ASIN          sql.NullString
-	Pages         uint16
-	Language      string
-	Publisher     string
-	PublisherURL  string
-	Edition       uint8
-	PubDate       time.Time
-	BookFileName  string
-	BookFileSize  int64
-	CoverFileName string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	AuthorName    sql.NullString
-	CategoryName  sql.NullString
-	FileTypeName  sql.NullString
-	TagName       sql.NullString
+	ID                  int64
+	Title               string
+	Subtitle            sql.NullString
+	Description         string
+	DescriptionMarkdown sql.NullString
+	DescriptionText     sql.NullString
+	ISBN10              sql.NullString
+	ISBN13              sql.NullInt64
+	ASIN                sql.NullString
+	DOI                 sql.NullString
+	Pages               uint16
+	Language            string
+	Publisher           string
+	PublisherURL        string
+	Edition             uint8
+	EditionQualifier    sql.NullString
+	EditionYear         sql.NullInt32
+	PubDate             time.Time
+	PubDatePrecision    parser.DatePrecision
+	Series              sql.NullString
+	SeriesVolume        sql.NullInt32
+	BookFileName        string
+	BookFileSize        int64
+	CoverFileName       string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	AuthorName          sql.NullString
+	AuthorRole          sql.NullString
+	CategoryName        sql.NullString
+	FileTypeName        sql.NullString
+	TagName             sql.NullString
+}
+
+// appendContributor appends the contributor, unless the same name and role pair is already there.
+// The empty role is the author role.
+func appendContributor(contributors []Contributor, contributor Contributor) []Contributor {
+	if contributor.Role == "" {
+		contributor.Role = parser.ContributorRoleAuthor
+	}
+	for _, existing := range contributors {
+		if existing == contributor {
+			return contributors
+		}
+	}
+
+	return append(contributors, contributor)
+}
+
+func getAuthorNames(contributors []Contributor) []string {
+	names := make([]string, 0, len(contributors))
+	for _, contributor := range contributors {
+		if !containsName(names, contributor.Name) {
+			names = append(names, contributor.Name)
+		}
+	}
+
+	return names
+}
+
+func getAuthorRoles(contributors []Contributor, name string) []string {
+	roles := make([]string, 0, 1)
+	for _, contributor := range contributors {
+		if contributor.Name == name {
+			roles = append(roles, contributor.Role)
+		}
+	}
+
+	return roles
+}
+
+func getContributors(contributors []Contributor) []string {
+	names := getAuthorNames(contributors)
+	contributorStrings := make([]string, 0, len(names))
+	for _, name := range names {
+		contributorStrings = append(contributorStrings,
+			parser.FormatContributor(name, getAuthorRoles(contributors, name)...))
+	}
+
+	return contributorStrings
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
 }
@@ -1,6 +1,9 @@
 package book
 
 import (
+	"github.com/sdreger/lib-file-processor-go/domain/author"
+	"github.com/sdreger/lib-file-processor-go/parser"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -9,17 +12,18 @@ func TestParsedData_GetBookFileName(t *testing.T) {
 	tests := []struct {
 		publisher   string
 		title       string
-		edition     uint8
+		edition     parser.Edition
 		ISBN10      string
 		ISBN13      int64
 		ASIN        string
 		publishDate time.Time
+		precision   parser.DatePrecision
 		fileName    string
 	}{
 		{
 			publisher:   "NSP",
 			title:       "Awesome Book",
-			edition:     1,
+			edition:     parser.Edition{Number: 1},
 			ISBN10:      "1234567890",
 			ISBN13:      0,
 			ASIN:        "",
@@ -29,7 +33,7 @@ func TestParsedData_GetBookFileName(t *testing.T) {
 		{
 			publisher:   "For, Dummies!",
 			title:       "What Is What?!",
-			edition:     2,
+			edition:     parser.Edition{Number: 2},
 			ISBN10:      "",
 			ISBN13:      0,
 			ASIN:        "BH128KL653",
@@ -39,7 +43,7 @@ func TestParsedData_GetBookFileName(t *testing.T) {
 		{
 			publisher:   "DK",
 			title:       "C#, For Beginners;",
-			edition:     3,
+			edition:     parser.Edition{Number: 3},
 			ISBN10:      "",
 			ISBN13:      1234567890123,
 			ASIN:        "",
@@ -49,7 +53,7 @@ func TestParsedData_GetBookFileName(t *testing.T) {
 		{
 			publisher:   "Maker Media",
 			title:       "C++ Data-Related Patterns. (Global Edition)",
-			edition:     4,
+			edition:     parser.Edition{Number: 4},
 			ISBN10:      "0987654321",
 			ISBN13:      0,
 			ASIN:        "",
@@ -59,25 +63,44 @@ func TestParsedData_GetBookFileName(t *testing.T) {
 		{
 			publisher:   "MK",
 			title:       "PHP & MySQL",
-			edition:     10,
+			edition:     parser.Edition{Number: 10},
 			ISBN10:      "5432112345",
 			ISBN13:      1234567890123,
 			ASIN:        "BH128KL653",
 			publishDate: testPublishDate,
 			fileName:    "MK.PHP.and.MySQL.10th.Edition.5432112345.Feb.2020.zip",
 		},
+		{
+			publisher:   "Apogeo",
+			title:       "Awesome Book",
+			edition:     parser.Edition{Number: 1},
+			ISBN10:      "1234567890",
+			publishDate: testPublishDate,
+			precision:   parser.DatePrecisionMonth,
+			fileName:    "Apogeo.Awesome.Book.1234567890.Feb.2020.zip",
+		},
+		{
+			publisher:   "Apogeo",
+			title:       "Awesome Book",
+			edition:     parser.Edition{Number: 1},
+			ISBN10:      "1234567890",
+			publishDate: testPublishDate,
+			precision:   parser.DatePrecisionYear,
+			fileName:    "Apogeo.Awesome.Book.1234567890.2020.zip",
+		},
 	}
 
 	t.Log("Given the need to test book filename getter.")
 	for i, tt := range tests {
 		inputData := ParsedData{
-			Publisher: tt.publisher,
-			Title:     tt.title,
-			Edition:   tt.edition,
-			ISBN10:    tt.ISBN10,
-			ISBN13:    tt.ISBN13,
-			ASIN:      tt.ASIN,
-			PubDate:   tt.publishDate,
+			Publisher:        tt.publisher,
+			Title:            tt.title,
+			Edition:          tt.edition,
+			ISBN10:           tt.ISBN10,
+			ISBN13:           tt.ISBN13,
+			ASIN:             tt.ASIN,
+			PubDate:          tt.publishDate,
+			PubDatePrecision: tt.precision,
 		}
 		t.Logf("\tTest: %d\tWhen checking %v for filename %s\n", i, inputData.GetPrimaryId(), tt.fileName)
 		fileName := inputData.GetBookFileName()
@@ -92,6 +115,64 @@ func TestParsedData_GetBookFileName(t *testing.T) {
 	t.Logf("\t\t%s\tShould be able to get correct book filename", succeed)
 }
 
+func TestParsedData_GetBookFileNameWithRules(t *testing.T) {
+	tests := []struct {
+		edition  parser.Edition
+		rules    FileNameRules
+		fileName string
+	}{
+		{
+			edition:  parser.Edition{Number: 3, Qualifier: "Revised"},
+			rules:    DefaultFileNameRules,
+			fileName: "Springer.Awesome.Book.3rd.Edition.1234567890.Feb.2020.zip",
+		},
+		{
+			edition:  parser.Edition{Number: 3, Qualifier: "Revised"},
+			rules:    FileNameRules{MinEdition: 2, EditionQualifier: true},
+			fileName: "Springer.Awesome.Book.3rd.Revised.Edition.1234567890.Feb.2020.zip",
+		},
+		{
+			edition:  parser.Edition{Number: 1, Qualifier: "20th Anniversary"},
+			rules:    FileNameRules{MinEdition: 2, EditionQualifier: true},
+			fileName: "Springer.Awesome.Book.20th.Anniversary.Edition.1234567890.Feb.2020.zip",
+		},
+		{
+			edition:  parser.Edition{Number: 1},
+			rules:    FileNameRules{MinEdition: 1},
+			fileName: "Springer.Awesome.Book.1st.Edition.1234567890.Feb.2020.zip",
+		},
+		{
+			edition:  parser.Edition{Year: 2023},
+			rules:    DefaultFileNameRules,
+			fileName: "Springer.Awesome.Book.1234567890.Feb.2020.zip",
+		},
+		{
+			edition:  parser.Edition{Number: 2, Year: 2023},
+			rules:    FileNameRules{MinEdition: 2, EditionYear: true},
+			fileName: "Springer.Awesome.Book.2nd.2023.Edition.1234567890.Feb.2020.zip",
+		},
+	}
+
+	t.Log("Given the need to test book filename rules.")
+	for i, tt := range tests {
+		inputData := ParsedData{
+			Publisher: "Springer",
+			Title:     "Awesome Book",
+			Edition:   tt.edition,
+			ISBN10:    "1234567890",
+			PubDate:   testPublishDate,
+		}
+		t.Logf("\tTest: %d\tWhen checking %+v edition with %+v rules\n", i, tt.edition, tt.rules)
+		fileName := inputData.GetBookFileNameWithRules(tt.rules)
+
+		if fileName != tt.fileName {
+			t.Errorf("\t\t%s\tShould get a %q filename: %q", failed, tt.fileName, fileName)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct filename value.", succeed)
+		}
+	}
+}
+
 func TestParsedData_GetBookFileNameWithoutExtension(t *testing.T) {
 	parsedData := ParsedData{BookFileName: "NSP.Awesome.Book.1234567890.Feb.2020.zip"}
 	expected := "NSP.Awesome.Book.1234567890.Feb.2020"
@@ -102,3 +183,129 @@ func TestParsedData_GetBookFileNameWithoutExtension(t *testing.T) {
 
 	t.Logf("\t\t%s\tShould be able to get book filename without extension", succeed)
 }
+
+func TestParsedData_GetAuthorProfiles(t *testing.T) {
+	profile := author.Profile{Name: "First Author", Source: "amazon", ExternalID: "B000000001"}
+	parsedData := ParsedData{
+		Contributors:   NewAuthors([]string{"First Author", "Second Author"}),
+		AuthorProfiles: []author.Profile{profile, {Name: "Removed Author", Source: "amazon", ExternalID: "B000000002"}},
+	}
+
+	profiles := parsedData.GetAuthorProfiles()
+	if len(profiles) != 2 {
+		t.Fatalf("\t\t%s\tShould get a profile for every author: %v", failed, profiles)
+	}
+	if profiles[0] != profile {
+		t.Errorf("\t\t%s\tShould get a %+v author profile, got: %+v", failed, profile, profiles[0])
+	}
+	if profiles[1] != (author.Profile{Name: "Second Author"}) {
+		t.Errorf("\t\t%s\tShould get a name only author profile, got: %+v", failed, profiles[1])
+	}
+	if !parsedData.hasAuthorProfiles() {
+		t.Errorf("\t\t%s\tShould have author profiles", failed)
+	}
+
+	t.Logf("\t\t%s\tShould be able to get author profiles", succeed)
+}
+
+func TestParsedData_SetContributors(t *testing.T) {
+	t.Log("Given the need to test setting the book contributors.")
+	t.Run("Single role contributors", testSetSingleRoleContributors)
+	t.Run("Multi-role contributors", testSetMultiRoleContributors)
+	t.Run("Repeated contributors", testSetRepeatedContributors)
+}
+
+func testSetSingleRoleContributors(t *testing.T) {
+	contributors := []string{"First Author", "Second Author (Editor)", "Third Author (Translator)"}
+	var parsedData ParsedData
+	parsedData.SetContributors(contributors)
+
+	if !reflect.DeepEqual(parsedData.GetAuthorNames(), []string{"First Author", "Second Author", "Third Author"}) {
+		t.Fatalf("\t\t%s\tShould get the author names without roles, got: %q", failed, parsedData.GetAuthorNames())
+	}
+	if roles := parsedData.GetAuthorRoles("First Author"); !reflect.DeepEqual(roles, []string{"author"}) {
+		t.Errorf("\t\t%s\tShould get a %q author role, got: %q", failed, parser.ContributorRoleAuthor, roles)
+	}
+	if roles := parsedData.GetAuthorRoles("Second Author"); !reflect.DeepEqual(roles, []string{"editor"}) {
+		t.Errorf("\t\t%s\tShould get a %q author role, got: %q", failed, parser.ContributorRoleEditor, roles)
+	}
+	if !reflect.DeepEqual(parsedData.GetContributors(), contributors) {
+		t.Errorf("\t\t%s\tShould get the %q contributors, got: %q", failed, contributors,
+			parsedData.GetContributors())
+	}
+	bookAuthors := parsedData.getBookAuthors([]int64{1, 2, 3})
+	if bookAuthors[1] != (author.BookAuthor{AuthorID: 2, Role: parser.ContributorRoleEditor}) {
+		t.Errorf("\t\t%s\tShould get an editor book author, got: %+v", failed, bookAuthors[1])
+	}
+
+	t.Logf("\t\t%s\tShould be able to set single role contributors", succeed)
+}
+
+func testSetMultiRoleContributors(t *testing.T) {
+	var parsedData ParsedData
+	parsedData.SetContributors([]string{"Jane Doe (Author, Editor)", "John Smith"})
+
+	expected := []Contributor{
+		{Name: "Jane Doe", Role: parser.ContributorRoleAuthor},
+		{Name: "Jane Doe", Role: parser.ContributorRoleEditor},
+		{Name: "John Smith", Role: parser.ContributorRoleAuthor},
+	}
+	if !reflect.DeepEqual(parsedData.Contributors, expected) {
+		t.Fatalf("\t\t%s\tShould get the %+v contributors, got: %+v", failed, expected, parsedData.Contributors)
+	}
+	if names := parsedData.GetAuthorNames(); !reflect.DeepEqual(names, []string{"Jane Doe", "John Smith"}) {
+		t.Errorf("\t\t%s\tShould get every author name once, got: %q", failed, names)
+	}
+	contributors := []string{"Jane Doe (Author, Editor)", "John Smith"}
+	if !reflect.DeepEqual(parsedData.GetContributors(), contributors) {
+		t.Errorf("\t\t%s\tShould get the %q contributors, got: %q", failed, contributors,
+			parsedData.GetContributors())
+	}
+	expectedBookAuthors := []author.BookAuthor{
+		{AuthorID: 1, Role: parser.ContributorRoleAuthor},
+		{AuthorID: 1, Role: parser.ContributorRoleEditor},
+		{AuthorID: 2, Role: parser.ContributorRoleAuthor},
+	}
+	if bookAuthors := parsedData.getBookAuthors([]int64{1, 2}); !reflect.DeepEqual(bookAuthors, expectedBookAuthors) {
+		t.Errorf("\t\t%s\tShould get a book author per role %+v, got: %+v", failed, expectedBookAuthors,
+			bookAuthors)
+	}
+
+	t.Logf("\t\t%s\tShould be able to set multi-role contributors", succeed)
+}
+
+func testSetRepeatedContributors(t *testing.T) {
+	var parsedData ParsedData
+	parsedData.SetContributors([]string{"Jane Doe (Author)", "John Smith", "Jane Doe (Editor)", "John Smith"})
+
+	expected := []Contributor{
+		{Name: "Jane Doe", Role: parser.ContributorRoleAuthor},
+		{Name: "John Smith", Role: parser.ContributorRoleAuthor},
+		{Name: "Jane Doe", Role: parser.ContributorRoleEditor},
+	}
+	if !reflect.DeepEqual(parsedData.Contributors, expected) {
+		t.Fatalf("\t\t%s\tShould get the %+v contributors, got: %+v", failed, expected, parsedData.Contributors)
+	}
+	if roles := parsedData.GetAuthorRoles("Jane Doe"); !reflect.DeepEqual(roles, []string{"author", "editor"}) {
+		t.Errorf("\t\t%s\tShould get both author and editor roles, got: %q", failed, roles)
+	}
+
+	t.Logf("\t\t%s\tShould be able to set repeated contributors", succeed)
+}
+
+func TestParsedData_SetDescription(t *testing.T) {
+	var parsedData ParsedData
+	parsedData.SetDescription(`<div class="a-expander"><p>Learn <strong>Go</strong></p><script>x()</script></div>`)
+
+	if parsedData.Description != "<p>Learn <b>Go</b></p>" {
+		t.Errorf("\t\t%s\tShould get a sanitized description, got: %q", failed, parsedData.Description)
+	}
+	if parsedData.DescriptionMarkdown != "Learn **Go**" {
+		t.Errorf("\t\t%s\tShould get a Markdown description, got: %q", failed, parsedData.DescriptionMarkdown)
+	}
+	if parsedData.DescriptionText != "Learn Go" {
+		t.Errorf("\t\t%s\tShould get a text description, got: %q", failed, parsedData.DescriptionText)
+	}
+
+	t.Logf("\t\t%s\tShould be able to set the description variants", succeed)
+}
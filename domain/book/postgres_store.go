@@ -10,7 +10,9 @@ import (
 	"github.com/sdreger/lib-file-processor-go/domain/filetype"
 	"github.com/sdreger/lib-file-processor-go/domain/lang"
 	"github.com/sdreger/lib-file-processor-go/domain/publisher"
+	"github.com/sdreger/lib-file-processor-go/domain/series"
 	"github.com/sdreger/lib-file-processor-go/domain/tag"
+	"github.com/sdreger/lib-file-processor-go/isbn"
 	"io"
 	"log"
 )
@@ -23,11 +25,13 @@ type PostgresStore struct {
 	categoryStore  category.Store
 	filetypeStore  filetype.Store
 	tagStore       tag.Store
+	seriesStore    series.Store
 	logger         *log.Logger
 }
 
 func NewPostgresStore(db *sql.DB, publisherStore publisher.Store, languageStore lang.Store, authorStore author.Store,
-	categoryStore category.Store, filetypeStore filetype.Store, tagStore tag.Store, logger *log.Logger) Store {
+	categoryStore category.Store, filetypeStore filetype.Store, tagStore tag.Store, seriesStore series.Store,
+	logger *log.Logger) Store {
 	return PostgresStore{
 		db:             db,
 		publisherStore: publisherStore,
@@ -36,6 +40,7 @@ func NewPostgresStore(db *sql.DB, publisherStore publisher.Store, languageStore
 		categoryStore:  categoryStore,
 		filetypeStore:  filetypeStore,
 		tagStore:       tagStore,
+		seriesStore:    seriesStore,
 		logger:         logger,
 	}
 }
@@ -44,14 +49,17 @@ func (s PostgresStore) Find(ctx context.Context, req SearchRequest) (*StoredData
 
 	var book StoredData
 	err := transaction.WithTransaction(ctx, s.db, func(txCtx context.Context, tx *sql.Tx) error {
-		selectQuery := `SELECT books.id, books.title, books.subtitle, books.description,
-		books.isbn10, books.isbn13, books.asin, books.pages, lang.name AS lang_name, pub.name AS pub_name,
-        books.publisher_url, books.edition, books.pub_date,
+		selectQuery := `SELECT books.id, books.title, books.subtitle, books.description, books.description_markdown,
+		books.description_text, books.isbn10, books.isbn13, books.asin, books.doi, books.pages,
+		lang.name AS lang_name, pub.name AS pub_name,
+        books.publisher_url, books.edition, books.edition_qualifier, books.edition_year, books.pub_date, books.pub_date_precision,
+        s.name AS series_name, books.series_volume,
         books.book_file_name, books.book_file_size, books.cover_file_name, books.created_at, books.updated_at,
-        a.name AS author_name, c.name AS category_name, ft.name AS file_type_name, t.name AS tag_name
+        a.name AS author_name, ba.role AS author_role, c.name AS category_name, ft.name AS file_type_name, t.name AS tag_name
 		FROM ebook.books
 			LEFT JOIN ebook.publishers pub ON books.publisher_id = pub.id
 			LEFT JOIN ebook.languages lang ON books.language_id = lang.id
+			LEFT JOIN ebook.series s ON books.series_id = s.id
 			LEFT JOIN ebook.book_author ba on books.id = ba.book_id
 			LEFT JOIN ebook.authors a on a.id = ba.author_id
 			LEFT JOIN ebook.book_category bc on books.id = bc.book_id
@@ -63,14 +71,17 @@ func (s PostgresStore) Find(ctx context.Context, req SearchRequest) (*StoredData
 		WHERE (books.title = $1 AND books.edition = $2 AND pub.name = $6) 
 			OR (books.isbn10 IS NOT NULL AND books.isbn10 = $3) 
 			OR (books.isbn13 IS NOT NULL AND books.isbn13 = $4)
-			OR (books.asin IS NOT NULL AND books.asin = $5)`
+			OR (books.asin IS NOT NULL AND books.asin = $5)
+		ORDER BY books.id, ba.author_order`
 		selectStmt, err := tx.PrepareContext(txCtx, selectQuery)
 		if err != nil {
 			return err
 		}
 		defer s.closeResource(selectStmt)
 
-		rows, err := selectStmt.QueryContext(txCtx, req.Title, req.Edition, req.ISBN10, req.ISBN13, req.ASIN, req.Publisher)
+		isbn10, isbn13 := completeISBNs(req.ISBN10, req.ISBN13)
+		rows, err := selectStmt.QueryContext(txCtx, req.Title, req.Edition, isbn10, isbn13, isbn.Normalize(req.ASIN),
+			req.Publisher)
 		if err != nil {
 			return err
 		}
@@ -94,22 +105,29 @@ func (s PostgresStore) Find(ctx context.Context, req SearchRequest) (*StoredData
 	return &book, nil
 }
 
+// scanBookData maps the book rows to the book data. If several books are found (like, one by the ISBN,
+// and another one by the title), the first one is taken, the rows of the others are skipped.
 func scanBookData(rows *sql.Rows) (StoredData, error) {
 	var bookData StoredData
 	for rows.Next() {
 		var rowData dotProductRow
-		err := rows.Scan(&rowData.ID, &rowData.Title, &rowData.Subtitle, &rowData.Description, &rowData.ISBN10,
-			&rowData.ISBN13, &rowData.ASIN, &rowData.Pages, &rowData.Language, &rowData.Publisher,
-			&rowData.PublisherURL, &rowData.Edition, &rowData.PubDate, &rowData.BookFileName, &rowData.BookFileSize,
-			&rowData.CoverFileName, &rowData.CreatedAt, &rowData.UpdatedAt, &rowData.AuthorName, &rowData.CategoryName,
-			&rowData.FileTypeName, &rowData.TagName)
+		err := rows.Scan(&rowData.ID, &rowData.Title, &rowData.Subtitle, &rowData.Description,
+			&rowData.DescriptionMarkdown, &rowData.DescriptionText,
+			&rowData.ISBN10, &rowData.ISBN13, &rowData.ASIN, &rowData.DOI, &rowData.Pages,
+			&rowData.Language, &rowData.Publisher, &rowData.PublisherURL,
+			&rowData.Edition, &rowData.EditionQualifier, &rowData.EditionYear, &rowData.PubDate, &rowData.PubDatePrecision,
+			&rowData.Series, &rowData.SeriesVolume,
+			&rowData.BookFileName, &rowData.BookFileSize, &rowData.CoverFileName, &rowData.CreatedAt, &rowData.UpdatedAt,
+			&rowData.AuthorName, &rowData.AuthorRole, &rowData.CategoryName, &rowData.FileTypeName, &rowData.TagName)
 		if err != nil {
 			return StoredData{}, err
 		}
+		if bookData.ID != 0 && rowData.ID != bookData.ID {
+			continue
+		}
 		mapToStoredData(rowData, &bookData)
 	}
 
-	bookData.Authors = deduplicateMappedData(bookData.Authors)
 	bookData.Categories = deduplicateMappedData(bookData.Categories)
 	bookData.Formats = deduplicateMappedData(bookData.Formats)
 	bookData.Tags = deduplicateMappedData(bookData.Tags)
@@ -130,8 +148,12 @@ func (s PostgresStore) Add(ctx context.Context, parsedData ParsedData) (int64, e
 
 		// ---------- Store book record ----------
 		insertQuery := `INSERT INTO ebook.books(title, subtitle, description, isbn10, isbn13, asin, pages, language_id, 
-                        publisher_id, publisher_url, edition, pub_date, book_file_name, book_file_size, cover_file_name)
-                		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING id`
+                        publisher_id, publisher_url, edition, pub_date, book_file_name, book_file_size, cover_file_name,
+                        doi, series_id, series_volume, description_markdown, description_text, edition_qualifier,
+                        edition_year, pub_date_precision)
+                		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+                		        $21, $22, $23)
+                		RETURNING id`
 		insertStmt, err := tx.PrepareContext(txCtx, insertQuery)
 		if err != nil {
 			return err
@@ -141,8 +163,11 @@ func (s PostgresStore) Add(ctx context.Context, parsedData ParsedData) (int64, e
 		isbn10, isbn13, asin := getBookIdentifiers(parsedData)
 		bookIDRow := insertStmt.QueryRowContext(txCtx, parsedData.Title, getNullableString(parsedData.Subtitle),
 			parsedData.Description, isbn10, isbn13, asin, parsedData.Pages, relKeys.languageID, relKeys.publisherID,
-			parsedData.PublisherURL, parsedData.Edition, parsedData.PubDate, parsedData.BookFileName,
-			parsedData.BookFileSize, parsedData.CoverFileName)
+			parsedData.PublisherURL, parsedData.Edition.Number, parsedData.PubDate, parsedData.BookFileName,
+			parsedData.BookFileSize, parsedData.CoverFileName, getNullableString(parsedData.DOI), relKeys.seriesID,
+			getSeriesVolume(parsedData), getNullableString(parsedData.DescriptionMarkdown),
+			getNullableString(parsedData.DescriptionText), getNullableString(parsedData.Edition.Qualifier),
+			getEditionYear(parsedData), parsedData.PubDatePrecision)
 		bookStoreErr := bookIDRow.Scan(&bookID)
 		if bookStoreErr != nil {
 			return fmt.Errorf("can not store book: %w", bookStoreErr)
@@ -181,8 +206,10 @@ func (s PostgresStore) Update(ctx context.Context, existingData *StoredData, par
 			title = $1, subtitle = $2, description = $3,
 			isbn10 = $4, isbn13 = $5, asin = $6, pages = $7, 
 			language_id = $8, publisher_id = $9, publisher_url = $10, edition = $11, pub_date = $12,
-			book_file_name = $13, book_file_size = $14, cover_file_name = $15, updated_at = NOW()::timestamp
-		WHERE id = $16`
+			book_file_name = $13, book_file_size = $14, cover_file_name = $15, doi = $16,
+			series_id = $17, series_volume = $18, description_markdown = $19, description_text = $20,
+			edition_qualifier = $21, edition_year = $22, pub_date_precision = $23, updated_at = NOW()::timestamp
+		WHERE id = $24`
 		updateStmt, err := tx.PrepareContext(txCtx, updateQuery)
 		if err != nil {
 			return err
@@ -192,8 +219,12 @@ func (s PostgresStore) Update(ctx context.Context, existingData *StoredData, par
 		isbn10, isbn13, asin := getBookIdentifiers(*parsedData)
 		_, bookUpdateErr := updateStmt.ExecContext(txCtx, parsedData.Title, parsedData.Subtitle,
 			parsedData.Description, isbn10, isbn13, asin, parsedData.Pages,
-			relKeys.languageID, relKeys.publisherID, parsedData.PublisherURL, parsedData.Edition, parsedData.PubDate,
-			parsedData.BookFileName, parsedData.BookFileSize, parsedData.CoverFileName, existingData.ID)
+			relKeys.languageID, relKeys.publisherID, parsedData.PublisherURL, parsedData.Edition.Number, parsedData.PubDate,
+			parsedData.BookFileName, parsedData.BookFileSize, parsedData.CoverFileName,
+			getNullableString(parsedData.DOI), relKeys.seriesID, getSeriesVolume(*parsedData),
+			getNullableString(parsedData.DescriptionMarkdown), getNullableString(parsedData.DescriptionText),
+			getNullableString(parsedData.Edition.Qualifier), getEditionYear(*parsedData), parsedData.PubDatePrecision,
+			existingData.ID)
 		if bookUpdateErr != nil {
 			return fmt.Errorf("can not update book: %w", err)
 		}
@@ -228,8 +259,23 @@ func (s PostgresStore) storeBookRelationData(txCtx context.Context, parsedData P
 		return relationKeys{}, fmt.Errorf("can not upsert language: %w", err)
 	}
 
+	// ---------- Upsert series ----------
+	var seriesID sql.NullInt64
+	if parsedData.Series != "" {
+		seriesID.Int64, err = s.seriesStore.Upsert(txCtx, parsedData.Series)
+		if err != nil {
+			return relationKeys{}, fmt.Errorf("can not upsert series: %w", err)
+		}
+		seriesID.Valid = true
+	}
+
 	// ---------- Upsert authors ----------
-	authorIDs, err := s.authorStore.UpsertAll(txCtx, parsedData.Authors)
+	var authorIDs []int64
+	if parsedData.hasAuthorProfiles() {
+		authorIDs, err = s.authorStore.UpsertProfiles(txCtx, parsedData.GetAuthorProfiles())
+	} else {
+		authorIDs, err = s.authorStore.UpsertAll(txCtx, parsedData.GetAuthorNames())
+	}
 	if err != nil {
 		return relationKeys{}, fmt.Errorf("can not upsert authors: %w", err)
 	}
@@ -255,7 +301,8 @@ func (s PostgresStore) storeBookRelationData(txCtx context.Context, parsedData P
 	return relationKeys{
 		publisherID: publisherID,
 		languageID:  languageID,
-		authorIDs:   authorIDs,
+		seriesID:    seriesID,
+		bookAuthors: parsedData.getBookAuthors(authorIDs),
 		categoryIDs: categoryIDs,
 		fileTypeIDs: fileTypeIDs,
 		tagIDs:      tagIDs,
@@ -263,7 +310,7 @@ func (s PostgresStore) storeBookRelationData(txCtx context.Context, parsedData P
 }
 
 func (s PostgresStore) storeBookRelationLinks(txCtx context.Context, bookID int64, relKeys relationKeys) error {
-	err := s.authorStore.ReplaceBookAuthors(txCtx, bookID, relKeys.authorIDs)
+	err := s.authorStore.ReplaceBookAuthors(txCtx, bookID, relKeys.bookAuthors)
 	if err != nil {
 		return fmt.Errorf("can not update book-author relations: %w", err)
 	}
@@ -286,21 +333,58 @@ func (s PostgresStore) storeBookRelationLinks(txCtx context.Context, bookID int6
 	return nil
 }
 
+// getBookIdentifiers returns the normalized book identifiers. A missing ISBN10 or ISBN13 is derived
+// from the other one, if it is valid.
 func getBookIdentifiers(parsedData ParsedData) (sql.NullString, sql.NullInt64, sql.NullString) {
 	var isbn13 sql.NullInt64
 	var isbn10, asin sql.NullString
-	if parsedData.ISBN10 != "" {
-		isbn10 = getNullableString(parsedData.ISBN10)
+	ISBN10, ISBN13 := completeISBNs(parsedData.ISBN10, parsedData.ISBN13)
+	if ISBN10 != "" {
+		isbn10 = getNullableString(ISBN10)
 	}
-	if parsedData.ISBN13 > 0 {
-		isbn13 = getNullableInt64(parsedData.ISBN13)
+	if ISBN13 > 0 {
+		isbn13 = getNullableInt64(ISBN13)
 	}
 	if parsedData.ASIN != "" {
-		asin = getNullableString(parsedData.ASIN)
+		asin = getNullableString(isbn.Normalize(parsedData.ASIN))
 	}
 	return isbn10, isbn13, asin
 }
 
+// completeISBNs normalizes the ISBN10, and derives the missing ISBN10 or ISBN13 from the other one.
+// Only the valid ISBNs are converted, the invalid ones are kept as is.
+func completeISBNs(isbn10 string, isbn13 int64) (string, int64) {
+	isbn10 = isbn.Normalize(isbn10)
+	if isbn13 == 0 && isbn10 != "" {
+		if converted, err := isbn.ToISBN13(isbn10); err == nil {
+			isbn13, _ = isbn.ParseISBN13(converted)
+		}
+	}
+	if isbn10 == "" && isbn13 != 0 {
+		if converted, err := isbn.ToISBN10(isbn.FormatISBN13(isbn13)); err == nil {
+			isbn10 = converted
+		}
+	}
+
+	return isbn10, isbn13
+}
+
+// getSeriesVolume returns the series volume, or NULL if the book is not a part of any series.
+func getSeriesVolume(parsedData ParsedData) sql.NullInt32 {
+	if parsedData.Series == "" || parsedData.SeriesVolume == 0 {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(parsedData.SeriesVolume), Valid: true}
+}
+
+// getEditionYear returns the year of a year-style edition, or NULL for the other editions.
+func getEditionYear(parsedData ParsedData) sql.NullInt32 {
+	if !parsedData.Edition.IsYearStyle() {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(parsedData.Edition.Year), Valid: true}
+}
+
 func getNullableInt64(val int64) sql.NullInt64 {
 	nullInt64 := sql.NullInt64{Int64: val}
 	if val > 0 {
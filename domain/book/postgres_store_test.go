@@ -3,6 +3,7 @@ package book
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
 	"github.com/sdreger/lib-file-processor-go/domain/author"
@@ -10,21 +11,27 @@ import (
 	"github.com/sdreger/lib-file-processor-go/domain/filetype"
 	"github.com/sdreger/lib-file-processor-go/domain/lang"
 	"github.com/sdreger/lib-file-processor-go/domain/publisher"
+	"github.com/sdreger/lib-file-processor-go/domain/series"
 	"github.com/sdreger/lib-file-processor-go/domain/tag"
+	"github.com/sdreger/lib-file-processor-go/parser"
 	"log"
+	"reflect"
 	"testing"
 	"time"
 )
 
 const (
-	findBookQuery = `SELECT books.id, books.title, books.subtitle, books.description,
-		books.isbn10, books.isbn13, books.asin, books.pages, lang.name AS lang_name, pub.name AS pub_name,
-        books.publisher_url, books.edition, books.pub_date,
+	findBookQuery = `SELECT books.id, books.title, books.subtitle, books.description, books.description_markdown,
+		books.description_text, books.isbn10, books.isbn13, books.asin, books.doi, books.pages,
+		lang.name AS lang_name, pub.name AS pub_name,
+        books.publisher_url, books.edition, books.edition_qualifier, books.edition_year, books.pub_date, books.pub_date_precision,
+        s.name AS series_name, books.series_volume,
         books.book_file_name, books.book_file_size, books.cover_file_name, books.created_at, books.updated_at,
-        a.name AS author_name, c.name AS category_name, ft.name AS file_type_name, t.name AS tag_name
+        a.name AS author_name, ba.role AS author_role, c.name AS category_name, ft.name AS file_type_name, t.name AS tag_name
 		FROM ebook.books
 			LEFT JOIN ebook.publishers pub ON books.publisher_id = pub.id
 			LEFT JOIN ebook.languages lang ON books.language_id = lang.id
+			LEFT JOIN ebook.series s ON books.series_id = s.id
 			LEFT JOIN ebook.book_author ba on books.id = ba.book_id
 			LEFT JOIN ebook.authors a on a.id = ba.author_id
 			LEFT JOIN ebook.book_category bc on books.id = bc.book_id
@@ -36,19 +43,24 @@ const (
 		WHERE \(books.title = \$1 AND books.edition = \$2 AND pub.name = \$6\) 
 			OR \(books.isbn10 IS NOT NULL AND books.isbn10 = \$3\) 
 			OR \(books.isbn13 IS NOT NULL AND books.isbn13 = \$4\)
-			OR \(books.asin IS NOT NULL AND books.asin = \$5\)`
+			OR \(books.asin IS NOT NULL AND books.asin = \$5\)
+		ORDER BY books.id, ba.author_order`
 
 	addBookQuery = `INSERT INTO ebook.books\(title, subtitle, description, isbn10, isbn13, asin, pages, 
 						language_id, publisher_id, publisher_url, edition, pub_date, book_file_name, book_file_size,
-						cover_file_name\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10, \$11, \$12, \$13,
-						\$14, \$15\) RETURNING id`
+						cover_file_name, doi, series_id, series_volume, description_markdown, description_text,
+						edition_qualifier, edition_year, pub_date_precision\)
+						VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10, \$11, \$12, \$13, \$14, \$15, \$16,
+						\$17, \$18, \$19, \$20, \$21, \$22, \$23\) RETURNING id`
 
 	updateBookQuery = `UPDATE ebook.books SET 
 			title = \$1, subtitle = \$2, description = \$3,
 			isbn10 = \$4, isbn13 = \$5, asin = \$6, pages = \$7, 
 			language_id = \$8, publisher_id = \$9, publisher_url = \$10, edition = \$11, pub_date = \$12,
-			book_file_name = \$13, book_file_size = \$14, cover_file_name = \$15, updated_at = NOW\(\)::timestamp
-		WHERE id = \$16`
+			book_file_name = \$13, book_file_size = \$14, cover_file_name = \$15, doi = \$16,
+			series_id = \$17, series_volume = \$18, description_markdown = \$19, description_text = \$20,
+			edition_qualifier = \$21, edition_year = \$22, pub_date_precision = \$23, updated_at = NOW\(\)::timestamp
+		WHERE id = \$24`
 )
 
 func TestPostgresStore_Find(t *testing.T) {
@@ -65,19 +77,25 @@ func TestPostgresStore_Find(t *testing.T) {
 	mockCategoryStore := category.NewMockStore(ctrl)
 	mockFileTypeStore := filetype.NewMockStore(ctrl)
 	mockTagStore := tag.NewMockStore(ctrl)
+	mockSeriesStore := series.NewMockStore(ctrl)
 	store := NewPostgresStore(db, mockPublisherStore, mockLanguageStore, mockAuthorStore, mockCategoryStore,
-		mockFileTypeStore, mockTagStore, log.Default())
+		mockFileTypeStore, mockTagStore, mockSeriesStore, log.Default())
 
 	rows := sqlmock.NewRows([]string{
-		"id", "title", "subtitle", "description", "isbn10", "isbn13", "asin", "pages", "lang_name", "pub_name",
-		"publisher_url", "edition", "pub_date", "book_file_name", "book_file_size", "cover_file_name", "created_at",
-		"updated_at", "author_name", "category_name", "file_type_name", "tag_name",
+		"id", "title", "subtitle", "description", "description_markdown", "description_text", "isbn10", "isbn13", "asin", "doi", "pages", "lang_name", "pub_name",
+		"publisher_url", "edition", "edition_qualifier", "edition_year", "pub_date", "pub_date_precision", "series_name", "series_volume", "book_file_name", "book_file_size", "cover_file_name", "created_at",
+		"updated_at", "author_name", "author_role", "category_name", "file_type_name", "tag_name",
 	})
 	nowTime := time.Now()
-	result := rows.AddRow(testBookID, testBookTitle, testBookSubtitle, testBookDescription, testBookISBN10,
-		testBookISBN13, testBookASIN, testBookPages, testBookLanguage, testBookPublisher, testBookPublisherURL,
-		testBookEdition, nowTime, testBookFileName, testBookFileSize, testBookCoverFileName, nowTime, nowTime,
-		testBookAuthorName, testBookCategoryName, testBookFileTypeName, testBookTagName)
+	result := rows.AddRow(testBookID, testBookTitle, testBookSubtitle, testBookDescription,
+		testBookDescriptionMarkdown, testBookDescriptionText, testBookISBN10, testBookISBN13, testBookASIN, testBookDOI, testBookPages, testBookLanguage, testBookPublisher, testBookPublisherURL,
+		testBookEdition, testBookEditionQualifier, nil, nowTime, testPublishDatePrecision, testBookSeries, testBookSeriesVolume, testBookFileName, testBookFileSize, testBookCoverFileName, nowTime, nowTime,
+		testBookAuthorName, testBookAuthorRole, testBookCategoryName, testBookFileTypeName, testBookTagName).
+		// another book, matching the search request, should be skipped
+		AddRow(testBookID+1, "Other title", nil, testBookDescription, nil, nil, nil, nil, nil, nil, testBookPages,
+			testBookLanguage, testBookPublisher, testBookPublisherURL, testBookEdition, nil, nil, nowTime, 0, nil, nil,
+			testBookFileName, testBookFileSize, testBookCoverFileName, nowTime, nowTime, "Other Author", nil, nil, nil,
+			nil)
 
 	mock.ExpectBegin()
 	selectStmt := mock.ExpectPrepare(findBookQuery).WillBeClosed()
@@ -113,6 +131,14 @@ func TestPostgresStore_Find(t *testing.T) {
 	if storedData.Description != testBookDescription {
 		t.Fatalf("\t\t%s\tShould get a %q book description: %q", failed, storedData.Description, testBookDescription)
 	}
+	if storedData.DescriptionMarkdown != testBookDescriptionMarkdown {
+		t.Fatalf("\t\t%s\tShould get a %q book Markdown description: %q", failed, storedData.DescriptionMarkdown,
+			testBookDescriptionMarkdown)
+	}
+	if storedData.DescriptionText != testBookDescriptionText {
+		t.Fatalf("\t\t%s\tShould get a %q book text description: %q", failed, storedData.DescriptionText,
+			testBookDescriptionText)
+	}
 	if storedData.ISBN10 != testBookISBN10 {
 		t.Fatalf("\t\t%s\tShould get a %q book ISBN10: %q", failed, storedData.ISBN10, testBookISBN10)
 	}
@@ -122,6 +148,9 @@ func TestPostgresStore_Find(t *testing.T) {
 	if storedData.ASIN != testBookASIN {
 		t.Fatalf("\t\t%s\tShould get a %q book ASIN: %q", failed, storedData.ASIN, testBookASIN)
 	}
+	if storedData.DOI != testBookDOI {
+		t.Fatalf("\t\t%s\tShould get a %q book DOI: %q", failed, storedData.DOI, testBookDOI)
+	}
 	if storedData.Pages != testBookPages {
 		t.Fatalf("\t\t%s\tShould get a %d book pages: %d", failed, storedData.Pages, testBookPages)
 	}
@@ -135,12 +164,27 @@ func TestPostgresStore_Find(t *testing.T) {
 		t.Fatalf("\t\t%s\tShould get a %q book publisher URL: %q", failed, storedData.PublisherURL,
 			testBookPublisherURL)
 	}
-	if storedData.Edition != testBookEdition {
-		t.Fatalf("\t\t%s\tShould get a %d book edition: %d", failed, storedData.Edition, testBookEdition)
+	if storedData.Edition.Number != testBookEdition {
+		t.Fatalf("\t\t%s\tShould get a %d book edition: %d", failed, storedData.Edition.Number, testBookEdition)
+	}
+	if storedData.Edition.Qualifier != testBookEditionQualifier {
+		t.Fatalf("\t\t%s\tShould get a %q book edition qualifier: %q", failed, storedData.Edition.Qualifier,
+			testBookEditionQualifier)
 	}
 	if storedData.PubDate != nowTime {
 		t.Fatalf("\t\t%s\tShould get a %q book publish date: %q", failed, storedData.PubDate, nowTime)
 	}
+	if storedData.PubDatePrecision != testPublishDatePrecision {
+		t.Fatalf("\t\t%s\tShould get a %v book publish date precision: %v", failed, testPublishDatePrecision,
+			storedData.PubDatePrecision)
+	}
+	if storedData.Series != testBookSeries {
+		t.Fatalf("\t\t%s\tShould get a %q book series: %q", failed, storedData.Series, testBookSeries)
+	}
+	if storedData.SeriesVolume != testBookSeriesVolume {
+		t.Fatalf("\t\t%s\tShould get a %d book series volume: %d", failed, storedData.SeriesVolume,
+			testBookSeriesVolume)
+	}
 	if storedData.BookFileName != testBookFileName {
 		t.Fatalf("\t\t%s\tShould get a %q book file name: %q", failed, storedData.BookFileName, testBookFileName)
 	}
@@ -156,8 +200,10 @@ func TestPostgresStore_Find(t *testing.T) {
 	if storedData.UpdatedAt != nowTime {
 		t.Fatalf("\t\t%s\tShould get a %q book update date: %q", failed, storedData.UpdatedAt, nowTime)
 	}
-	if len(storedData.Authors) != 1 && storedData.Authors[0] != testBookAuthorName {
-		t.Fatalf("\t\t%s\tShould get a %q book author: %q", failed, storedData.Authors, testBookAuthorName)
+	expectedContributors := []Contributor{{Name: testBookAuthorName, Role: testBookAuthorRole}}
+	if !reflect.DeepEqual(storedData.Contributors, expectedContributors) {
+		t.Fatalf("\t\t%s\tShould get a %+v book contributors: %+v", failed, expectedContributors,
+			storedData.Contributors)
 	}
 	if len(storedData.Categories) != 1 && storedData.Categories[0] != testBookCategoryName {
 		t.Fatalf("\t\t%s\tShould get a %q book category: %q", failed, storedData.Categories, testBookCategoryName)
@@ -191,9 +237,10 @@ func TestPostgresStore_Add(t *testing.T) {
 		Return(testBookLanguageID, nil).Times(1)
 
 	mockAuthorStore := author.NewMockStore(ctrl)
-	mockAuthorStore.EXPECT().UpsertAll(gomock.Any(), gomock.Eq(parsedData.Authors)).
+	mockAuthorStore.EXPECT().UpsertAll(gomock.Any(), gomock.Eq(parsedData.GetAuthorNames())).
 		Return([]int64{testBookAuthorID}, nil).Times(1)
-	mockAuthorStore.EXPECT().ReplaceBookAuthors(gomock.Any(), testBookID, []int64{testBookAuthorID}).
+	mockAuthorStore.EXPECT().ReplaceBookAuthors(gomock.Any(), testBookID,
+		[]author.BookAuthor{{AuthorID: testBookAuthorID, Role: testBookAuthorRole}}).
 		Return(nil).Times(1)
 
 	mockCategoryStore := category.NewMockStore(ctrl)
@@ -214,8 +261,12 @@ func TestPostgresStore_Add(t *testing.T) {
 	mockTagStore.EXPECT().ReplaceBookTags(gomock.Any(), testBookID, []int64{testBookTagID}).
 		Return(nil).Times(1)
 
+	mockSeriesStore := series.NewMockStore(ctrl)
+	mockSeriesStore.EXPECT().Upsert(gomock.Any(), gomock.Eq(parsedData.Series)).
+		Return(testBookSeriesID, nil).Times(1)
+
 	store := NewPostgresStore(db, mockPublisherStore, mockLanguageStore, mockAuthorStore, mockCategoryStore,
-		mockFileTypeStore, mockTagStore, log.Default())
+		mockFileTypeStore, mockTagStore, mockSeriesStore, log.Default())
 
 	mock.ExpectBegin()
 
@@ -225,7 +276,9 @@ func TestPostgresStore_Add(t *testing.T) {
 	addStmt := mock.ExpectPrepare(addBookQuery).WillBeClosed()
 	addStmt.ExpectQuery().WithArgs(testBookTitle, testBookSubtitle, testBookDescription, testBookISBN10,
 		testBookISBN13, testBookASIN, testBookPages, testBookLanguageID, testBookPublisherID, testBookPublisherURL,
-		testBookEdition, testPublishDate, testBookFileName, testBookFileSize, testBookCoverFileName).
+		testBookEdition, testPublishDate, testBookFileName, testBookFileSize, testBookCoverFileName, testBookDOI,
+		testBookSeriesID, testBookSeriesVolume, testBookDescriptionMarkdown, testBookDescriptionText,
+		testBookEditionQualifier, nil, testPublishDatePrecision).
 		WillReturnRows(resultAdd).RowsWillBeClosed()
 	mock.ExpectCommit()
 
@@ -261,9 +314,10 @@ func TestPostgresStore_Update(t *testing.T) {
 		Return(testBookLanguageID, nil).Times(1)
 
 	mockAuthorStore := author.NewMockStore(ctrl)
-	mockAuthorStore.EXPECT().UpsertAll(gomock.Any(), gomock.Eq(parsedData.Authors)).
+	mockAuthorStore.EXPECT().UpsertAll(gomock.Any(), gomock.Eq(parsedData.GetAuthorNames())).
 		Return([]int64{testBookAuthorID}, nil).Times(1)
-	mockAuthorStore.EXPECT().ReplaceBookAuthors(gomock.Any(), testBookID, []int64{testBookAuthorID}).
+	mockAuthorStore.EXPECT().ReplaceBookAuthors(gomock.Any(), testBookID,
+		[]author.BookAuthor{{AuthorID: testBookAuthorID, Role: testBookAuthorRole}}).
 		Return(nil).Times(1)
 
 	mockCategoryStore := category.NewMockStore(ctrl)
@@ -284,15 +338,21 @@ func TestPostgresStore_Update(t *testing.T) {
 	mockTagStore.EXPECT().ReplaceBookTags(gomock.Any(), testBookID, []int64{testBookTagID}).
 		Return(nil).Times(1)
 
+	mockSeriesStore := series.NewMockStore(ctrl)
+	mockSeriesStore.EXPECT().Upsert(gomock.Any(), gomock.Eq(parsedData.Series)).
+		Return(testBookSeriesID, nil).Times(1)
+
 	store := NewPostgresStore(db, mockPublisherStore, mockLanguageStore, mockAuthorStore, mockCategoryStore,
-		mockFileTypeStore, mockTagStore, log.Default())
+		mockFileTypeStore, mockTagStore, mockSeriesStore, log.Default())
 
 	mock.ExpectBegin()
 
 	updateStmt := mock.ExpectPrepare(updateBookQuery).WillBeClosed()
 	updateStmt.ExpectExec().WithArgs(testBookTitle, testBookSubtitle, testBookDescription, testBookISBN10,
 		testBookISBN13, testBookASIN, testBookPages, testBookLanguageID, testBookPublisherID, testBookPublisherURL,
-		testBookEdition, testPublishDate, testBookFileName, testBookFileSize, testBookCoverFileName, testBookID).
+		testBookEdition, testPublishDate, testBookFileName, testBookFileSize, testBookCoverFileName, testBookDOI,
+		testBookSeriesID, testBookSeriesVolume, testBookDescriptionMarkdown, testBookDescriptionText,
+		testBookEditionQualifier, nil, testPublishDatePrecision, testBookID).
 		WillReturnResult(sqlmock.NewResult(testBookID, 1))
 	mock.ExpectCommit()
 
@@ -304,6 +364,91 @@ func TestPostgresStore_Update(t *testing.T) {
 	t.Logf("\t\t%s\tShould successfully update a book", succeed)
 }
 
+// capturedArgument matches any SQL argument, and keeps its driver value.
+type capturedArgument struct {
+	value driver.Value
+}
+
+func (a *capturedArgument) Match(value driver.Value) bool {
+	a.value = value
+	return true
+}
+
+func TestPostgresStore_PubDatePrecisionRoundTrip(t *testing.T) {
+	t.Log("Given the need to test the publication date precision storing.")
+	db, mock := initMockDB(t)
+	defer db.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPublisherStore := publisher.NewMockStore(ctrl)
+	mockPublisherStore.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(testBookPublisherID, nil).AnyTimes()
+	mockLanguageStore := lang.NewMockStore(ctrl)
+	mockLanguageStore.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(testBookLanguageID, nil).AnyTimes()
+	mockAuthorStore := author.NewMockStore(ctrl)
+	mockAuthorStore.EXPECT().UpsertAll(gomock.Any(), gomock.Any()).Return([]int64{testBookAuthorID}, nil).AnyTimes()
+	mockAuthorStore.EXPECT().ReplaceBookAuthors(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockCategoryStore := category.NewMockStore(ctrl)
+	mockCategoryStore.EXPECT().UpsertAll(gomock.Any(), gomock.Any()).Return([]int64{testBookCategoryID}, nil).AnyTimes()
+	mockCategoryStore.EXPECT().ReplaceBookCategories(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockFileTypeStore := filetype.NewMockStore(ctrl)
+	mockFileTypeStore.EXPECT().UpsertAll(gomock.Any(), gomock.Any()).Return([]int64{testBookFileTypeID}, nil).AnyTimes()
+	mockFileTypeStore.EXPECT().ReplaceBookFileTypes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockTagStore := tag.NewMockStore(ctrl)
+	mockTagStore.EXPECT().UpsertAll(gomock.Any(), gomock.Any()).Return([]int64{testBookTagID}, nil).AnyTimes()
+	mockTagStore.EXPECT().ReplaceBookTags(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockSeriesStore := series.NewMockStore(ctrl)
+	mockSeriesStore.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(testBookSeriesID, nil).AnyTimes()
+	store := NewPostgresStore(db, mockPublisherStore, mockLanguageStore, mockAuthorStore, mockCategoryStore,
+		mockFileTypeStore, mockTagStore, mockSeriesStore, log.Default())
+
+	// A year-only publication date is stored as January 1, with the year precision
+	parsedData := getTestParsedData()
+	parsedData.PubDate = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	parsedData.PubDatePrecision = parser.DatePrecisionYear
+	storedPrecision := &capturedArgument{}
+	args := make([]driver.Value, 0, 23)
+	for i := 0; i < 22; i++ {
+		args = append(args, sqlmock.AnyArg())
+	}
+	mock.ExpectBegin()
+	mock.ExpectPrepare(addBookQuery).WillBeClosed().ExpectQuery().WithArgs(append(args, storedPrecision)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testBookID)).RowsWillBeClosed()
+	mock.ExpectCommit()
+	if _, err := store.Add(context.Background(), parsedData); err != nil {
+		t.Fatalf("\t\t%s\tShould be able to add a book: %v", failed, err)
+	}
+
+	rows := sqlmock.NewRows([]string{
+		"id", "title", "subtitle", "description", "description_markdown", "description_text", "isbn10", "isbn13",
+		"asin", "doi", "pages", "lang_name", "pub_name", "publisher_url", "edition", "edition_qualifier",
+		"edition_year", "pub_date", "pub_date_precision", "series_name", "series_volume", "book_file_name",
+		"book_file_size", "cover_file_name", "created_at", "updated_at", "author_name", "author_role",
+		"category_name", "file_type_name", "tag_name",
+	}).AddRow(testBookID, testBookTitle, nil, testBookDescription, nil, nil, testBookISBN10, nil, nil, nil,
+		testBookPages, testBookLanguage, testBookPublisher, testBookPublisherURL, testBookEdition, nil, nil,
+		parsedData.PubDate, storedPrecision.value, nil, nil, testBookFileName, testBookFileSize,
+		testBookCoverFileName, testCreateDate, testCreateDate, nil, nil, nil, nil, nil)
+	mock.ExpectBegin()
+	mock.ExpectPrepare(findBookQuery).WillBeClosed().ExpectQuery().WillReturnRows(rows).RowsWillBeClosed()
+	mock.ExpectCommit()
+	storedData, err := store.Find(context.Background(), SearchRequest{ISBN10: testBookISBN10})
+	if err != nil || storedData == nil {
+		t.Fatalf("\t\t%s\tShould be able to find a book: %v", failed, err)
+	}
+
+	// The stored precision is kept, if the book is updated without a new publication date
+	var updatedData ParsedData
+	mapToParsedDate(storedData, &updatedData)
+	if updatedData.PubDate != parsedData.PubDate || updatedData.PubDatePrecision != parser.DatePrecisionYear {
+		t.Fatalf("\t\t%s\tShould get the %v publish date with the %v precision: %v, %v", failed,
+			parsedData.PubDate, parser.DatePrecisionYear, updatedData.PubDate, updatedData.PubDatePrecision)
+	}
+
+	t.Logf("\t\t%s\tShould keep the publication date precision", succeed)
+}
+
 func initMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -312,3 +457,29 @@ func initMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
 
 	return db, mock
 }
+
+func TestCompleteISBNs(t *testing.T) {
+	tests := []struct {
+		isbn10         string
+		isbn13         int64
+		expectedISBN10 string
+		expectedISBN13 int64
+	}{
+		{isbn10: "0-306-40615-2", expectedISBN10: "0306406152", expectedISBN13: 9780306406157},
+		{isbn13: 9783161484100, expectedISBN10: "316148410X", expectedISBN13: 9783161484100},
+		{isbn13: 9791090636071, expectedISBN13: 9791090636071},
+		{isbn10: testBookISBN10, expectedISBN10: testBookISBN10},
+	}
+
+	t.Log("Given the need to test book ISBNs completion.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q and %d ISBNs\n", i, tt.isbn10, tt.isbn13)
+		isbn10, isbn13 := completeISBNs(tt.isbn10, tt.isbn13)
+		if isbn10 != tt.expectedISBN10 || isbn13 != tt.expectedISBN13 {
+			t.Errorf("\t\t%s\tShould get the %q and %d ISBNs: %q, %d", failed, tt.expectedISBN10,
+				tt.expectedISBN13, isbn10, isbn13)
+		} else {
+			t.Logf("\t\t%s\tShould be able to complete the ISBNs.", succeed)
+		}
+	}
+}
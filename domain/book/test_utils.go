@@ -2,6 +2,7 @@ package book
 
 import (
 	"database/sql"
+	"github.com/sdreger/lib-file-processor-go/parser"
 	"time"
 )
 
@@ -9,112 +10,142 @@ const (
 	succeed = "\u2713"
 	failed  = "\u2717"
 
-	testBookID            = int64(100)
-	testBookTitle         = "Test title"
-	testBookSubtitle      = "Test subtitle"
-	testBookDescription   = "Test description"
-	testBookISBN10        = "1573273281"
-	testBookISBN13        = 9781573273281
-	testBookASIN          = "B08HG2JYS2"
-	testBookPages         = 355
-	testBookLanguageID    = int64(1)
-	testBookLanguage      = "Test language"
-	testBookPublisher     = "Test publisher"
-	testBookPublisherID   = int64(1)
-	testBookPublisherURL  = "https://test.pub/1573273281"
-	testBookEdition       = 3
-	testBookFileName      = "Test book name"
-	testBookFileSize      = 5000
-	testBookCoverFileName = "Test book cover name"
-	testBookAuthorName    = "Test author name"
-	testBookAuthorID      = int64(1)
-	testBookCategoryName  = "Test category name"
-	testBookCategoryID    = int64(1)
-	testBookFileTypeName  = "Test filetype name"
-	testBookFileTypeID    = int64(1)
-	testBookTagName       = "Test tag name"
-	testBookTagID         = int64(1)
+	testBookID                  = int64(100)
+	testBookTitle               = "Test title"
+	testBookSubtitle            = "Test subtitle"
+	testBookDescription         = "Test description"
+	testBookDescriptionMarkdown = "Test **description**"
+	testBookDescriptionText     = "Test description text"
+	testBookISBN10              = "1573273281"
+	testBookISBN13              = 9781573273281
+	testBookASIN                = "B08HG2JYS2"
+	testBookDOI                 = "10.1007/978-1-4842-6579-5"
+	testBookPages               = 355
+	testBookLanguageID          = int64(1)
+	testBookLanguage            = "Test language"
+	testBookPublisher           = "Test publisher"
+	testBookPublisherID         = int64(1)
+	testBookPublisherURL        = "https://test.pub/1573273281"
+	testBookEdition             = 3
+	testBookEditionQualifier    = "Revised"
+	testBookSeries              = "Test series"
+	testBookSeriesID            = int64(1)
+	testBookSeriesVolume        = 2
+	testBookFileName            = "Test book name"
+	testBookFileSize            = 5000
+	testBookCoverFileName       = "Test book cover name"
+	testBookAuthorName          = "Test author name"
+	testBookAuthorRole          = "editor"
+	testBookAuthorID            = int64(1)
+	testBookCategoryName        = "Test category name"
+	testBookCategoryID          = int64(1)
+	testBookFileTypeName        = "Test filetype name"
+	testBookFileTypeID          = int64(1)
+	testBookTagName             = "Test tag name"
+	testBookTagID               = int64(1)
 )
 
 var (
 	testPublishDate, _ = time.Parse(time.RFC3339, "2020-02-20T05:15:45Z")
 	testCreateDate, _  = time.Parse(time.RFC3339, "2022-07-30T12:20:00Z")
+
+	testPublishDatePrecision = parser.DatePrecisionMonth
 )
 
 func getTestProductRow() dotProductRow {
 	return dotProductRow{
-		ID:            testBookID,
-		Title:         testBookTitle,
-		Subtitle:      sql.NullString{String: testBookSubtitle, Valid: true},
-		Description:   testBookDescription,
-		ISBN10:        sql.NullString{String: testBookISBN10, Valid: true},
-		ISBN13:        sql.NullInt64{Int64: testBookISBN13, Valid: true},
-		ASIN:          sql.NullString{String: testBookASIN, Valid: true},
-		Pages:         testBookPages,
-		Language:      testBookLanguage,
-		Publisher:     testBookPublisher,
-		PublisherURL:  testBookPublisherURL,
-		Edition:       testBookEdition,
-		PubDate:       testPublishDate,
-		BookFileName:  testBookFileName,
-		BookFileSize:  testBookFileSize,
-		CoverFileName: testBookCoverFileName,
-		CreatedAt:     testCreateDate,
-		UpdatedAt:     testCreateDate,
-		AuthorName:    sql.NullString{String: testBookAuthorName, Valid: true},
-		CategoryName:  sql.NullString{String: testBookCategoryName, Valid: true},
-		FileTypeName:  sql.NullString{String: testBookFileTypeName, Valid: true},
-		TagName:       sql.NullString{String: testBookTagName, Valid: true},
+		ID:                  testBookID,
+		Title:               testBookTitle,
+		Subtitle:            sql.NullString{String: testBookSubtitle, Valid: true},
+		Description:         testBookDescription,
+		DescriptionMarkdown: sql.NullString{String: testBookDescriptionMarkdown, Valid: true},
+		DescriptionText:     sql.NullString{String: testBookDescriptionText, Valid: true},
+		ISBN10:              sql.NullString{String: testBookISBN10, Valid: true},
+		ISBN13:              sql.NullInt64{Int64: testBookISBN13, Valid: true},
+		ASIN:                sql.NullString{String: testBookASIN, Valid: true},
+		DOI:                 sql.NullString{String: testBookDOI, Valid: true},
+		Pages:               testBookPages,
+		Language:            testBookLanguage,
+		Publisher:           testBookPublisher,
+		PublisherURL:        testBookPublisherURL,
+		Edition:             testBookEdition,
+		EditionQualifier:    sql.NullString{String: testBookEditionQualifier, Valid: true},
+		PubDate:             testPublishDate,
+		PubDatePrecision:    testPublishDatePrecision,
+		Series:              sql.NullString{String: testBookSeries, Valid: true},
+		SeriesVolume:        sql.NullInt32{Int32: testBookSeriesVolume, Valid: true},
+		BookFileName:        testBookFileName,
+		BookFileSize:        testBookFileSize,
+		CoverFileName:       testBookCoverFileName,
+		CreatedAt:           testCreateDate,
+		UpdatedAt:           testCreateDate,
+		AuthorName:          sql.NullString{String: testBookAuthorName, Valid: true},
+		AuthorRole:          sql.NullString{String: testBookAuthorRole, Valid: true},
+		CategoryName:        sql.NullString{String: testBookCategoryName, Valid: true},
+		FileTypeName:        sql.NullString{String: testBookFileTypeName, Valid: true},
+		TagName:             sql.NullString{String: testBookTagName, Valid: true},
 	}
 }
 
 func getTestStoredData() StoredData {
 	return StoredData{
-		ID:            testBookID,
-		Title:         testBookTitle,
-		Subtitle:      testBookSubtitle,
-		Description:   testBookDescription,
-		ISBN10:        testBookISBN10,
-		ISBN13:        testBookISBN13,
-		ASIN:          testBookASIN,
-		Pages:         testBookPages,
-		Language:      testBookLanguage,
-		Publisher:     testBookPublisher,
-		PublisherURL:  testBookPublisherURL,
-		Edition:       testBookEdition,
-		PubDate:       testPublishDate,
-		BookFileName:  testBookFileName,
-		BookFileSize:  testBookFileSize,
-		CoverFileName: testBookCoverFileName,
-		CreatedAt:     testCreateDate,
-		UpdatedAt:     testCreateDate,
-		Authors:       []string{testBookAuthorName},
-		Categories:    []string{testBookCategoryName},
-		Tags:          []string{testBookTagName},
-		Formats:       []string{testBookFileTypeName},
+		ID:                  testBookID,
+		Title:               testBookTitle,
+		Subtitle:            testBookSubtitle,
+		Description:         testBookDescription,
+		DescriptionMarkdown: testBookDescriptionMarkdown,
+		DescriptionText:     testBookDescriptionText,
+		ISBN10:              testBookISBN10,
+		ISBN13:              testBookISBN13,
+		ASIN:                testBookASIN,
+		DOI:                 testBookDOI,
+		Pages:               testBookPages,
+		Language:            testBookLanguage,
+		Publisher:           testBookPublisher,
+		PublisherURL:        testBookPublisherURL,
+		Edition:             parser.Edition{Number: testBookEdition, Qualifier: testBookEditionQualifier},
+		PubDate:             testPublishDate,
+		PubDatePrecision:    testPublishDatePrecision,
+		Series:              testBookSeries,
+		SeriesVolume:        testBookSeriesVolume,
+		BookFileName:        testBookFileName,
+		BookFileSize:        testBookFileSize,
+		CoverFileName:       testBookCoverFileName,
+		CreatedAt:           testCreateDate,
+		UpdatedAt:           testCreateDate,
+		Contributors:        []Contributor{{Name: testBookAuthorName, Role: testBookAuthorRole}},
+		Categories:          []string{testBookCategoryName},
+		Tags:                []string{testBookTagName},
+		Formats:             []string{testBookFileTypeName},
 	}
 }
 
 func getTestParsedData() ParsedData {
 	return ParsedData{
-		Title:         testBookTitle,
-		Subtitle:      testBookSubtitle,
-		Description:   testBookDescription,
-		ISBN10:        testBookISBN10,
-		ISBN13:        testBookISBN13,
-		ASIN:          testBookASIN,
-		Pages:         testBookPages,
-		Language:      testBookLanguage,
-		Publisher:     testBookPublisher,
-		PublisherURL:  testBookPublisherURL,
-		Edition:       testBookEdition,
-		PubDate:       testPublishDate,
-		Authors:       []string{testBookAuthorName},
-		Categories:    []string{testBookCategoryName},
-		Tags:          []string{testBookTagName},
-		Formats:       []string{testBookFileTypeName},
-		BookFileName:  testBookFileName,
-		BookFileSize:  testBookFileSize,
-		CoverFileName: testBookCoverFileName,
+		Title:               testBookTitle,
+		Subtitle:            testBookSubtitle,
+		Description:         testBookDescription,
+		DescriptionMarkdown: testBookDescriptionMarkdown,
+		DescriptionText:     testBookDescriptionText,
+		ISBN10:              testBookISBN10,
+		ISBN13:              testBookISBN13,
+		ASIN:                testBookASIN,
+		DOI:                 testBookDOI,
+		Pages:               testBookPages,
+		Language:            testBookLanguage,
+		Publisher:           testBookPublisher,
+		PublisherURL:        testBookPublisherURL,
+		Edition:             parser.Edition{Number: testBookEdition, Qualifier: testBookEditionQualifier},
+		PubDate:             testPublishDate,
+		PubDatePrecision:    testPublishDatePrecision,
+		Series:              testBookSeries,
+		SeriesVolume:        testBookSeriesVolume,
+		Contributors:        []Contributor{{Name: testBookAuthorName, Role: testBookAuthorRole}},
+		Categories:          []string{testBookCategoryName},
+		Tags:                []string{testBookTagName},
+		Formats:             []string{testBookFileTypeName},
+		BookFileName:        testBookFileName,
+		BookFileSize:        testBookFileSize,
+		CoverFileName:       testBookCoverFileName,
 	}
 }
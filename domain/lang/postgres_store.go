@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/sdreger/lib-file-processor-go/db/transaction"
+	"github.com/sdreger/lib-file-processor-go/parser"
 	"io"
 	"log"
 )
@@ -21,22 +22,30 @@ func NewPostgresStore(db *sql.DB, logger *log.Logger) PostgresStore {
 	}
 }
 
-// Upsert adds a new language to DB if it doesn't exist.
+// Upsert adds a new language to DB if it doesn't exist. The language is normalized to the English name and the
+// ISO 639 code, like: 'Englisch' -> 'English' ('en'), and matched by the code, so the display names of different
+// locales are stored once. A language without a known code is matched by the name.
 // Returns an inserted ID, or an existing ID, if the language already exist.
 func (s PostgresStore) Upsert(ctx context.Context, language string) (int64, error) {
+	language, code := parser.NormalizeLanguage(language)
 	if language == "" {
 		return 0, fmt.Errorf("the lang name should not be blank")
 	}
 
+	selectQuery, selectArg := "SELECT id FROM ebook.languages WHERE name = $1", language
+	if code != "" {
+		selectQuery, selectArg = "SELECT id FROM ebook.languages WHERE code = $1", code
+	}
+
 	var languageID int64
 	err := transaction.WithTransaction(ctx, s.db, func(txCtx context.Context, tx *sql.Tx) error {
-		selectStmt, err := tx.PrepareContext(txCtx, "SELECT id FROM ebook.languages WHERE name = $1")
+		selectStmt, err := tx.PrepareContext(txCtx, selectQuery)
 		if err != nil {
 			return err
 		}
 		defer s.closeResource(selectStmt)
 
-		row := selectStmt.QueryRowContext(txCtx, language)
+		row := selectStmt.QueryRowContext(txCtx, selectArg)
 		err = row.Scan(&languageID)
 		if err == nil {
 			//log.Printf("[INFO] - Existing language ID: %d", languageID)
@@ -46,13 +55,14 @@ func (s PostgresStore) Upsert(ctx context.Context, language string) (int64, erro
 			return err
 		}
 
-		insertStmt, err := s.db.PrepareContext(txCtx, "INSERT INTO ebook.languages(name) VALUES ($1) RETURNING id")
+		insertStmt, err := s.db.PrepareContext(txCtx,
+			"INSERT INTO ebook.languages(name, code) VALUES ($1, $2) RETURNING id")
 		if err != nil {
 			return err
 		}
 		defer s.closeResource(insertStmt)
 
-		if err := insertStmt.QueryRowContext(txCtx, language).Scan(&languageID); err != nil {
+		if err := insertStmt.QueryRowContext(txCtx, language, getNullableString(code)).Scan(&languageID); err != nil {
 			return err
 		}
 		s.logger.Printf("[INFO] - Stored language ID: %d", languageID)
@@ -73,3 +83,7 @@ func (s PostgresStore) closeResource(rows io.Closer) {
 		s.logger.Printf("[ERROR] - %v", err)
 	}
 }
+
+func getNullableString(val string) sql.NullString {
+	return sql.NullString{String: val, Valid: val != ""}
+}
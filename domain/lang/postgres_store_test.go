@@ -11,6 +11,12 @@ import (
 const (
 	succeed = "\u2713"
 	failed  = "\u2717"
+
+	insertLanguageQuery    = "INSERT INTO ebook.languages\\(name, code\\) VALUES \\(\\$1, \\$2\\) RETURNING id"
+	selectLanguageByCode   = "SELECT id FROM ebook.languages WHERE code = \\$1"
+	localizedLanguage      = "Englisch"
+	normalizedLanguage     = "English"
+	normalizedLanguageCode = "en"
 )
 
 var (
@@ -21,6 +27,8 @@ func TestStore_Upsert(t *testing.T) {
 	t.Log("Given the need to test language upsert")
 	t.Run("Upsert a new record", testUpsertOneNew)
 	t.Run("Upsert an existing record", testUpsertOneExisting)
+	t.Run("Upsert a new record with a language code", testUpsertOneNewWithCode)
+	t.Run("Upsert an existing record with a language code", testUpsertOneExistingWithCode)
 	t.Run("Do not upsert language with empty language slice", testUpsertNoLanguage)
 }
 
@@ -41,9 +49,8 @@ func testUpsertOneNew(t *testing.T) {
 	selectPrepare.ExpectQuery().WithArgs(languageToInsert).WillReturnRows(rowsSelected).RowsWillBeClosed()
 
 	// One new insert
-	insertPrepare := mock.ExpectPrepare("INSERT INTO ebook.languages\\(name\\) VALUES \\(\\$1\\) RETURNING id").
-		WillBeClosed()
-	insertPrepare.ExpectQuery().WithArgs(languageToInsert).WillReturnRows(rowsInserted.AddRow(newLanguageID))
+	insertPrepare := mock.ExpectPrepare(insertLanguageQuery).WillBeClosed()
+	insertPrepare.ExpectQuery().WithArgs(languageToInsert, nil).WillReturnRows(rowsInserted.AddRow(newLanguageID))
 	mock.ExpectCommit()
 
 	languageID, err := store.Upsert(context.Background(), languageToInsert)
@@ -94,6 +101,71 @@ func testUpsertOneExisting(t *testing.T) {
 	t.Logf("\t\t%s\tShould be able to add language", succeed)
 }
 
+func testUpsertOneNewWithCode(t *testing.T) {
+	t.Logf("\t\tWhen checking for insertion of a new language with a language code\n")
+
+	var newLanguageID int64 = 1
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	selectPrepare := mock.ExpectPrepare(selectLanguageByCode).WillBeClosed()
+	selectPrepare.ExpectQuery().WithArgs(normalizedLanguageCode).WillReturnRows(sqlmock.NewRows([]string{"id"})).
+		RowsWillBeClosed()
+
+	// One new insert with the normalized name
+	insertPrepare := mock.ExpectPrepare(insertLanguageQuery).WillBeClosed()
+	insertPrepare.ExpectQuery().WithArgs(normalizedLanguage, normalizedLanguageCode).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newLanguageID))
+	mock.ExpectCommit()
+
+	languageID, err := store.Upsert(context.Background(), localizedLanguage)
+	if err != nil {
+		t.Errorf("\t\t%s\tShould be able to get upserted language ID: %v", failed, err)
+	}
+
+	if languageID != newLanguageID {
+		t.Errorf("\t\t%s\tShould get a %d language ID: %d", failed, newLanguageID, languageID)
+	}
+
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to add language with a language code", succeed)
+}
+
+func testUpsertOneExistingWithCode(t *testing.T) {
+	t.Logf("\t\tWhen checking for insertion of an existing language with a language code\n")
+
+	var existingLanguageID int64 = 1
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	selectPrepare := mock.ExpectPrepare(selectLanguageByCode).WillBeClosed()
+	selectPrepare.ExpectQuery().WithArgs(normalizedLanguageCode).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(existingLanguageID))
+
+	// No new insert
+	mock.ExpectCommit()
+
+	languageID, err := store.Upsert(context.Background(), localizedLanguage)
+	if err != nil {
+		t.Errorf("\t\t%s\tShould be able to get upserted language ID: %v", failed, err)
+	}
+
+	if languageID != existingLanguageID {
+		t.Errorf("\t\t%s\tShould get a %d language ID: %d", failed, existingLanguageID, languageID)
+	}
+
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to match language by the language code", succeed)
+}
+
 func testUpsertNoLanguage(t *testing.T) {
 	db, _ := initMockDB(t)
 	defer db.Close()
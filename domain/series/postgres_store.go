@@ -0,0 +1,75 @@
+package series
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/sdreger/lib-file-processor-go/db/transaction"
+	"io"
+	"log"
+)
+
+type PostgresStore struct {
+	db     *sql.DB
+	logger *log.Logger
+}
+
+func NewPostgresStore(db *sql.DB, logger *log.Logger) PostgresStore {
+	return PostgresStore{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Upsert adds a new series to DB if it doesn't exist.
+// Returns an inserted ID, or an existing ID, if the series already exist.
+func (s PostgresStore) Upsert(ctx context.Context, series string) (int64, error) {
+	if series == "" {
+		return 0, fmt.Errorf("the series name should not be blank")
+	}
+
+	var seriesID int64
+	err := transaction.WithTransaction(ctx, s.db, func(txCtx context.Context, tx *sql.Tx) error {
+		selectStmt, err := tx.PrepareContext(txCtx, "SELECT id FROM ebook.series WHERE name = $1")
+		if err != nil {
+			return err
+		}
+		defer s.closeResource(selectStmt)
+
+		row := selectStmt.QueryRowContext(txCtx, series)
+		err = row.Scan(&seriesID)
+		if err == nil {
+			s.logger.Printf("[INFO] - Existing series ID: %d", seriesID)
+			return nil
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		insertStmt, err := tx.PrepareContext(txCtx, "INSERT INTO ebook.series(name) VALUES ($1) RETURNING id")
+		if err != nil {
+			return err
+		}
+		defer s.closeResource(insertStmt)
+
+		if err := insertStmt.QueryRowContext(txCtx, series).Scan(&seriesID); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	s.logger.Printf("[INFO] - Stored series ID: %d", seriesID)
+
+	return seriesID, nil
+}
+
+func (s PostgresStore) closeResource(rows io.Closer) {
+	err := rows.Close()
+	if err != nil {
+		s.logger.Printf("[ERROR] - %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package series
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"log"
+	"testing"
+)
+
+const (
+	succeed = "\u2713"
+	failed  = "\u2717"
+)
+
+var (
+	seriesToInsert = "Test Series"
+)
+
+func TestStore_Upsert(t *testing.T) {
+	t.Log("Given the need to test series upsert")
+	t.Run("Upsert a new record", testUpsertOneNew)
+	t.Run("Upsert an existing record", testUpsertOneExisting)
+	t.Run("Do not upsert series with empty series slice", testUpsertNoSeries)
+}
+
+func testUpsertOneNew(t *testing.T) {
+	t.Logf("\t\tWhen checking for insertion of a new series\n")
+
+	var newSeriesID int64 = 1
+	rowsSelected := sqlmock.NewRows([]string{"id"})
+	rowsInserted := sqlmock.NewRows([]string{"id"})
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	selectPrepare := mock.ExpectPrepare("SELECT id FROM ebook.series WHERE name = \\$1").
+		WillBeClosed()
+	selectPrepare.ExpectQuery().WithArgs(seriesToInsert).WillReturnRows(rowsSelected).RowsWillBeClosed()
+
+	// One new insert
+	insertPrepare := mock.ExpectPrepare("INSERT INTO ebook.series\\(name\\) VALUES \\(\\$1\\) RETURNING id").
+		WillBeClosed()
+	insertPrepare.ExpectQuery().WithArgs(seriesToInsert).WillReturnRows(rowsInserted.AddRow(newSeriesID))
+	mock.ExpectCommit()
+
+	seriesID, err := store.Upsert(context.Background(), seriesToInsert)
+	if err != nil {
+		t.Errorf("\t\t%s\tShould be able to get upserted series ID: %v", failed, err)
+	}
+
+	if seriesID != newSeriesID {
+		t.Errorf("\t\t%s\tShould get a %d series ID: %d", failed, newSeriesID, seriesID)
+	}
+
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to add series", succeed)
+}
+
+func testUpsertOneExisting(t *testing.T) {
+	t.Logf("\t\tWhen checking for insertion of an exisiting series\n")
+
+	var existingSeriesID int64 = 1
+	rowsSelected := sqlmock.NewRows([]string{"id"})
+
+	db, mock := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	mock.ExpectBegin()
+	selectPrepare := mock.ExpectPrepare("SELECT id FROM ebook.series WHERE name = \\$1").
+		WillBeClosed()
+	selectQuery := selectPrepare.ExpectQuery().WithArgs(seriesToInsert)
+	rowToReturn := rowsSelected.AddRow(existingSeriesID)
+	selectQuery.WillReturnRows(rowToReturn)
+
+	// No new insert
+	mock.ExpectCommit()
+
+	seriesID, err := store.Upsert(context.Background(), seriesToInsert)
+	if err != nil {
+		t.Errorf("\t\t%s\tShould be able to get upserted series ID: %v", failed, err)
+	}
+
+	if seriesID != existingSeriesID {
+		t.Errorf("\t\t%s\tShould get a %d series ID: %d", failed, existingSeriesID, seriesID)
+	}
+
+	assertMockExpectations(t, mock)
+
+	t.Logf("\t\t%s\tShould be able to add series", succeed)
+}
+
+func testUpsertNoSeries(t *testing.T) {
+	db, _ := initMockDB(t)
+	defer db.Close()
+	store := NewPostgresStore(db, log.Default())
+
+	seriesID, err := store.Upsert(context.Background(), "")
+	if err == nil {
+		t.Fatalf("\t\t%s\tShould not return an error when there is no series", failed)
+	}
+
+	if seriesID != 0 {
+		t.Fatalf("\t\t%s\tShould return an empty result when there is no series", failed)
+	}
+
+	t.Logf("\t\t%s\tShould return an empty result when there is no series", succeed)
+}
+
+func initMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to init the DB mock: %v", failed, err)
+	}
+
+	return db, mock
+}
+
+func assertMockExpectations(t *testing.T, mock sqlmock.Sqlmock) {
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("\t\t%s\tShould be able to fulfill all mock expectations: %v", failed, err)
+	}
+}
@@ -0,0 +1,8 @@
+package series
+
+import "context"
+
+//go:generate mockgen -destination=./store_mock.go -package=series github.com/sdreger/lib-file-processor-go/domain/series Store
+type Store interface {
+	Upsert(ctx context.Context, series string) (int64, error)
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"github.com/fsnotify/fsnotify"
 	"log"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
 )
 
+// FileSystemWatcher extracts new book zip archives, and sends the extracted book IDs to the BookIDChan.
+// New saved book page files (.html) are not extracted, their paths are sent to the BookIDChan instead.
 type FileSystemWatcher struct {
 	Watcher       *fsnotify.Watcher
 	Extractor     BookExtractor
@@ -103,6 +106,11 @@ func (w *FileSystemWatcher) handleMacosChanges(event fsnotify.Event) error {
 }
 
 func (w *FileSystemWatcher) handleNewFile(fileName string) error {
+	// Saved book product pages are not extracted, the page path is passed as is
+	if IsBookPageFile(fileName) {
+		w.BookIDChan <- fileName
+		return nil
+	}
 	err := w.Extractor.ExtractZipFile(fileName, w.PathToExtract)
 	if err != nil {
 		return fmt.Errorf("can not extract %q file: %w", fileName, err)
@@ -116,6 +124,12 @@ func (w *FileSystemWatcher) handleNewFile(fileName string) error {
 	return nil
 }
 
+// IsBookPageFile checks if the file is a book product page, saved in a browser.
+func IsBookPageFile(fileName string) bool {
+	extension := strings.ToLower(filepath.Ext(fileName))
+	return extension == ".html" || extension == ".htm"
+}
+
 func (w *FileSystemWatcher) Close() error {
 	close(w.BookIDChan)
 	return w.Watcher.Close()
@@ -0,0 +1,44 @@
+package isbn
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidBookID is returned when the book ID length matches neither ISBN10/ASIN, nor ISBN13.
+var ErrInvalidBookID = errors.New("invalid book ID")
+
+// ParseBookID normalizes the book ID input (like a hyphenated ISBN, or an ISBN13 from a barcode),
+// and detects its kind. The returned error explains what is wrong with the ID: the length, the characters,
+// the prefix or the check digit.
+func ParseBookID(input string) (string, Kind, error) {
+	id := Normalize(input)
+	switch {
+	case IsASIN(id):
+		return id, KindASIN, nil
+	case len(id) == isbn10Length:
+		if err := ValidateISBN10(id); err != nil {
+			return "", KindUnknown, err
+		}
+		return id, KindISBN10, nil
+	case len(id) == isbn13Length:
+		if err := ValidateISBN13(id); err != nil {
+			return "", KindUnknown, err
+		}
+		return id, KindISBN13, nil
+	}
+
+	return "", KindUnknown, fmt.Errorf("%w: %q has %d characters, expected an ISBN10 or ASIN (%d), or an ISBN13 (%d)",
+		ErrInvalidBookID, id, len(id), isbn10Length, isbn13Length)
+}
+
+// LooksLikeISBN returns true if the input consists of the ISBN characters only (digits, hyphens, spaces and
+// the 'X' check digit), and is long enough to be a mistyped ISBN, rather than a number in a search query.
+func LooksLikeISBN(input string) bool {
+	id := Normalize(input)
+	if len(id) < isbn10Length-1 {
+		return false
+	}
+
+	return isDigits(id[:len(id)-1]) && (isDigit(id[len(id)-1]) || id[len(id)-1] == 'X')
+}
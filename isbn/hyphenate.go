@@ -0,0 +1,118 @@
+package isbn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// rangeDigits is the number of the digits after the registration group, the registrant ranges are defined for
+const rangeDigits = 7
+
+// registrantRange maps the range of the digits after the registration group to the registrant element length
+type registrantRange struct {
+	min, max int
+	length   int
+}
+
+// registrationGroup is the ISBN registration group (a country, a geographic region or a language area)
+type registrationGroup struct {
+	prefix      string // the EAN prefix: '978' or '979'
+	group       string
+	registrants []registrantRange
+}
+
+// registrationGroups are the registrant ranges of the largest registration groups, taken from the
+// International ISBN Agency range message (RangeMessage.xml). The groups of the same prefix never are prefixes
+// of each other.
+var registrationGroups = []registrationGroup{
+	{prefix: prefix978, group: "0", registrants: []registrantRange{ // English language
+		{0, 1999999, 2}, {2000000, 2279999, 3}, {2280000, 2289999, 4}, {2290000, 3689999, 3},
+		{3690000, 3699999, 4}, {3700000, 6389999, 3}, {6390000, 6397999, 4}, {6398000, 6399999, 7},
+		{6400000, 6449999, 3}, {6450000, 6459999, 7}, {6460000, 6479999, 3}, {6480000, 6489999, 7},
+		{6490000, 6549999, 3}, {6550000, 6559999, 4}, {6560000, 6999999, 3}, {7000000, 8499999, 4},
+		{8500000, 8999999, 5}, {9000000, 9499999, 6}, {9500000, 9999999, 7},
+	}},
+	{prefix: prefix978, group: "1", registrants: []registrantRange{ // English language
+		{0, 999999, 2}, {1000000, 3999999, 3}, {4000000, 5499999, 4},
+		{5500000, 8697999, 5}, {8698000, 9989999, 6}, {9990000, 9999999, 7},
+	}},
+	{prefix: prefix978, group: "2", registrants: []registrantRange{ // French language
+		{0, 1999999, 2}, {2000000, 3499999, 3}, {3500000, 3999999, 5}, {4000000, 6999999, 3},
+		{7000000, 8399999, 4}, {8400000, 8999999, 5}, {9000000, 9499999, 6}, {9500000, 9999999, 7},
+	}},
+	{prefix: prefix978, group: "3", registrants: []registrantRange{ // German language
+		{0, 299999, 2}, {300000, 339999, 3}, {340000, 369999, 4}, {370000, 399999, 5},
+		{400000, 1999999, 2}, {2000000, 6999999, 3}, {7000000, 8499999, 4}, {8500000, 8999999, 5},
+		{9000000, 9499999, 6}, {9500000, 9539999, 7}, {9540000, 9699999, 5}, {9700000, 9849999, 7},
+		{9850000, 9999999, 5},
+	}},
+	{prefix: prefix978, group: "4", registrants: []registrantRange{ // Japan
+		{0, 1999999, 2}, {2000000, 6999999, 3}, {7000000, 8499999, 4},
+		{8500000, 8999999, 5}, {9000000, 9499999, 6}, {9500000, 9999999, 7},
+	}},
+	{prefix: prefix978, group: "7", registrants: []registrantRange{ // China
+		{0, 999999, 2}, {1000000, 4999999, 3}, {5000000, 7999999, 4},
+		{8000000, 8999999, 5}, {9000000, 9999999, 6},
+	}},
+	{prefix: prefix979, group: "10", registrants: []registrantRange{ // France
+		{0, 1999999, 2}, {2000000, 6999999, 3}, {7000000, 8999999, 4},
+		{9000000, 9759999, 5}, {9760000, 9999999, 6},
+	}},
+}
+
+// Hyphenate splits the ISBN10 or ISBN13 to the prefix, registration group, registrant, publication
+// and check digit elements, like: '978-0-306-40615-7'. Hyphens and spaces are ignored.
+// Returns ErrUnknownRange for the registration groups without known ranges.
+func Hyphenate(id string) (string, error) {
+	id = Normalize(id)
+	switch len(id) {
+	case isbn10Length:
+		if err := ValidateISBN10(id); err != nil {
+			return "", err
+		}
+		hyphenated, err := hyphenate(prefix978, id[:isbn10Length-1])
+		if err != nil {
+			return "", err
+		}
+		return hyphenated + "-" + id[isbn10Length-1:], nil
+	case isbn13Length:
+		if err := ValidateISBN13(id); err != nil {
+			return "", err
+		}
+		hyphenated, err := hyphenate(id[:3], id[3:isbn13Length-1])
+		if err != nil {
+			return "", err
+		}
+		return id[:3] + "-" + hyphenated + "-" + id[isbn13Length-1:], nil
+	}
+
+	return "", fmt.Errorf("%w: %q has %d characters, expected %d or %d",
+		ErrInvalidLength, id, len(id), isbn10Length, isbn13Length)
+}
+
+// hyphenate splits the 9 digits between the EAN prefix and the check digit.
+func hyphenate(prefix, body string) (string, error) {
+	for _, group := range registrationGroups {
+		if group.prefix != prefix || !strings.HasPrefix(body, group.group) {
+			continue
+		}
+		rest := body[len(group.group):]
+		rangeValue, err := strconv.Atoi((rest + strings.Repeat("0", rangeDigits))[:rangeDigits])
+		if err != nil {
+			return "", fmt.Errorf("%w: %q", ErrInvalidCharacter, body)
+		}
+		for _, registrant := range group.registrants {
+			if rangeValue < registrant.min || rangeValue > registrant.max {
+				continue
+			}
+			if registrant.length >= len(rest) {
+				break
+			}
+			return group.group + "-" + rest[:registrant.length] + "-" + rest[registrant.length:], nil
+		}
+		break
+	}
+
+	return "", fmt.Errorf("%w: %s-%s", ErrUnknownRange, prefix, body)
+}
@@ -0,0 +1,215 @@
+// Package isbn validates, converts and hyphenates the ISBN10 and ISBN13 book identifiers,
+// and tells them apart from the Amazon ASINs.
+package isbn
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	isbn10Length = 10
+	isbn13Length = 13
+	asinLength   = 10
+
+	prefix978 = "978"
+	prefix979 = "979"
+)
+
+// Kind is the book identifier kind.
+type Kind string
+
+const (
+	KindUnknown Kind = ""
+	KindISBN10  Kind = "ISBN10"
+	KindISBN13  Kind = "ISBN13"
+	KindASIN    Kind = "ASIN"
+)
+
+var (
+	// ErrInvalidLength is returned when the identifier has neither ISBN10, nor ISBN13 length.
+	ErrInvalidLength = errors.New("invalid ISBN length")
+	// ErrInvalidCharacter is returned when the identifier has non-digit characters (except the ISBN10 'X' check digit).
+	ErrInvalidCharacter = errors.New("invalid ISBN character")
+	// ErrInvalidChecksum is returned when the identifier check digit does not match.
+	ErrInvalidChecksum = errors.New("invalid ISBN checksum")
+	// ErrInvalidPrefix is returned when the ISBN13 prefix is neither '978', nor '979'.
+	ErrInvalidPrefix = errors.New("invalid ISBN13 prefix")
+	// ErrNotConvertible is returned when the ISBN13 has no ISBN10 equivalent (only '978' prefixed ones have).
+	ErrNotConvertible = errors.New("the ISBN13 has no ISBN10 equivalent")
+	// ErrUnknownRange is returned when the ISBN registration group ranges are unknown.
+	ErrUnknownRange = errors.New("unknown ISBN registration group range")
+)
+
+// Normalize removes the hyphens and spaces from the identifier, and upper-cases it (the ISBN10 'x' check digit).
+func Normalize(id string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '\u2010', '\u2011', '\u2012', '\u2013', ' ', '\u00a0', '\t':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(id)))
+}
+
+// ValidateISBN10 checks the normalized ISBN10 length, characters and check digit.
+func ValidateISBN10(isbn10 string) error {
+	if len(isbn10) != isbn10Length {
+		return fmt.Errorf("%w: %q has %d characters, expected %d", ErrInvalidLength, isbn10, len(isbn10), isbn10Length)
+	}
+	if !isDigits(isbn10[:isbn10Length-1]) || !(isDigit(isbn10[isbn10Length-1]) || isbn10[isbn10Length-1] == 'X') {
+		return fmt.Errorf("%w: %q", ErrInvalidCharacter, isbn10)
+	}
+	if checkDigit := isbn10CheckDigit(isbn10[:isbn10Length-1]); checkDigit != isbn10[isbn10Length-1] {
+		return fmt.Errorf("%w: %q, expected the check digit %q", ErrInvalidChecksum, isbn10, checkDigit)
+	}
+
+	return nil
+}
+
+// ValidateISBN13 checks the normalized ISBN13 length, characters, prefix and check digit.
+func ValidateISBN13(isbn13 string) error {
+	if len(isbn13) != isbn13Length {
+		return fmt.Errorf("%w: %q has %d characters, expected %d", ErrInvalidLength, isbn13, len(isbn13), isbn13Length)
+	}
+	if !isDigits(isbn13) {
+		return fmt.Errorf("%w: %q", ErrInvalidCharacter, isbn13)
+	}
+	if prefix := isbn13[:3]; prefix != prefix978 && prefix != prefix979 {
+		return fmt.Errorf("%w: %q", ErrInvalidPrefix, isbn13)
+	}
+	if checkDigit := isbn13CheckDigit(isbn13[:isbn13Length-1]); checkDigit != isbn13[isbn13Length-1] {
+		return fmt.Errorf("%w: %q, expected the check digit %q", ErrInvalidChecksum, isbn13, checkDigit)
+	}
+
+	return nil
+}
+
+// IsValidISBN10 returns true if the identifier is a valid ISBN10. Hyphens and spaces are ignored.
+func IsValidISBN10(id string) bool {
+	return ValidateISBN10(Normalize(id)) == nil
+}
+
+// IsValidISBN13 returns true if the identifier is a valid ISBN13. Hyphens and spaces are ignored.
+func IsValidISBN13(id string) bool {
+	return ValidateISBN13(Normalize(id)) == nil
+}
+
+// ToISBN13 converts the ISBN10 to the '978' prefixed ISBN13. Hyphens and spaces are ignored.
+func ToISBN13(isbn10 string) (string, error) {
+	isbn10 = Normalize(isbn10)
+	if err := ValidateISBN10(isbn10); err != nil {
+		return "", err
+	}
+
+	body := prefix978 + isbn10[:isbn10Length-1]
+	return body + string(isbn13CheckDigit(body)), nil
+}
+
+// ToISBN10 converts the '978' prefixed ISBN13 to the ISBN10. Hyphens and spaces are ignored.
+func ToISBN10(isbn13 string) (string, error) {
+	isbn13 = Normalize(isbn13)
+	if err := ValidateISBN13(isbn13); err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(isbn13, prefix978) {
+		return "", fmt.Errorf("%w: %q", ErrNotConvertible, isbn13)
+	}
+
+	body := isbn13[3 : isbn13Length-1]
+	return body + string(isbn10CheckDigit(body)), nil
+}
+
+// ParseISBN13 validates the ISBN13 and returns it as a number, the way it is stored in the book data.
+// Hyphens and spaces are ignored.
+func ParseISBN13(isbn13 string) (int64, error) {
+	isbn13 = Normalize(isbn13)
+	if err := ValidateISBN13(isbn13); err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(isbn13, 10, 64)
+}
+
+// FormatISBN13 returns the ISBN13 number as a string, or an empty string for the zero value.
+func FormatISBN13(isbn13 int64) string {
+	if isbn13 == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(isbn13, 10)
+}
+
+// IsASIN returns true if the identifier looks like an Amazon ASIN: 10 upper-case alphanumeric characters,
+// starting with 'B', which is not a valid ISBN10. The book ASINs of the printed editions are their ISBN10s.
+func IsASIN(id string) bool {
+	if len(id) != asinLength || id[0] != 'B' {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if !isDigit(id[i]) && (id[i] < 'A' || id[i] > 'Z') {
+			return false
+		}
+	}
+
+	return ValidateISBN10(id) != nil
+}
+
+// DetectKind detects the identifier kind. Hyphens and spaces are ignored, the ISBNs should have a valid checksum.
+func DetectKind(id string) Kind {
+	id = Normalize(id)
+	switch {
+	case ValidateISBN13(id) == nil:
+		return KindISBN13
+	case ValidateISBN10(id) == nil:
+		return KindISBN10
+	case IsASIN(id):
+		return KindASIN
+	}
+
+	return KindUnknown
+}
+
+// isbn10CheckDigit calculates the ISBN10 check digit of the first 9 digits: the weighted (10..2) sum modulo 11.
+func isbn10CheckDigit(body string) byte {
+	sum := 0
+	for i := 0; i < len(body); i++ {
+		sum += int(body[i]-'0') * (isbn10Length - i)
+	}
+	checkDigit := (11 - sum%11) % 11
+	if checkDigit == 10 {
+		return 'X'
+	}
+
+	return byte('0' + checkDigit)
+}
+
+// isbn13CheckDigit calculates the ISBN13 check digit of the first 12 digits: the weighted (1, 3) sum modulo 10.
+func isbn13CheckDigit(body string) byte {
+	sum := 0
+	for i := 0; i < len(body); i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(body[i]-'0') * weight
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,183 @@
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  Kind
+		err   error
+	}{
+		{input: "0306406152", kind: KindISBN10},
+		{input: "3-16-148410-x", kind: KindISBN10},
+		{input: "0306406153", err: ErrInvalidChecksum},
+		{input: "03064061A2", err: ErrInvalidCharacter},
+		{input: "978-0-306-40615-7", kind: KindISBN13},
+		{input: "978 1 4842 6579 6", kind: KindISBN13},
+		{input: "9780306406158", err: ErrInvalidChecksum},
+		{input: "9770306406155", err: ErrInvalidPrefix},
+		{input: "B07XYZ1234", kind: KindASIN},
+		{input: "030640615", err: ErrInvalidLength},
+	}
+
+	t.Log("Given the need to test book identifier validation.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for the %q kind\n", i, tt.input, tt.kind)
+		if kind := DetectKind(tt.input); kind != tt.kind {
+			t.Errorf("\t\t%s\tShould detect the %q kind: %q", failed, tt.kind, kind)
+			continue
+		}
+		id := Normalize(tt.input)
+		var err error
+		switch len(id) {
+		case isbn13Length:
+			err = ValidateISBN13(id)
+		default:
+			err = ValidateISBN10(id)
+		}
+		if tt.kind == KindASIN {
+			err = nil
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("\t\t%s\tShould get the %v error: %v", failed, tt.err, err)
+		} else {
+			t.Logf("\t\t%s\tShould be able to validate the identifier.", succeed)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		isbn10 string
+		isbn13 string
+	}{
+		{isbn10: "0306406152", isbn13: "9780306406157"},
+		{isbn10: "316148410X", isbn13: "9783161484100"},
+		{isbn10: "1484265793", isbn13: "9781484265796"},
+	}
+
+	t.Log("Given the need to test ISBN10 to ISBN13 conversion.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen converting %q and %q\n", i, tt.isbn10, tt.isbn13)
+		isbn13, err := ToISBN13(tt.isbn10)
+		if err != nil || isbn13 != tt.isbn13 {
+			t.Errorf("\t\t%s\tShould convert to the %q ISBN13: %q, %v", failed, tt.isbn13, isbn13, err)
+			continue
+		}
+		isbn10, err := ToISBN10(tt.isbn13)
+		if err != nil || isbn10 != tt.isbn10 {
+			t.Errorf("\t\t%s\tShould convert to the %q ISBN10: %q, %v", failed, tt.isbn10, isbn10, err)
+		} else {
+			t.Logf("\t\t%s\tShould be able to convert the ISBN in both directions.", succeed)
+		}
+	}
+
+	t.Logf("\tTest: %d\tWhen converting the '979' prefixed ISBN13\n", len(tests))
+	if _, err := ToISBN10("9791090636071"); !errors.Is(err, ErrNotConvertible) {
+		t.Errorf("\t\t%s\tShould not convert the '979' prefixed ISBN13: %v", failed, err)
+	} else {
+		t.Logf("\t\t%s\tShould not be able to convert the '979' prefixed ISBN13.", succeed)
+	}
+}
+
+func TestHyphenate(t *testing.T) {
+	tests := []struct {
+		input      string
+		hyphenated string
+		err        error
+	}{
+		{input: "0306406152", hyphenated: "0-306-40615-2"},
+		{input: "316148410X", hyphenated: "3-16-148410-X"},
+		{input: "9780306406157", hyphenated: "978-0-306-40615-7"},
+		{input: "9781484265796", hyphenated: "978-1-4842-6579-6"},
+		{input: "9783161484100", hyphenated: "978-3-16-148410-0"},
+		{input: "9784101092058", hyphenated: "978-4-10-109205-8"},
+		{input: "9791090636071", hyphenated: "979-10-90636-07-1"},
+		{input: "9791234567896", err: ErrUnknownRange},
+		{input: "9780306406158", err: ErrInvalidChecksum},
+		// The registration group 0 range boundaries
+		{input: "0227999991", hyphenated: "0-227-99999-1"},
+		{input: "0228000009", hyphenated: "0-2280-0000-9"},
+		{input: "0228999995", hyphenated: "0-2289-9999-5"},
+		{input: "0229000002", hyphenated: "0-229-00000-2"},
+		{input: "0368999998", hyphenated: "0-368-99999-8"},
+		{input: "0369000005", hyphenated: "0-3690-0000-5"},
+		{input: "0369999991", hyphenated: "0-3699-9999-1"},
+		{input: "0370000005", hyphenated: "0-370-00000-5"},
+		{input: "0638999995", hyphenated: "0-638-99999-5"},
+		{input: "0639000002", hyphenated: "0-6390-0000-2"},
+		{input: "063979999X", hyphenated: "0-6397-9999-X"},
+		{input: "0639800009", hyphenated: "0-6398000-0-9"},
+		{input: "0639999999", hyphenated: "0-6399999-9-9"},
+		{input: "0640000002", hyphenated: "0-640-00000-2"},
+		{input: "0644999993", hyphenated: "0-644-99999-3"},
+		{input: "0645000000", hyphenated: "0-6450000-0-0"},
+		{input: "0645999997", hyphenated: "0-6459999-9-7"},
+		{input: "0646000004", hyphenated: "0-646-00000-4"},
+		{input: "0647999994", hyphenated: "0-647-99999-4"},
+		{input: "0648000001", hyphenated: "0-6480000-0-1"},
+		{input: "0648999998", hyphenated: "0-6489999-9-8"},
+		{input: "0649000005", hyphenated: "0-649-00000-5"},
+		{input: "0654999996", hyphenated: "0-654-99999-6"},
+		{input: "0655000003", hyphenated: "0-6550-0000-3"},
+		{input: "065599999X", hyphenated: "0-6559-9999-X"},
+		{input: "0656000007", hyphenated: "0-656-00000-7"},
+		{input: "9780645000009", hyphenated: "978-0-6450000-0-9"},
+	}
+
+	t.Log("Given the need to test ISBN hyphenation.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen hyphenating %q\n", i, tt.input)
+		hyphenated, err := Hyphenate(tt.input)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("\t\t%s\tShould get the %v error: %v", failed, tt.err, err)
+			continue
+		}
+		if hyphenated != tt.hyphenated {
+			t.Errorf("\t\t%s\tShould get the %q hyphenated ISBN: %q", failed, tt.hyphenated, hyphenated)
+		} else {
+			t.Logf("\t\t%s\tShould be able to hyphenate the ISBN.", succeed)
+		}
+	}
+}
+
+func TestParseBookID(t *testing.T) {
+	tests := []struct {
+		input string
+		id    string
+		kind  Kind
+		err   error
+	}{
+		{input: "0-306-40615-2", id: "0306406152", kind: KindISBN10},
+		{input: "978 0 306 40615 7", id: "9780306406157", kind: KindISBN13},
+		{input: "979-10-90636-07-1", id: "9791090636071", kind: KindISBN13},
+		{input: "B07XYZ1234", id: "B07XYZ1234", kind: KindASIN},
+		{input: "0-306-40615-3", err: ErrInvalidChecksum},
+		{input: "978-0-306-40615", err: ErrInvalidBookID},
+	}
+
+	t.Log("Given the need to test book ID input parsing.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for the %q book ID\n", i, tt.input, tt.id)
+		id, kind, err := ParseBookID(tt.input)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("\t\t%s\tShould get the %v error: %v", failed, tt.err, err)
+			continue
+		}
+		if id != tt.id || kind != tt.kind {
+			t.Errorf("\t\t%s\tShould get the %q %s book ID: %q %s", failed, tt.id, tt.kind, id, kind)
+		} else {
+			t.Logf("\t\t%s\tShould be able to parse the book ID.", succeed)
+		}
+	}
+
+	t.Logf("\tTest: %d\tWhen checking a search query with a number\n", len(tests))
+	if LooksLikeISBN("1984") || LooksLikeISBN("Kubernetes in Action") || !LooksLikeISBN("978-0-306-40615") {
+		t.Errorf("\t\t%s\tShould tell the ISBNs apart from the search queries.", failed)
+	} else {
+		t.Logf("\t\t%s\tShould be able to tell the ISBNs apart from the search queries.", succeed)
+	}
+}
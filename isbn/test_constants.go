@@ -0,0 +1,6 @@
+package isbn
+
+const (
+	succeed = "\u2713"
+	failed  = "\u2717"
+)
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The contributor roles, the book authors have the 'author' role
+const (
+	ContributorRoleAuthor       = "author"
+	ContributorRoleEditor       = "editor"
+	ContributorRoleTranslator   = "translator"
+	ContributorRoleIllustrator  = "illustrator"
+	ContributorRoleForeword     = "foreword"
+	ContributorRoleIntroduction = "introduction"
+	ContributorRoleAfterword    = "afterword"
+	ContributorRoleNarrator     = "narrator"
+	ContributorRoleContributor  = "contributor"
+)
+
+var (
+	// Jane Doe (Editor) / Jane Doe (Author, Editor)
+	contributorRegex = regexp.MustCompile(`^(.+?)\s*\(([^()]+)\)$`)
+
+	// contributorRoles maps the role words of the supported storefronts to the contributor roles
+	contributorRoles = map[string]string{
+		"author": ContributorRoleAuthor,
+		"autor":  ContributorRoleAuthor,
+		"auteur": ContributorRoleAuthor,
+
+		"editor":                   ContributorRoleEditor,
+		"edited by":                ContributorRoleEditor,
+		"herausgeber":              ContributorRoleEditor,
+		"directeur de publication": ContributorRoleEditor,
+
+		"translator": ContributorRoleTranslator,
+		"übersetzer": ContributorRoleTranslator,
+		"traducteur": ContributorRoleTranslator,
+		"traduction": ContributorRoleTranslator,
+
+		"illustrator":   ContributorRoleIllustrator,
+		"illustrations": ContributorRoleIllustrator,
+		"illustrateur":  ContributorRoleIllustrator,
+
+		"foreword": ContributorRoleForeword,
+		"vorwort":  ContributorRoleForeword,
+		"préface":  ContributorRoleForeword,
+
+		"introduction": ContributorRoleIntroduction,
+		"einführung":   ContributorRoleIntroduction,
+
+		"afterword": ContributorRoleAfterword,
+		"nachwort":  ContributorRoleAfterword,
+		"postface":  ContributorRoleAfterword,
+
+		"narrator":  ContributorRoleNarrator,
+		"erzähler":  ContributorRoleNarrator,
+		"narrateur": ContributorRoleNarrator,
+
+		"contributor":   ContributorRoleContributor,
+		"mitwirkende":   ContributorRoleContributor,
+		"collaborateur": ContributorRoleContributor,
+	}
+)
+
+// ParseContributorString parses a byline contributor string, like: 'Jane Doe (Editor)' or 'Jane Doe (Author, Editor)',
+// and returns the contributor name and roles. A contributor without a known role suffix is a book author.
+func ParseContributorString(contributorString string) (string, []string) {
+	contributorString = strings.TrimSpace(contributorString)
+	if subMatch := contributorRegex.FindStringSubmatch(contributorString); subMatch != nil {
+		if roles := ParseContributorRoles(subMatch[2]); len(roles) > 0 {
+			return subMatch[1], roles
+		}
+	}
+
+	return contributorString, []string{ContributorRoleAuthor}
+}
+
+// ParseContributorRoles parses a contributor role string, like: '(Editor)' or 'Author, Illustrator', and returns
+// all known roles in the string order. Returns an empty slice for an unknown role.
+func ParseContributorRoles(roleString string) []string {
+	roleString = strings.Trim(strings.TrimSpace(roleString), "()")
+	roles := make([]string, 0, 1)
+	for _, role := range strings.Split(roleString, ",") {
+		contributorRole, ok := contributorRoles[strings.ToLower(strings.TrimSpace(role))]
+		if ok && !containsString(roles, contributorRole) {
+			roles = append(roles, contributorRole)
+		}
+	}
+
+	return roles
+}
+
+// FormatContributor returns the contributor string, like: 'Jane Doe (Editor)' or 'Jane Doe (Author, Editor)'.
+// ParseContributorString parses it back. The book authors without other roles have no role suffix.
+func FormatContributor(name string, roles ...string) string {
+	roleNames := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if role == "" {
+			role = ContributorRoleAuthor
+		}
+		roleNames = append(roleNames, strings.ToUpper(role[:1])+role[1:])
+	}
+	if len(roles) == 0 || (len(roles) == 1 && strings.ToLower(roleNames[0]) == ContributorRoleAuthor) {
+		return name
+	}
+
+	return name + " (" + strings.Join(roleNames, ", ") + ")"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,230 @@
+package parser
+
+import (
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// allowedDescriptionTags maps the description tags to the allowed ones, other tags are unwrapped
+	allowedDescriptionTags = map[string]string{
+		"p":      "p",
+		"b":      "b",
+		"strong": "b",
+		"i":      "i",
+		"em":     "i",
+		"ul":     "ul",
+		"ol":     "ol",
+		"li":     "li",
+		"br":     "br",
+		"jats:p": "p",
+	}
+	// inlineDescriptionTags keep the surrounding whitespace outside the tag: 'foo<b> bar</b>' -> 'foo <b>bar</b>'
+	inlineDescriptionTags = map[string]bool{
+		"b": true,
+		"i": true,
+	}
+	// droppedDescriptionTags are removed along with their content
+	droppedDescriptionTags = map[string]bool{
+		"script":   true,
+		"style":    true,
+		"noscript": true,
+		"iframe":   true,
+		"template": true,
+	}
+
+	htmlTagRegex       = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	whitespaceRegex    = regexp.MustCompile(`\s+`)
+	paragraphRegex     = regexp.MustCompile(`\n\s*\n`)
+	multiNewLinesRegex = regexp.MustCompile(`\n[ \t]*\n(?:[ \t]*\n)*`)
+	markdownEscaper    = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`", `[`, `\[`, `#`, `\#`)
+	// The list markers at the line start: '- ' / '+ ' / '1. ' / '1) '
+	markdownListMarkerRegex = regexp.MustCompile(`^(\s*)(\d*)([-+.)])(\s|$)`)
+)
+
+// SanitizeDescription cleans up a book description HTML, keeping only the allowed tags (p, b, i, ul, ol, li, br)
+// without any attributes. The content of other tags is kept, except for scripts and styles. Empty paragraphs
+// are removed. A plain text description is converted to paragraphs, split by empty lines.
+func SanitizeDescription(description string) string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return ""
+	}
+	if !htmlTagRegex.MatchString(description) {
+		return textToHTML(description)
+	}
+
+	nodes, err := parseDescription(description)
+	if err != nil {
+		return html.EscapeString(description)
+	}
+	var builder strings.Builder
+	for _, node := range nodes {
+		writeSanitizedNode(&builder, node)
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
+// DescriptionToMarkdown converts a book description HTML to Markdown.
+func DescriptionToMarkdown(description string) string {
+	return convertDescription(description, true)
+}
+
+// DescriptionToText converts a book description HTML to plain text. Paragraphs are separated by empty lines,
+// list items start with '- ' or the item number.
+func DescriptionToText(description string) string {
+	return convertDescription(description, false)
+}
+
+func convertDescription(description string, markdown bool) string {
+	sanitized := SanitizeDescription(description)
+	if sanitized == "" {
+		return ""
+	}
+	nodes, err := parseDescription(sanitized)
+	if err != nil {
+		return ""
+	}
+
+	var builder strings.Builder
+	for _, node := range nodes {
+		writeConvertedNode(&builder, node, markdown)
+	}
+	result := multiNewLinesRegex.ReplaceAllString(builder.String(), "\n\n")
+
+	return strings.TrimSpace(result)
+}
+
+func parseDescription(description string) ([]*html.Node, error) {
+	context := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	return html.ParseFragment(strings.NewReader(description), context)
+}
+
+func writeSanitizedNode(builder *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		text := whitespaceRegex.ReplaceAllString(node.Data, " ")
+		if strings.HasSuffix(builder.String(), " ") {
+			text = strings.TrimPrefix(text, " ")
+		}
+		builder.WriteString(html.EscapeString(text))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	if droppedDescriptionTags[node.Data] {
+		return
+	}
+
+	tag, allowed := allowedDescriptionTags[node.Data]
+	if tag == "br" {
+		builder.WriteString("<br>")
+		return
+	}
+	var content strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeSanitizedNode(&content, child)
+	}
+	if !allowed {
+		builder.WriteString(content.String())
+		return
+	}
+	inner := strings.TrimSpace(content.String())
+	inline := inlineDescriptionTags[tag]
+	if inline && strings.HasPrefix(content.String(), " ") && !strings.HasSuffix(builder.String(), " ") {
+		builder.WriteString(" ")
+	}
+	if strings.TrimSpace(strings.ReplaceAll(inner, "<br>", "")) != "" {
+		builder.WriteString("<" + tag + ">" + inner + "</" + tag + ">")
+	}
+	if inline && inner != "" && strings.HasSuffix(content.String(), " ") {
+		builder.WriteString(" ")
+	}
+}
+
+func writeConvertedNode(builder *strings.Builder, node *html.Node, markdown bool) {
+	if node.Type == html.TextNode {
+		text := node.Data
+		if markdown {
+			text = markdownEscaper.Replace(text)
+			if node.PrevSibling == nil || node.PrevSibling.Data == "br" {
+				text = escapeMarkdownListMarker(text)
+			}
+		}
+		builder.WriteString(text)
+		return
+	}
+	if node.Type != html.ElementNode {
+		return
+	}
+
+	var content strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeConvertedNode(&content, child, markdown)
+	}
+	inner := strings.TrimSpace(content.String())
+	switch node.Data {
+	case "p":
+		builder.WriteString("\n\n" + inner + "\n\n")
+	case "ul", "ol":
+		builder.WriteString("\n\n" + inner + "\n\n")
+	case "li":
+		builder.WriteString(listItemMarker(node) + " " + inner + "\n")
+	case "br":
+		if markdown {
+			builder.WriteString("  ")
+		}
+		builder.WriteString("\n")
+	case "b":
+		if markdown {
+			inner = "**" + inner + "**"
+		}
+		builder.WriteString(inner)
+	case "i":
+		if markdown {
+			inner = "*" + inner + "*"
+		}
+		builder.WriteString(inner)
+	default:
+		builder.WriteString(inner)
+	}
+}
+
+// listItemMarker returns '-' for the unordered list items, and the item number, like '2.' for the ordered ones.
+func listItemMarker(item *html.Node) string {
+	if item.Parent == nil || item.Parent.Data != "ol" {
+		return "-"
+	}
+	number := 1
+	for sibling := item.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+		if sibling.Type == html.ElementNode && sibling.Data == "li" {
+			number++
+		}
+	}
+
+	return strconv.Itoa(number) + "."
+}
+
+// escapeMarkdownListMarker escapes a list marker at the text start, so the text is not rendered as a list item:
+// '- text' -> '\- text', '1. text' -> '1\. text'.
+func escapeMarkdownListMarker(text string) string {
+	return markdownListMarkerRegex.ReplaceAllString(text, `$1$2\$3$4`)
+}
+
+// textToHTML converts a plain text description to paragraphs, split by empty lines.
+func textToHTML(text string) string {
+	var builder strings.Builder
+	for _, paragraph := range paragraphRegex.Split(text, -1) {
+		paragraph = strings.TrimSpace(whitespaceRegex.ReplaceAllString(paragraph, " "))
+		if paragraph != "" {
+			builder.WriteString("<p>" + html.EscapeString(paragraph) + "</p>")
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,262 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/mantidtech/wordnumber"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxEditionNumber limits the edition numbers, the bigger ones are either years, or parsing errors
+	maxEditionNumber = 200
+	minEditionYear   = 1900
+	maxEditionYear   = 2100
+)
+
+var (
+	// Edition / edition, the 'ed.' abbreviation is expanded before matching
+	editionWordRegex = regexp.MustCompile(`(?i)\bedition\b`)
+	// 1st / 2nd / 3rd / 4th
+	editionCardinalWordRegex = regexp.MustCompile(`(?i)^(\d+)(?:st|nd|rd|th)$`)
+	// II / IV / XII
+	romanNumeralRegex = regexp.MustCompile(`^[IVXL]+$`)
+
+	// editionQualifiers are the words, describing an edition kind, like: 'Revised Edition', 'Bilingual Edition'
+	editionQualifiers = map[string]bool{
+		"abridged": true, "annotated": true, "anniversary": true, "bilingual": true, "classroom": true,
+		"collector's": true, "collectors": true, "complete": true, "definitive": true, "deluxe": true,
+		"enlarged": true, "expanded": true, "extended": true, "global": true, "illustrated": true,
+		"instructor's": true, "international": true, "large": true, "limited": true, "pocket": true, "print": true,
+		"reprint": true, "revised": true, "special": true, "student": true, "teacher's": true, "unabridged": true,
+		"updated": true, "workbook": true,
+	}
+	// ordinalQualifiers are the qualifiers, which take an ordinal, like: '20th Anniversary Edition'
+	ordinalQualifiers = map[string]bool{"anniversary": true}
+
+	romanNumerals = map[rune]int{'I': 1, 'V': 5, 'X': 10, 'L': 50}
+	// romanNumeralSymbols are the canonical Roman numeral symbols, in the descending value order
+	romanNumeralSymbols = []struct {
+		value  int
+		symbol string
+	}{{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"}}
+)
+
+// Edition is a book edition: the edition number (like: 3 for the '3rd Edition'), the qualifier
+// (like: 'Revised', '20th Anniversary', 'Bilingual'), and the year of a year-style edition (like: '2023 edition').
+type Edition struct {
+	Number    uint8
+	Qualifier string
+	Year      uint16
+}
+
+// NewEdition returns the edition with the given number. The year-like numbers (like: '2023. Auflage')
+// are treated as the year-style editions, the other too big numbers are dropped.
+func NewEdition(number int) Edition {
+	switch {
+	case number >= minEditionYear && number < maxEditionYear:
+		return Edition{Year: uint16(number)}
+	case number > 0 && number < maxEditionNumber:
+		return Edition{Number: uint8(number)}
+	}
+
+	return Edition{}
+}
+
+// IsYearStyle returns true for the year-style editions, like Springer's '2023 edition'.
+func (e Edition) IsYearStyle() bool {
+	return e.Year != 0
+}
+
+func (e Edition) IsEmpty() bool {
+	return e.Number == 0 && e.Qualifier == "" && e.Year == 0
+}
+
+// Merge fills the empty edition parts from the other edition.
+func (e Edition) Merge(other Edition) Edition {
+	if e.Number == 0 {
+		e.Number = other.Number
+	}
+	if e.Qualifier == "" {
+		e.Qualifier = other.Qualifier
+	}
+	if e.Year == 0 {
+		e.Year = other.Year
+	}
+
+	return e
+}
+
+// String returns the edition text, like: '3rd Revised Edition', or '2023 Edition'. ParseEdition parses it back.
+func (e Edition) String() string {
+	if e.IsEmpty() {
+		return ""
+	}
+	parts := make([]string, 0, 4)
+	if e.Number > 0 {
+		ordinalShort, _ := wordnumber.IntToOrdinalShort(int(e.Number))
+		parts = append(parts, ordinalShort)
+	}
+	if e.Qualifier != "" {
+		parts = append(parts, e.Qualifier)
+	}
+	if e.Year > 0 {
+		parts = append(parts, strconv.Itoa(int(e.Year)))
+	}
+
+	return strings.Join(append(parts, "Edition"), " ")
+}
+
+// ParseEdition parses a book edition string (or a title, containing the edition part), and returns the edition.
+// The words right before the 'Edition' word are taken: the ordinals (like: '3rd', 'Third'), the Roman numerals
+// (like: 'II Edition', or 'Edition II'), the years (like: '2023 edition') and the qualifiers (like: 'Revised').
+// Returns an error, if the edition word is there, but none of the words before it is recognized.
+func ParseEdition(editionString string) (Edition, error) {
+	// For the cases like: 2nd ed. / 2nd ed. 2023 edition
+	if strings.Contains(editionString, " ed.") {
+		editionString = strings.Replace(editionString, " ed.", "", 1)
+	}
+
+	var edition Edition
+	var unknownWord string
+	for _, location := range editionWordRegex.FindAllStringIndex(editionString, -1) {
+		phraseEdition, unknown := parseEditionPhrase(strings.Fields(editionString[:location[0]]),
+			strings.Fields(editionString[location[1]:]))
+		edition = edition.Merge(phraseEdition)
+		if unknownWord == "" {
+			unknownWord = unknown
+		}
+	}
+	if edition.IsEmpty() && unknownWord != "" {
+		return Edition{}, fmt.Errorf("unknown edition '%s' in the edition string '%s'", unknownWord, editionString)
+	}
+
+	return edition, nil
+}
+
+// parseEditionPhrase parses the words before the 'Edition' word (backwards, until an unrecognized word,
+// or a clause boundary, like a comma), and the Roman numeral or the number right after it.
+// Returns the word right before the 'Edition' word, if the phrase is not recognized at all.
+func parseEditionPhrase(before, after []string) (Edition, string) {
+	var edition Edition
+	var unknownWord string
+	qualifiers := make([]string, 0)
+words:
+	for i := len(before) - 1; i >= 0; i-- {
+		rawWord := before[i]
+		// A comma, a colon or a dot ends the previous clause, like: 'Mastery, 20th Anniversary Edition'
+		if i < len(before)-1 && strings.ContainsAny(rawWord[len(rawWord)-1:], ",:;.") {
+			break
+		}
+		word := strings.Trim(rawWord, "()[],:;")
+		number, isNumber := parseEditionNumber(word)
+		switch {
+		case editionQualifiers[strings.ToLower(word)]:
+			qualifiers = append([]string{word}, qualifiers...)
+		case isNumber && len(qualifiers) > 0 && ordinalQualifiers[strings.ToLower(qualifiers[0])]:
+			// The ordinal is a part of the qualifier, like: '20th Anniversary'
+			qualifiers = append([]string{word}, qualifiers...)
+		case isNumber && number >= minEditionYear && edition.Year == 0:
+			edition = edition.Merge(NewEdition(number))
+		case isNumber && number < minEditionYear && edition.Number == 0:
+			edition = edition.Merge(NewEdition(number))
+		default:
+			if i == len(before)-1 {
+				unknownWord = word
+			}
+			break words
+		}
+		// An opening parenthesis starts the edition phrase, like: '(2nd Edition)'
+		if strings.HasPrefix(rawWord, "(") {
+			break
+		}
+	}
+	edition.Qualifier = strings.Join(qualifiers, " ")
+	if edition.Number == 0 {
+		edition.Number = NewEdition(parseEditionAfter(after)).Number
+	}
+	if !edition.IsEmpty() {
+		unknownWord = ""
+	}
+
+	return edition, unknownWord
+}
+
+// parseEditionAfter returns the edition number right after the 'Edition' word, like: 'Edition II', or 'Edition 2'.
+func parseEditionAfter(after []string) int {
+	if len(after) == 0 {
+		return 0
+	}
+	word := strings.Trim(after[0], "()[],:;.")
+	if number, err := strconv.Atoi(word); err == nil && number < maxEditionNumber {
+		return number
+	}
+
+	return parseRomanNumeral(word)
+}
+
+// parseEditionNumber parses an edition number word: a cardinal one (like: '3rd'), an ordinal one (like: 'Third'),
+// a plain number (like: '3', or a '2023' year), or a Roman numeral (like: 'III').
+func parseEditionNumber(word string) (int, bool) {
+	if subMatch := editionCardinalWordRegex.FindStringSubmatch(word); subMatch != nil {
+		number, err := strconv.Atoi(subMatch[1])
+		return number, err == nil
+	}
+	if number, err := strconv.Atoi(word); err == nil {
+		return number, number > 0
+	}
+	if number := parseRomanNumeral(word); number > 0 {
+		return number, true
+	}
+	if len(word) < 3 || !isLetters(word) {
+		return 0, false
+	}
+	number, err := wordnumber.OrdinalToInt(word)
+
+	return number, err == nil && number > 0
+}
+
+// parseRomanNumeral parses an upper case Roman numeral up to 'L' (50), returns 0 for any other word.
+// Only the canonical numerals are accepted, like: 'IV', but not 'IIII' or 'VX'.
+func parseRomanNumeral(word string) int {
+	if !romanNumeralRegex.MatchString(word) {
+		return 0
+	}
+	number := 0
+	for i, char := range word {
+		value := romanNumerals[char]
+		if i+1 < len(word) && value < romanNumerals[rune(word[i+1])] {
+			number -= value
+		} else {
+			number += value
+		}
+	}
+	if formatRomanNumeral(number) != word {
+		return 0
+	}
+
+	return number
+}
+
+// formatRomanNumeral returns the canonical Roman numeral of a number, like: 'XIV' for 14.
+func formatRomanNumeral(number int) string {
+	var builder strings.Builder
+	for _, numeral := range romanNumeralSymbols {
+		for ; number >= numeral.value; number -= numeral.value {
+			builder.WriteString(numeral.symbol)
+		}
+	}
+
+	return builder.String()
+}
+
+func isLetters(word string) bool {
+	for _, char := range word {
+		if (char < 'a' || char > 'z') && (char < 'A' || char > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"strings"
+)
+
+var (
+	// languageNames maps the ISO 639-1 codes (or the ISO 639-3 codes, if there is no ISO 639-1 code)
+	// to the English language names
+	languageNames = map[string]string{
+		"ar":  "Arabic",
+		"cs":  "Czech",
+		"da":  "Danish",
+		"de":  "German",
+		"el":  "Greek",
+		"en":  "English",
+		"es":  "Spanish",
+		"fi":  "Finnish",
+		"fr":  "French",
+		"grc": "Ancient Greek",
+		"he":  "Hebrew",
+		"hu":  "Hungarian",
+		"it":  "Italian",
+		"ja":  "Japanese",
+		"ko":  "Korean",
+		"la":  "Latin",
+		"nl":  "Dutch",
+		"no":  "Norwegian",
+		"pl":  "Polish",
+		"pt":  "Portuguese",
+		"ru":  "Russian",
+		"sv":  "Swedish",
+		"tr":  "Turkish",
+		"uk":  "Ukrainian",
+		"zh":  "Chinese",
+	}
+
+	// languageAliases are the language names of the supported storefront locales (en, de, fr, es, it),
+	// and the ISO 639-2/639-3 codes of the languages
+	languageAliases = map[string][]string{
+		"ar":  {"arabic", "arabisch", "arabe", "árabe", "arabo", "ara"},
+		"cs":  {"czech", "tschechisch", "tchèque", "checo", "ceco", "ces", "cze"},
+		"da":  {"danish", "dänisch", "danois", "danés", "danese", "dan"},
+		"de":  {"german", "deutsch", "allemand", "alemán", "tedesco", "deu", "ger"},
+		"el":  {"greek", "griechisch", "grec", "griego", "greco", "ell", "gre"},
+		"en":  {"english", "englisch", "anglais", "inglés", "inglese", "eng"},
+		"es":  {"spanish", "spanisch", "espagnol", "español", "spagnolo", "spa"},
+		"fi":  {"finnish", "finnisch", "finnois", "finlandés", "finlandese", "fin"},
+		"fr":  {"french", "französisch", "français", "francés", "francese", "fra", "fre"},
+		"grc": {"ancient greek", "altgriechisch", "grec ancien", "griego antiguo", "greco antico"},
+		"he":  {"hebrew", "hebräisch", "hébreu", "hebreo", "ebraico", "heb"},
+		"hu":  {"hungarian", "ungarisch", "hongrois", "húngaro", "ungherese", "hun"},
+		"it":  {"italian", "italienisch", "italien", "italiano", "ita"},
+		"ja":  {"japanese", "japanisch", "japonais", "japonés", "giapponese", "jpn"},
+		"ko":  {"korean", "koreanisch", "coréen", "coreano", "kor"},
+		"la":  {"latin", "latein", "latín", "latino", "lat"},
+		"nl":  {"dutch", "niederländisch", "néerlandais", "neerlandés", "olandese", "nld", "dut"},
+		"no":  {"norwegian", "norwegisch", "norvégien", "noruego", "norvegese", "nor"},
+		"pl":  {"polish", "polnisch", "polonais", "polaco", "polacco", "pol"},
+		"pt":  {"portuguese", "portugiesisch", "portugais", "portugués", "portoghese", "por"},
+		"ru":  {"russian", "russisch", "russe", "ruso", "russo", "rus"},
+		"sv":  {"swedish", "schwedisch", "suédois", "sueco", "svedese", "swe"},
+		"tr":  {"turkish", "türkisch", "turc", "turco", "tur"},
+		"uk":  {"ukrainian", "ukrainisch", "ukrainien", "ucraniano", "ucraino", "ukr"},
+		"zh":  {"chinese", "chinesisch", "chinois", "chino", "cinese", "zho", "chi"},
+	}
+)
+
+// ParseLanguage parses a language display name in one of the supported storefront locales, like: 'Englisch' or
+// 'Anglais', an ISO 639 code, like: 'en' or 'eng', or a language tag, like: 'en-US', and returns the ISO 639-1 code
+// (or the ISO 639-3 code, if there is no ISO 639-1 code). Returns an empty string for an unknown language.
+func ParseLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if code := findLanguageCode(language); code != "" {
+		return code
+	}
+	// en-US / en_GB / /languages/eng
+	language = strings.TrimPrefix(language, "/languages/")
+	language = strings.SplitN(strings.ReplaceAll(language, "_", "-"), "-", 2)[0]
+
+	return findLanguageCode(language)
+}
+
+func findLanguageCode(language string) string {
+	if _, ok := languageNames[language]; ok {
+		return language
+	}
+	for code, aliases := range languageAliases {
+		for _, alias := range aliases {
+			if alias == language {
+				return code
+			}
+		}
+	}
+
+	return ""
+}
+
+// GetLanguageName returns the English name of an ISO 639 language code, like: 'en' -> 'English'.
+// Returns an empty string for an unknown language code.
+func GetLanguageName(code string) string {
+	return languageNames[code]
+}
+
+// NormalizeLanguage returns the English language name and the ISO 639 code of a language display name, a code or
+// a tag, like: 'Englisch' -> 'English', 'en'. An unknown language keeps its trimmed name, and gets no code.
+func NormalizeLanguage(language string) (string, string) {
+	if code := ParseLanguage(language); code != "" {
+		return languageNames[code], code
+	}
+
+	return strings.TrimSpace(language), ""
+}
@@ -0,0 +1,261 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LanguageEnglish = "en"
+	LanguageGerman  = "de"
+	LanguageFrench  = "fr"
+	LanguageSpanish = "es"
+	LanguageItalian = "it"
+	// LanguageJapanese dates are numeric only, like: '2020/10/1' or '2020年10月1日'
+	LanguageJapanese = "ja"
+
+	// localizedPublisherRuleName is the rule name of the non-English publisher strings, like: 'Rheinwerk; 3. Auflage'
+	localizedPublisherRuleName = "localized"
+)
+
+var (
+	localizedMonths = map[string]map[string]time.Month{
+		LanguageGerman: {
+			"januar":    time.January,
+			"jänner":    time.January,
+			"jan":       time.January,
+			"februar":   time.February,
+			"feb":       time.February,
+			"märz":      time.March,
+			"mär":       time.March,
+			"april":     time.April,
+			"apr":       time.April,
+			"mai":       time.May,
+			"juni":      time.June,
+			"jun":       time.June,
+			"juli":      time.July,
+			"jul":       time.July,
+			"august":    time.August,
+			"aug":       time.August,
+			"september": time.September,
+			"sept":      time.September,
+			"sep":       time.September,
+			"oktober":   time.October,
+			"okt":       time.October,
+			"november":  time.November,
+			"nov":       time.November,
+			"dezember":  time.December,
+			"dez":       time.December,
+		},
+		LanguageFrench: {
+			"janvier":   time.January,
+			"janv":      time.January,
+			"février":   time.February,
+			"févr":      time.February,
+			"mars":      time.March,
+			"avril":     time.April,
+			"avr":       time.April,
+			"mai":       time.May,
+			"juin":      time.June,
+			"juillet":   time.July,
+			"juil":      time.July,
+			"août":      time.August,
+			"septembre": time.September,
+			"sept":      time.September,
+			"octobre":   time.October,
+			"oct":       time.October,
+			"novembre":  time.November,
+			"nov":       time.November,
+			"décembre":  time.December,
+			"déc":       time.December,
+		},
+		LanguageSpanish: {
+			"enero":      time.January,
+			"ene":        time.January,
+			"febrero":    time.February,
+			"feb":        time.February,
+			"marzo":      time.March,
+			"mar":        time.March,
+			"abril":      time.April,
+			"abr":        time.April,
+			"mayo":       time.May,
+			"may":        time.May,
+			"junio":      time.June,
+			"jun":        time.June,
+			"julio":      time.July,
+			"jul":        time.July,
+			"agosto":     time.August,
+			"ago":        time.August,
+			"septiembre": time.September,
+			"setiembre":  time.September,
+			"sept":       time.September,
+			"sep":        time.September,
+			"octubre":    time.October,
+			"oct":        time.October,
+			"noviembre":  time.November,
+			"nov":        time.November,
+			"diciembre":  time.December,
+			"dic":        time.December,
+		},
+		LanguageItalian: {
+			"gennaio":   time.January,
+			"gen":       time.January,
+			"febbraio":  time.February,
+			"feb":       time.February,
+			"marzo":     time.March,
+			"mar":       time.March,
+			"aprile":    time.April,
+			"apr":       time.April,
+			"maggio":    time.May,
+			"mag":       time.May,
+			"giugno":    time.June,
+			"giu":       time.June,
+			"luglio":    time.July,
+			"lug":       time.July,
+			"agosto":    time.August,
+			"ago":       time.August,
+			"settembre": time.September,
+			"set":       time.September,
+			"ottobre":   time.October,
+			"ott":       time.October,
+			"novembre":  time.November,
+			"nov":       time.November,
+			"dicembre":  time.December,
+			"dic":       time.December,
+		},
+		LanguageJapanese: {},
+	}
+
+	// 6. April 2022 / 6 avril 2022 / 1er octobre 2020 / 1 de octubre de 2020 / 1º ottobre 2020
+	localizedDateRegex = regexp.MustCompile(`^(\d{1,2})(?:\.|er|º|°)? (?:de )?(\p{L}+)\.? (?:de )?(\d{4})$`)
+	// Oktober 2020 / octobre 2020 / octubre de 2020
+	localizedMonthRegex = regexp.MustCompile(`^(\p{L}+)\.? (?:de )?(\d{4})$`)
+	// 2020-10-01 / 2020/10/1 / 2020-10 / 2020
+	numericDateRegex = regexp.MustCompile(`^(\d{4})(?:[-/.](\d{1,2})(?:[-/.](\d{1,2}))?)?$`)
+	// 2020年10月1日 / 2020年10月 / 2020年
+	japaneseDateRegex = regexp.MustCompile(`^(\d{4})年(?:(\d{1,2})月(?:(\d{1,2})日)?)?$`)
+	// dpunkt.verlag GmbH; 4. Edition (6. April 2022) / Eyrolles; 4e édition (6 avril 2022)
+	localizedPubRegex = regexp.MustCompile(`^([^;(]+?)\s*(?:;\s*([^(]*?))?\s*(?:\(([^)]+)\))?$`)
+	// 4. Auflage / 4. Edition / 4e édition / 1re édition / 4ª edición / 第4版
+	localizedEditionRegex = regexp.MustCompile(`^第?(\d+)(?:\.|e|ème|er|re|ª|º|°|版)?(?:\s|$)`)
+)
+
+// ParseLocalizedDateString parses a date string written in the given language (like: 'de', 'fr').
+// Falls back to the English date formats for unknown languages or unmatched strings.
+func ParseLocalizedDateString(dateString, language string) (time.Time, error) {
+	date, _, err := ParsePartialDateString(dateString, language)
+
+	return date, err
+}
+
+// ParsePartialDateString parses a full or a partial date string (like: 'Oktober 2020', or '2020') written
+// in the given language, and returns the date with its precision. The numeric dates (like: '2020-10-01',
+// or the Japanese '2020/10/1' and '2020年10月1日') are parsed for any language.
+// Falls back to the English date formats for unknown languages or unmatched strings.
+func ParsePartialDateString(dateString, language string) (time.Time, DatePrecision, error) {
+	dateString = strings.Join(strings.Fields(dateString), " ")
+	subMatch := numericDateRegex.FindStringSubmatch(dateString)
+	if subMatch == nil {
+		subMatch = japaneseDateRegex.FindStringSubmatch(strings.ReplaceAll(dateString, " ", ""))
+	}
+	if subMatch != nil {
+		return getPartialDate(dateString, subMatch[1], subMatch[2], subMatch[3])
+	}
+
+	months, ok := localizedMonths[language]
+	if !ok {
+		return parseEnglishDateString(dateString)
+	}
+	precision := DatePrecisionDay
+	subMatch = localizedDateRegex.FindStringSubmatch(dateString)
+	if subMatch == nil {
+		precision = DatePrecisionMonth
+		// The day is omitted for the partial date: [full match, month, year]
+		if subMatch = localizedMonthRegex.FindStringSubmatch(dateString); subMatch != nil {
+			subMatch = []string{subMatch[0], "1", subMatch[1], subMatch[2]}
+		}
+	}
+	if subMatch == nil {
+		return parseEnglishDateString(dateString)
+	}
+	month, ok := months[strings.ToLower(subMatch[2])]
+	if !ok {
+		return time.Time{}, precision, fmt.Errorf("unknown month name '%s' in the date string '%s'",
+			subMatch[2], dateString)
+	}
+	day, _ := strconv.Atoi(subMatch[1])
+	year, _ := strconv.Atoi(subMatch[3])
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day {
+		return time.Time{}, precision, fmt.Errorf("invalid day in the date string '%s'", dateString)
+	}
+
+	return date, precision, nil
+}
+
+// getPartialDate returns the date of the numeric year, month and day parts, the month and the day are optional.
+func getPartialDate(dateString, yearString, monthString, dayString string) (time.Time, DatePrecision, error) {
+	year, _ := strconv.Atoi(yearString)
+	month, day, precision := 1, 1, DatePrecisionYear
+	if monthString != "" {
+		month, _ = strconv.Atoi(monthString)
+		precision = DatePrecisionMonth
+	}
+	if dayString != "" {
+		day, _ = strconv.Atoi(dayString)
+		precision = DatePrecisionDay
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if month < 1 || month > 12 || date.Day() != day {
+		return time.Time{}, precision, fmt.Errorf("invalid month or day in the date string '%s'", dateString)
+	}
+
+	return date, precision, nil
+}
+
+// ParseLocalizedPublisherString parses a book publisher string written in the given language,
+// and returns publisher-related meta information. English strings are handled by ParsePublisherString.
+func ParseLocalizedPublisherString(publisherString, language string) (BookPublishMeta, error) {
+	result, err := TraceLocalizedPublisherString(publisherString, language)
+
+	return result.Meta, err
+}
+
+// TraceLocalizedPublisherString parses a book publisher string written in the given language, and returns
+// the publisher metadata with the parsing trace. English strings are handled by TracePublisherString,
+// the other languages are matched by the 'localized' rule.
+func TraceLocalizedPublisherString(publisherString, language string) (PublisherParseResult, error) {
+	if _, ok := localizedMonths[language]; !ok {
+		return TracePublisherString(publisherString)
+	}
+
+	subMatch := localizedPubRegex.FindStringSubmatch(strings.TrimSpace(publisherString))
+	if subMatch == nil || subMatch[1] == "" {
+		return PublisherParseResult{}, fmt.Errorf("the publisher string '%s' can not be parsed", publisherString)
+	}
+	result := PublisherParseResult{Rule: localizedPublisherRuleName, Matched: []string{localizedPublisherRuleName}}
+
+	var edition int
+	if editionSubMatch := localizedEditionRegex.FindStringSubmatch(subMatch[2]); editionSubMatch != nil {
+		edition, _ = strconv.Atoi(editionSubMatch[1])
+	}
+	result.Meta = BookPublishMeta{
+		Publisher: strings.TrimSpace(subMatch[1]),
+		Edition:   NewEdition(edition),
+	}
+	if subMatch[3] == "" {
+		return result, nil
+	}
+
+	date, precision, err := ParsePartialDateString(subMatch[3], language)
+	if err != nil {
+		return result, fmt.Errorf("can not get publication date: %w", err)
+	}
+	result.Meta.PubDate = date
+	result.Meta.PubDatePrecision = precision
+
+	return result, nil
+}
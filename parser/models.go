@@ -2,8 +2,35 @@ package parser
 
 import "time"
 
+// DatePrecision is the precision of a parsed date. A partial date, like 'October 2020' or '2020',
+// is completed with the first month and/or day, the precision tells which parts are actually known.
+type DatePrecision uint8
+
+const (
+	DatePrecisionDay DatePrecision = iota
+	DatePrecisionMonth
+	DatePrecisionYear
+)
+
+func (p DatePrecision) String() string {
+	switch p {
+	case DatePrecisionMonth:
+		return "month"
+	case DatePrecisionYear:
+		return "year"
+	}
+
+	return "day"
+}
+
 type BookPublishMeta struct {
-	Publisher string
-	Edition   uint8
-	PubDate   time.Time
+	Publisher        string
+	Edition          Edition
+	PubDate          time.Time
+	PubDatePrecision DatePrecision
+}
+
+type BookSeries struct {
+	Name   string
+	Volume uint16
 }
@@ -13,6 +13,10 @@ const (
 	dateLayout01 = "January 2, 2006"
 	dateLayout02 = "2 Jan. 2006"
 	dateLayout03 = "2 January 2006"
+	// The partial dates, like: 'October 2020' / 'Oct 2020' / 'Oct. 2020'
+	monthLayout01 = "January 2006"
+	monthLayout02 = "Jan 2006"
+	monthLayout03 = "Jan. 2006"
 )
 
 var (
@@ -20,27 +24,14 @@ var (
 	editionCardinalRegex = regexp.MustCompile(`(?i),? ?\(?(\d+)(st|nd|rd|th) (Edition)\)?`)
 	// Second Edition / Fifth Edition
 	editionOrdinalRegex = regexp.MustCompile(`(?i),? ?\(?([a-zA-Z]{3,}) (Edition)\)?`)
-	// Apress; 1st ed. edition (November 1, 2020) / Wiley; 1st edition (October 16, 2017)
-	pubRegexp01 = regexp.MustCompile(`(^[A-Z][^;]+); ((\d+)(st|nd|rd|th))?([^(]+)\((\w+ \d+, \d+)\)`)
-	// No Starch Press (November 5, 2020)
-	pubRegexp02 = regexp.MustCompile(`(^[A-Z][^(;]+) \((\w+ \d+, \d+)\)`)
-	// Esri Press; Fourth edition (December 28, 2021) / Esri Press; Fourth Bilingual edition (December 28, 2021)
-	pubRegexp03 = regexp.MustCompile(`(?i)(^[A-Z][^(;]+); ([a-z-A-Z]+(st|nd|rd|th)) ?\w* edition \((\w+ \d+, \d+)\)`)
-	// Packt Publishing; 3rd edition (17 May 2021)
-	pubRegexp04 = regexp.MustCompile(`(^[A-Z][^;]+); ((\d+)(st|nd|rd|th))?([^(]+)\((\d+ \w+\.? \d+)\)`)
-	// No Starch Press (1 Oct. 2020)
-	pubRegexp05 = regexp.MustCompile(`(^[A-Z][^(;]+) \((\d+ \w+\.? \d+)\)`)
-	// Esri Press; Fourth edition (10 Feb. 2022) / Esri Press; Fourth Bilingual edition (10 Feb. 2022)
-	pubRegexp06 = regexp.MustCompile(`(?i)(^[A-Z][^(;]+); ([a-z-A-Z]+(st|nd|rd|th)) ?\w* edition \((\d+ \w+\.? \d+)\)`)
-	// Springer; 2nd ed. 2023 edition
-	pubRegexp07 = regexp.MustCompile(`(^[A-Z][^;]+); ((\d+)(st|nd|rd|th))? (ed\. \d+ edition)$`)
-	// Packt Publishing
-	pubRegexp08 = regexp.MustCompile(`(^[A-Z][^(;]+)$`)
-	// 522 pages
-	lengthRegex = regexp.MustCompile(`(^\d+) pages`)
+	// Apress; 1st ed. edition (November 1, 2020) -> '1st ed. edition'
+	pubEditionRegexp = regexp.MustCompile(`^[^;]+;\s*([^(]+)`)
+	// 522 pages / 522 Seiten
+	lengthRegex = regexp.MustCompile(`(^\d+) (?:pages|Seiten)`)
 )
 
 // ParseTitleString parses a book title string and returns separate title and subtitle strings.
+// The edition and series parts are removed, the series could be extracted by ParseTitleSeries.
 func ParseTitleString(titleString string) (string, string) {
 	// Try to cut out the ordinal 'edition' part, like '3rd Edition'
 	cardinalEditionSubMatch := editionCardinalRegex.FindStringSubmatch(titleString)
@@ -57,6 +48,9 @@ func ParseTitleString(titleString string) (string, string) {
 		}
 	}
 
+	// Try to cut out the series part, like '(Addison-Wesley Professional Computing Series)'
+	titleString = cutTitleSeries(titleString)
+
 	// Extract 'titleString' and 'subtitle' values
 	lastColonIndex := strings.LastIndex(titleString, ": ")
 	if lastColonIndex == -1 {
@@ -73,135 +67,19 @@ func ParseTitleString(titleString string) (string, string) {
 }
 
 // ParsePublisherString parses a book publisher string and returns publisher-related meta information.
+// The string is parsed by the publisher rules, TracePublisherString returns the matched rules as well.
 func ParsePublisherString(publisherString string) (BookPublishMeta, error) {
-	var publisher string
-	var edition = 0
-	var dateString string
-
-	// US format 1
-	subMatch := pubRegexp01.FindStringSubmatch(publisherString)
-	if len(subMatch) == 7 {
-		publisher = subMatch[1]
-		if subMatch[3] != "" {
-			edition, _ = strconv.Atoi(subMatch[3])
-		}
-		dateString = subMatch[6]
-	}
-
-	// US format 2
-	subMatch = pubRegexp02.FindStringSubmatch(publisherString)
-	if len(subMatch) == 3 {
-		publisher = subMatch[1]
-		dateString = subMatch[2]
-	}
-
-	// US format 3
-	subMatch = pubRegexp03.FindStringSubmatch(publisherString)
-	if len(subMatch) == 5 {
-		publisher = subMatch[1]
-		if subMatch[2] != "" {
-			ed, err := wordnumber.OrdinalToInt(subMatch[2])
-			if err == nil {
-				edition = ed
-			}
-		}
-		dateString = subMatch[4]
-	}
-
-	// EU format 1
-	subMatch = pubRegexp04.FindStringSubmatch(publisherString)
-	if len(subMatch) == 7 {
-		publisher = subMatch[1]
-		if subMatch[3] != "" {
-			edition, _ = strconv.Atoi(subMatch[3])
-		}
-		dateString = subMatch[6]
-	}
-
-	// EU format 2
-	subMatch = pubRegexp05.FindStringSubmatch(publisherString)
-	if len(subMatch) == 3 {
-		publisher = subMatch[1]
-		dateString = subMatch[2]
-	}
-
-	// EU format 3
-	subMatch = pubRegexp06.FindStringSubmatch(publisherString)
-	if len(subMatch) == 5 {
-		publisher = subMatch[1]
-		if subMatch[2] != "" {
-			ed, err := wordnumber.OrdinalToInt(subMatch[2])
-			if err == nil {
-				edition = ed
-			}
-		}
-		dateString = subMatch[4]
-	}
-
-	// US format 4
-	subMatch = pubRegexp07.FindStringSubmatch(publisherString)
-	if len(subMatch) == 6 {
-		publisher = subMatch[1]
-		if subMatch[2] != "" {
-			ed, err := strconv.Atoi(subMatch[3])
-			if err == nil {
-				edition = ed
-			}
-		}
-	}
-
-	// Plain format
-	subMatch = pubRegexp08.FindStringSubmatch(publisherString)
-	if len(subMatch) == 2 {
-		publisher = subMatch[1]
-	}
+	result, err := TracePublisherString(publisherString)
 
-	if publisher == "" {
-		return BookPublishMeta{}, fmt.Errorf("the publisher string '%s' can not be parsed", publisherString)
-	}
-
-	publisher = strings.TrimSpace(publisher)
-	editionNumber := uint8(edition)
-
-	date, err := ParseDateString(dateString)
-	if err != nil {
-		return BookPublishMeta{
-			Publisher: publisher,
-			Edition:   editionNumber,
-		}, fmt.Errorf("can not get publication date: %w", err)
-	}
-
-	return BookPublishMeta{
-		Publisher: publisher,
-		Edition:   editionNumber,
-		PubDate:   date,
-	}, nil
+	return result.Meta, err
 }
 
 // ParseEditionString parses a book edition string and returns its numeric value.
+// ParseEdition returns the edition qualifier and the year of a year-style edition as well.
 func ParseEditionString(editionString string) (uint8, error) {
-	// For the cases like: 2nd ed.
-	if strings.Contains(editionString, " ed.") {
-		editionString = strings.Replace(editionString, " ed.", "", 1)
-	}
-	// Try to parse cardinal value like '2nd Edition'
-	cardinalEditionSubMatch := editionCardinalRegex.FindStringSubmatch(editionString)
-	if cardinalEditionSubMatch != nil {
-		ed, _ := strconv.Atoi(cardinalEditionSubMatch[1])
-		return uint8(ed), nil
-	}
+	edition, err := ParseEdition(editionString)
 
-	// Try to parse ordinal edition value like 'Second Edition'
-	ordinalEditionSubMatch := editionOrdinalRegex.FindStringSubmatch(editionString)
-	if ordinalEditionSubMatch != nil {
-		ordinalValue, err := wordnumber.OrdinalToInt(ordinalEditionSubMatch[1])
-		if err != nil {
-			return 0, err
-		}
-		return uint8(ordinalValue), nil
-	}
-
-	return 0, nil
+	return edition.Number, err
 }
 
 // ParseLengthString parses a book length string and returns its numeric value.
@@ -215,23 +93,45 @@ func ParseLengthString(lengthString string) uint16 {
 	return 0
 }
 
-// ParseDateString parses a date string in one of allowed formats and returns its value.
+// ParseDateString parses an English date string in one of allowed formats and returns its value.
+// The partial dates, like 'October 2020' or '2020', are completed with the first month and/or day,
+// ParsePartialDateString returns their precision as well.
 func ParseDateString(dateString string) (time.Time, error) {
-	// Handle invalid month abbreviation, like: '30 Sept. 2022', and normalize it to: '30 Sep. 2022' form
-	if strings.Contains(dateString, ".") {
-		splitString := strings.Split(dateString, " ")
-		if len(splitString) == 3 && len(splitString[1]) > 4 {
-			dateString = fmt.Sprintf("%s %s. %s", splitString[0], splitString[1][:3], splitString[2])
+	date, _, err := ParsePartialDateString(dateString, LanguageEnglish)
+
+	return date, err
+}
+
+// parseEnglishDateString parses an English full or partial date string.
+func parseEnglishDateString(dateString string) (time.Time, DatePrecision, error) {
+	dateString = normalizeMonthAbbreviation(dateString)
+	for _, layout := range []string{dateLayout01, dateLayout02, dateLayout03} {
+		if t, err := time.Parse(layout, dateString); err == nil {
+			return t, DatePrecisionDay, nil
 		}
 	}
-
-	if t, err := time.Parse(dateLayout01, dateString); err == nil {
-		return t, nil
+	for _, layout := range []string{monthLayout01, monthLayout02, monthLayout03} {
+		if t, err := time.Parse(layout, dateString); err == nil {
+			return t, DatePrecisionMonth, nil
+		}
 	}
 
-	if t, err := time.Parse(dateLayout02, dateString); err == nil {
-		return t, nil
+	return time.Time{}, DatePrecisionDay, fmt.Errorf("the date string '%s' can not be parsed", dateString)
+}
+
+// normalizeMonthAbbreviation handles invalid month abbreviation, like: '30 Sept. 2022', and normalizes it
+// to: '30 Sep. 2022' form.
+func normalizeMonthAbbreviation(dateString string) string {
+	if !strings.Contains(dateString, ".") {
+		return dateString
+	}
+	splitString := strings.Split(dateString, " ")
+	if len(splitString) == 3 && len(splitString[1]) > 4 {
+		return fmt.Sprintf("%s %s. %s", splitString[0], splitString[1][:3], splitString[2])
+	}
+	if len(splitString) == 2 && len(splitString[0]) > 4 {
+		return fmt.Sprintf("%s. %s", splitString[0][:3], splitString[1])
 	}
 
-	return time.Parse(dateLayout03, dateString)
+	return dateString
 }
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseContributorString(t *testing.T) {
+	tests := []struct {
+		input string
+		name  string
+		roles []string
+	}{
+		{input: "Jane Doe", name: "Jane Doe", roles: []string{ContributorRoleAuthor}},
+		{input: " Jane Doe (Editor) ", name: "Jane Doe", roles: []string{ContributorRoleEditor}},
+		{input: "Jane Doe (Translator)", name: "Jane Doe", roles: []string{ContributorRoleTranslator}},
+		{input: "Jane Doe (Foreword)", name: "Jane Doe", roles: []string{ContributorRoleForeword}},
+		{input: "Jane Doe (Author, Illustrator)", name: "Jane Doe",
+			roles: []string{ContributorRoleAuthor, ContributorRoleIllustrator}},
+		{input: "Jane Doe (Author, Editor)", name: "Jane Doe",
+			roles: []string{ContributorRoleAuthor, ContributorRoleEditor}},
+		{input: "Jane Doe (Illustrator, Unknown, Editor)", name: "Jane Doe",
+			roles: []string{ContributorRoleIllustrator, ContributorRoleEditor}},
+		{input: "Jane Doe (Editor, Edited by)", name: "Jane Doe", roles: []string{ContributorRoleEditor}},
+		{input: "Max Mustermann (Herausgeber)", name: "Max Mustermann", roles: []string{ContributorRoleEditor}},
+		{input: "Jean Dupont (Préface)", name: "Jean Dupont", roles: []string{ContributorRoleForeword}},
+		{input: "John Smith (Jr.)", name: "John Smith (Jr.)", roles: []string{ContributorRoleAuthor}},
+	}
+
+	t.Log("Given the need to test contributor string parsing.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for contributor %q with %q roles\n", i, tt.input, tt.name, tt.roles)
+		name, roles := ParseContributorString(tt.input)
+		if name != tt.name || !reflect.DeepEqual(roles, tt.roles) {
+			t.Errorf("\t\t%s\tShould get a %q contributor with %q roles: %q with %q roles", failed, tt.name,
+				tt.roles, name, roles)
+			continue
+		}
+		if formatted, _ := ParseContributorString(FormatContributor(name, roles...)); formatted != name {
+			t.Errorf("\t\t%s\tShould parse the formatted contributor back: %q", failed, formatted)
+			continue
+		}
+		t.Logf("\t\t%s\tShould be able to get correct contributor value.", succeed)
+	}
+}
+
+func TestFormatContributor(t *testing.T) {
+	tests := []struct {
+		name   string
+		role   string
+		output string
+	}{
+		{name: "Jane Doe", role: "", output: "Jane Doe"},
+		{name: "Jane Doe", role: ContributorRoleAuthor, output: "Jane Doe"},
+		{name: "Jane Doe", role: ContributorRoleEditor, output: "Jane Doe (Editor)"},
+		{name: "Jane Doe", role: ContributorRoleIntroduction, output: "Jane Doe (Introduction)"},
+	}
+	multiRoleTests := []struct {
+		name   string
+		roles  []string
+		output string
+	}{
+		{name: "Jane Doe", roles: nil, output: "Jane Doe"},
+		{name: "Jane Doe", roles: []string{ContributorRoleAuthor, ContributorRoleEditor},
+			output: "Jane Doe (Author, Editor)"},
+		{name: "Jane Doe", roles: []string{ContributorRoleEditor, ContributorRoleTranslator},
+			output: "Jane Doe (Editor, Translator)"},
+	}
+
+	t.Log("Given the need to test contributor formatting.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen formatting %q contributor with %q role\n", i, tt.name, tt.role)
+		if output := FormatContributor(tt.name, tt.role); output != tt.output {
+			t.Errorf("\t\t%s\tShould get a %q contributor string: %q", failed, tt.output, output)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct contributor string.", succeed)
+		}
+	}
+	for i, tt := range multiRoleTests {
+		t.Logf("\tTest: %d\tWhen formatting %q contributor with %q roles\n", len(tests)+i, tt.name, tt.roles)
+		if output := FormatContributor(tt.name, tt.roles...); output != tt.output {
+			t.Errorf("\t\t%s\tShould get a %q contributor string: %q", failed, tt.output, output)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct contributor string.", succeed)
+		}
+	}
+}
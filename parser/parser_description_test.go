@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"testing"
+)
+
+var descriptionTests = []struct {
+	input    string
+	html     string
+	markdown string
+	text     string
+}{
+	{
+		input:    "",
+		html:     "",
+		markdown: "",
+		text:     "",
+	},
+	{
+		input:    "First paragraph\nline.\n\nSecond < paragraph.",
+		html:     "<p>First paragraph line.</p><p>Second &lt; paragraph.</p>",
+		markdown: "First paragraph line.\n\nSecond < paragraph.",
+		text:     "First paragraph line.\n\nSecond < paragraph.",
+	},
+	{
+		input:    `<p><span class="a-text-bold">Test</span> description</p><p></p><p><br></p>`,
+		html:     "<p>Test description</p>",
+		markdown: "Test description",
+		text:     "Test description",
+	},
+	{
+		input:    `<div style="color: red">Learn <strong>Go</strong> and <em>C_1*</em>:<br/>today</div><script>alert(1)</script>`,
+		html:     "Learn <b>Go</b> and <i>C_1*</i>:<br>today",
+		markdown: "Learn **Go** and *C\\_1\\**:  \ntoday",
+		text:     "Learn Go and C_1*:\ntoday",
+	},
+	{
+		input:    `<p>Topics:</p><ol class="list"><li><span>one</span></li><li>two &amp; three</li></ol><p>Tail <a href="/x">link</a></p>`,
+		html:     "<p>Topics:</p><ol><li>one</li><li>two &amp; three</li></ol><p>Tail link</p>",
+		markdown: "Topics:\n\n1. one\n2. two & three\n\nTail link",
+		text:     "Topics:\n\n1. one\n2. two & three\n\nTail link",
+	},
+	{
+		input:    `<ul><li>one</li><li>two</li></ul>`,
+		html:     "<ul><li>one</li><li>two</li></ul>",
+		markdown: "- one\n- two",
+		text:     "- one\n- two",
+	},
+	{
+		input:    `<p>foo<b> bar</b>baz <i>qux </i> end<em> </em>tail</p>`,
+		html:     "<p>foo <b>bar</b>baz <i>qux</i> end tail</p>",
+		markdown: "foo **bar**baz *qux* end tail",
+		text:     "foo barbaz qux end tail",
+	},
+	{
+		input:    "<p># Not a [title](url) with `code`</p><p>- not a list<br>1. not a list<br>+ not a list</p>",
+		html:     "<p># Not a [title](url) with `code`</p><p>- not a list<br>1. not a list<br>+ not a list</p>",
+		markdown: "\\# Not a \\[title](url) with \\`code\\`\n\n\\- not a list  \n1\\. not a list  \n\\+ not a list",
+		text:     "# Not a [title](url) with `code`\n\n- not a list\n1. not a list\n+ not a list",
+	},
+	{
+		input:    "<p>3.14 - pi, 2023. Great year</p><p>2023. Great year</p>",
+		html:     "<p>3.14 - pi, 2023. Great year</p><p>2023. Great year</p>",
+		markdown: "3.14 - pi, 2023. Great year\n\n2023\\. Great year",
+		text:     "3.14 - pi, 2023. Great year\n\n2023. Great year",
+	},
+	{
+		input:    `<jats:p>Abstract <style>p {}</style>text</jats:p>`,
+		html:     "<p>Abstract text</p>",
+		markdown: "Abstract text",
+		text:     "Abstract text",
+	},
+}
+
+func TestSanitizeDescription(t *testing.T) {
+	t.Log("Given the need to test book description sanitizing.")
+	for i, tt := range descriptionTests {
+		t.Logf("\tTest: %d\tWhen checking %q for HTML %q\n", i, tt.input, tt.html)
+		result := SanitizeDescription(tt.input)
+		if result != tt.html {
+			t.Errorf("\t\t%s\tShould get a %q HTML: %q", failed, tt.html, result)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct HTML.", succeed)
+		}
+	}
+}
+
+func TestDescriptionToMarkdown(t *testing.T) {
+	t.Log("Given the need to test book description conversion to Markdown.")
+	for i, tt := range descriptionTests {
+		t.Logf("\tTest: %d\tWhen checking %q for Markdown %q\n", i, tt.input, tt.markdown)
+		result := DescriptionToMarkdown(tt.input)
+		if result != tt.markdown {
+			t.Errorf("\t\t%s\tShould get a %q Markdown: %q", failed, tt.markdown, result)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct Markdown.", succeed)
+		}
+	}
+}
+
+func TestDescriptionToText(t *testing.T) {
+	t.Log("Given the need to test book description conversion to plain text.")
+	for i, tt := range descriptionTests {
+		t.Logf("\tTest: %d\tWhen checking %q for text %q\n", i, tt.input, tt.text)
+		result := DescriptionToText(tt.input)
+		if result != tt.text {
+			t.Errorf("\t\t%s\tShould get a %q text: %q", failed, tt.text, result)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct text.", succeed)
+		}
+	}
+}
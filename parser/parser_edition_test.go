@@ -63,3 +63,111 @@ func TestParseEditionString(t *testing.T) {
 		}
 	}
 }
+
+func TestParseEdition(t *testing.T) {
+	tests := []struct {
+		input             string
+		edition           Edition
+		shouldReturnError bool
+	}{
+		{
+			input:   "3rd Revised Edition",
+			edition: Edition{Number: 3, Qualifier: "Revised"},
+		},
+		{
+			input:   "The Pragmatic Programmer: Your Journey To Mastery, 20th Anniversary Edition (2nd Edition)",
+			edition: Edition{Number: 2, Qualifier: "20th Anniversary"},
+		},
+		{
+			input:   "Fourth Bilingual edition",
+			edition: Edition{Number: 4, Qualifier: "Bilingual"},
+		},
+		{
+			input:   "2nd ed. 2023 edition",
+			edition: Edition{Number: 2, Year: 2023},
+		},
+		{
+			input:   "2023 Edition",
+			edition: Edition{Year: 2023},
+		},
+		{
+			input:   "Mathematik für Informatiker, Edition III",
+			edition: Edition{Number: 3},
+		},
+		{
+			input:   "Linux Bible, IV Edition",
+			edition: Edition{Number: 4},
+		},
+		{
+			input:   "C++ Data-Related Patterns. (Global Edition)",
+			edition: Edition{Qualifier: "Global"},
+		},
+		{
+			input:   "Real-World Python: A Hacker's Guide to Solving Problems with Code",
+			edition: Edition{},
+		},
+		{
+			input:             "Unknown Edition",
+			shouldReturnError: true,
+		},
+	}
+
+	t.Log("Given the need to test structured edition parsing.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for edition %+v\n", i, tt.input, tt.edition)
+		edition, err := ParseEdition(tt.input)
+		if err != nil {
+			if tt.shouldReturnError {
+				t.Logf("\t\t%s\tShould get an error.", succeed)
+				continue
+			}
+			t.Fatalf("\t\t%s\tShould be able to get edition value: %v", failed, err)
+		}
+		if tt.shouldReturnError {
+			t.Fatalf("\t\t%s\tShould get an error.", failed)
+		}
+
+		if edition != tt.edition {
+			t.Errorf("\t\t%s\tShould get a %+v edition: %+v", failed, tt.edition, edition)
+			continue
+		}
+		if parsed, _ := ParseEdition(edition.String()); parsed != edition {
+			t.Errorf("\t\t%s\tShould parse the %q edition text back: %+v", failed, edition.String(), parsed)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct edition value.", succeed)
+		}
+	}
+}
+
+func TestParseRomanNumeral(t *testing.T) {
+	tests := []struct {
+		input  string
+		number int
+	}{
+		{input: "I", number: 1},
+		{input: "IV", number: 4},
+		{input: "IX", number: 9},
+		{input: "XIV", number: 14},
+		{input: "XLIX", number: 49},
+		{input: "LXXX", number: 80},
+		{input: "IIII", number: 0},
+		{input: "VX", number: 0},
+		{input: "IL", number: 0},
+		{input: "IIV", number: 0},
+		{input: "VV", number: 0},
+		{input: "XXXX", number: 0},
+		{input: "iv", number: 0},
+		{input: "", number: 0},
+	}
+
+	t.Log("Given the need to test Roman numeral parsing.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for number %d\n", i, tt.input, tt.number)
+		number := parseRomanNumeral(tt.input)
+		if number != tt.number {
+			t.Errorf("\t\t%s\tShould get a %d number: %d", failed, tt.number, number)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct number.", succeed)
+		}
+	}
+}
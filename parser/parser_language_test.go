@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		input string
+		name  string
+		code  string
+	}{
+		{input: "English", name: "English", code: "en"},
+		{input: " Englisch ", name: "English", code: "en"},
+		{input: "Anglais", name: "English", code: "en"},
+		{input: "Französisch", name: "French", code: "fr"},
+		{input: "español", name: "Spanish", code: "es"},
+		{input: "Tedesco", name: "German", code: "de"},
+		{input: "en", name: "English", code: "en"},
+		{input: "eng", name: "English", code: "en"},
+		{input: "ger", name: "German", code: "de"},
+		{input: "en-US", name: "English", code: "en"},
+		{input: "pt_BR", name: "Portuguese", code: "pt"},
+		{input: "/languages/fre", name: "French", code: "fr"},
+		{input: "Altgriechisch", name: "Ancient Greek", code: "grc"},
+		{input: "Klingon", name: "Klingon", code: ""},
+		{input: "", name: "", code: ""},
+	}
+
+	t.Log("Given the need to test language normalization.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for %q language with %q code\n", i, tt.input, tt.name, tt.code)
+		name, code := NormalizeLanguage(tt.input)
+		if name != tt.name || code != tt.code {
+			t.Errorf("\t\t%s\tShould get a %q language with %q code: %q with %q code", failed, tt.name, tt.code,
+				name, code)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct language value.", succeed)
+		}
+	}
+}
+
+func TestLanguageAliases(t *testing.T) {
+	t.Log("Given the need to test language aliases.")
+	codes := make(map[string]string)
+	for code, aliases := range languageAliases {
+		if GetLanguageName(code) == "" {
+			t.Errorf("\t\t%s\tShould have a %q language name", failed, code)
+		}
+		for _, alias := range aliases {
+			if existingCode, ok := codes[alias]; ok {
+				t.Errorf("\t\t%s\tShould have a unique %q alias: %q and %q", failed, alias, existingCode, code)
+			}
+			codes[alias] = code
+		}
+	}
+
+	t.Logf("\t\t%s\tShould be able to map every language alias to a single language", succeed)
+}
@@ -13,6 +13,10 @@ func TestParseLengthString(t *testing.T) {
 			input:  "544 pages",
 			length: 544,
 		},
+		{
+			input:  "355 Seiten",
+			length: 355,
+		},
 		{
 			input:  "",
 			length: 0,
@@ -0,0 +1,293 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLocalizedDateString(t *testing.T) {
+	tests := []struct {
+		input             string
+		language          string
+		date              time.Time
+		shouldReturnError bool
+	}{
+		{
+			input:    "6. April 2022",
+			language: LanguageGerman,
+			date:     time.Date(2022, 4, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:    "17. März 2021",
+			language: LanguageGerman,
+			date:     time.Date(2021, 3, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:    "6 avril 2022",
+			language: LanguageFrench,
+			date:     time.Date(2022, 4, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:    "1er octobre 2020",
+			language: LanguageFrench,
+			date:     time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:    "October 25, 2022",
+			language: LanguageGerman,
+			date:     time.Date(2022, 10, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:    "25 Oct. 2022",
+			language: LanguageEnglish,
+			date:     time.Date(2022, 10, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:             "6. Foo 2022",
+			language:          LanguageGerman,
+			shouldReturnError: true,
+		},
+	}
+
+	t.Log("Given the need to test localized date string parsing.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q (%s) for date %s\n", i, tt.input, tt.language, tt.date)
+		date, err := ParseLocalizedDateString(tt.input, tt.language)
+		if err != nil {
+			if tt.shouldReturnError {
+				t.Logf("\t\t%s\tShould get an error.", succeed)
+				continue
+			}
+			t.Fatalf("\t\t%s\tShould be able to get date value: %v", failed, err)
+		}
+		if tt.shouldReturnError {
+			t.Fatalf("\t\t%s\tShould get an error.", failed)
+		}
+
+		if date != tt.date {
+			t.Errorf("\t\t%s\tShould get a %s date: %s", failed, tt.date, date)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct date value.", succeed)
+		}
+	}
+}
+
+func TestParsePartialDateString(t *testing.T) {
+	tests := []struct {
+		input             string
+		language          string
+		date              time.Time
+		precision         DatePrecision
+		shouldReturnError bool
+	}{
+		{
+			input:    "1. Oktober 2020",
+			language: LanguageGerman,
+			date:     time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:    "1 octobre 2020",
+			language: LanguageFrench,
+			date:     time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:    "1 de octubre de 2020",
+			language: LanguageSpanish,
+			date:     time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:    "1 ott. 2020",
+			language: LanguageItalian,
+			date:     time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:    "2020/10/1",
+			language: LanguageJapanese,
+			date:     time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:    "2020年10月1日",
+			language: LanguageJapanese,
+			date:     time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:     "Oktober 2020",
+			language:  LanguageGerman,
+			date:      time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+			precision: DatePrecisionMonth,
+		},
+		{
+			input:     "octubre de 2020",
+			language:  LanguageSpanish,
+			date:      time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+			precision: DatePrecisionMonth,
+		},
+		{
+			input:     "October 2020",
+			language:  LanguageEnglish,
+			date:      time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+			precision: DatePrecisionMonth,
+		},
+		{
+			input:     "Sept. 2020",
+			language:  LanguageEnglish,
+			date:      time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+			precision: DatePrecisionMonth,
+		},
+		{
+			input:     "2020-10",
+			language:  LanguageEnglish,
+			date:      time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+			precision: DatePrecisionMonth,
+		},
+		{
+			input:     "2020",
+			language:  LanguageFrench,
+			date:      time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			precision: DatePrecisionYear,
+		},
+		{
+			input:     "2020年",
+			language:  LanguageJapanese,
+			date:      time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			precision: DatePrecisionYear,
+		},
+		{
+			input:             "2020/13/1",
+			language:          LanguageJapanese,
+			shouldReturnError: true,
+		},
+		{
+			input:             "31 de febrero de 2020",
+			language:          LanguageSpanish,
+			shouldReturnError: true,
+		},
+	}
+
+	t.Log("Given the need to test partial and multilingual date string parsing.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q (%s) for date %s\n", i, tt.input, tt.language, tt.date)
+		date, precision, err := ParsePartialDateString(tt.input, tt.language)
+		if err != nil {
+			if tt.shouldReturnError {
+				t.Logf("\t\t%s\tShould get an error.", succeed)
+				continue
+			}
+			t.Fatalf("\t\t%s\tShould be able to get date value: %v", failed, err)
+		}
+		if tt.shouldReturnError {
+			t.Fatalf("\t\t%s\tShould get an error.", failed)
+		}
+
+		if date != tt.date || precision != tt.precision {
+			t.Errorf("\t\t%s\tShould get a %s date with the %s precision: %s, %s", failed, tt.date, tt.precision,
+				date, precision)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct date value and precision.", succeed)
+		}
+	}
+}
+
+func TestParseLocalizedPublisherString(t *testing.T) {
+	tests := []struct {
+		input             string
+		language          string
+		meta              BookPublishMeta
+		shouldReturnError bool
+	}{
+		{
+			input:    "dpunkt.verlag GmbH; 4. Edition (6. April 2022)",
+			language: LanguageGerman,
+			meta: BookPublishMeta{
+				Publisher: "dpunkt.verlag GmbH",
+				Edition:   Edition{Number: 4},
+				PubDate:   time.Date(2022, 4, 6, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:    "Rheinwerk Computing; 2. Auflage",
+			language: LanguageGerman,
+			meta: BookPublishMeta{
+				Publisher: "Rheinwerk Computing",
+				Edition:   Edition{Number: 2},
+			},
+		},
+		{
+			input:    "Eyrolles (1er octobre 2020)",
+			language: LanguageFrench,
+			meta: BookPublishMeta{
+				Publisher: "Eyrolles",
+				PubDate:   time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:    "ENI; 3e édition (6 avril 2022)",
+			language: LanguageFrench,
+			meta: BookPublishMeta{
+				Publisher: "ENI",
+				Edition:   Edition{Number: 3},
+				PubDate:   time.Date(2022, 4, 6, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:    "Anaya Multimedia; 4ª edición (1 de octubre de 2020)",
+			language: LanguageSpanish,
+			meta: BookPublishMeta{
+				Publisher: "Anaya Multimedia",
+				Edition:   Edition{Number: 4},
+				PubDate:   time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:    "オライリージャパン; 第3版 (2020/10/1)",
+			language: LanguageJapanese,
+			meta: BookPublishMeta{
+				Publisher: "オライリージャパン",
+				Edition:   Edition{Number: 3},
+				PubDate:   time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:    "Apogeo (ottobre 2020)",
+			language: LanguageItalian,
+			meta: BookPublishMeta{
+				Publisher:        "Apogeo",
+				PubDate:          time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+				PubDatePrecision: DatePrecisionMonth,
+			},
+		},
+		{
+			input:    "Packt Publishing; 3rd edition (17 May 2021)",
+			language: LanguageEnglish,
+			meta: BookPublishMeta{
+				Publisher: "Packt Publishing",
+				Edition:   Edition{Number: 3},
+				PubDate:   time.Date(2021, 5, 17, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			input:             "",
+			language:          LanguageGerman,
+			shouldReturnError: true,
+		},
+	}
+
+	t.Log("Given the need to test localized publisher string parsing.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q (%s) for publisher meta\n", i, tt.input, tt.language)
+		meta, err := ParseLocalizedPublisherString(tt.input, tt.language)
+		if err != nil {
+			if tt.shouldReturnError {
+				t.Logf("\t\t%s\tShould get an error.", succeed)
+				continue
+			}
+			t.Fatalf("\t\t%s\tShould be able to get publisher meta: %v", failed, err)
+		}
+
+		if meta != tt.meta {
+			t.Errorf("\t\t%s\tShould get a %+v publisher meta: %+v", failed, tt.meta, meta)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct publisher meta.", succeed)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"gopkg.in/yaml.v3"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	publisherCorpusFile = "testdata/publisher_corpus.yaml"
+	publisherRulesFile  = "rules/publisher.yaml"
+)
+
+type publisherCorpusEntry struct {
+	Input     string `yaml:"input"`
+	Rule      string `yaml:"rule"`
+	Publisher string `yaml:"publisher"`
+	Edition   struct {
+		Number    uint8  `yaml:"number"`
+		Qualifier string `yaml:"qualifier"`
+		Year      uint16 `yaml:"year"`
+	} `yaml:"edition"`
+	Date  string `yaml:"date"`
+	Error bool   `yaml:"error"`
+}
+
+func TestPublisherRulesCorpus(t *testing.T) {
+	data, err := os.ReadFile(publisherCorpusFile)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to read the publisher corpus file: %v", failed, err)
+	}
+	var corpus []publisherCorpusEntry
+	if err := yaml.Unmarshal(data, &corpus); err != nil {
+		t.Fatalf("\t\t%s\tShould be able to decode the publisher corpus file: %v", failed, err)
+	}
+
+	t.Log("Given the need to test all known publisher strings against the publisher rules.")
+	for i, entry := range corpus {
+		t.Logf("\tTest: %d\tWhen checking %q for the %q rule\n", i, entry.Input, entry.Rule)
+		meta := BookPublishMeta{
+			Publisher: entry.Publisher,
+			Edition:   Edition{Number: entry.Edition.Number, Qualifier: entry.Edition.Qualifier, Year: entry.Edition.Year},
+		}
+		if entry.Date != "" {
+			meta.PubDate, err = time.Parse("2006-01-02", entry.Date)
+			if err != nil {
+				t.Fatalf("\t\t%s\tShould have a valid corpus date: %v", failed, err)
+			}
+		}
+
+		result, err := TracePublisherString(entry.Input)
+		if (err != nil) != entry.Error {
+			t.Errorf("\t\t%s\tShould get an error (%t) parsing the publisher string: %v", failed, entry.Error, err)
+		}
+		if result.Rule != entry.Rule {
+			t.Errorf("\t\t%s\tShould match the %q rule: %q", failed, entry.Rule, result.Rule)
+		}
+		if result.Ambiguous {
+			t.Errorf("\t\t%s\tShould match a single top priority rule: %v", failed, result.Matched)
+		}
+		if result.Meta != meta {
+			t.Errorf("\t\t%s\tShould get a %v publisher metadata: %v", failed, meta, result.Meta)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct publisher metadata.", succeed)
+		}
+	}
+}
+
+func TestLoadPublisherRules(t *testing.T) {
+	t.Log("Given the need to test the built-in publisher rules file loading.")
+	t.Logf("\tTest: %d\tWhen loading the %q file\n", 0, publisherRulesFile)
+	rules, err := LoadPublisherRules(publisherRulesFile)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to load the publisher rules file: %v", failed, err)
+	}
+	if !reflect.DeepEqual(rules, DefaultPublisherRules()) {
+		t.Errorf("\t\t%s\tShould get the built-in publisher rules: %v", failed, rules)
+	} else {
+		t.Logf("\t\t%s\tShould be able to get the built-in publisher rules.", succeed)
+	}
+}
+
+func TestNewPublisherRuleSet(t *testing.T) {
+	tests := []struct {
+		name              string
+		rules             []PublisherRule
+		shouldReturnError bool
+	}{
+		{
+			name:  "valid rules",
+			rules: DefaultPublisherRules(),
+		},
+		{
+			name:              "empty name",
+			rules:             []PublisherRule{{Pattern: `(.+)`, Publisher: 1}},
+			shouldReturnError: true,
+		},
+		{
+			name: "duplicate name",
+			rules: []PublisherRule{
+				{Name: "custom", Pattern: `(.+)`, Publisher: 1},
+				{Name: "custom", Pattern: `(.+)`, Publisher: 1},
+			},
+			shouldReturnError: true,
+		},
+		{
+			name:              "invalid pattern",
+			rules:             []PublisherRule{{Name: "custom", Pattern: `(.+`, Publisher: 1}},
+			shouldReturnError: true,
+		},
+		{
+			name:              "no publisher capture",
+			rules:             []PublisherRule{{Name: "custom", Pattern: `(.+)`}},
+			shouldReturnError: true,
+		},
+		{
+			name:              "date capture out of range",
+			rules:             []PublisherRule{{Name: "custom", Pattern: `(.+)`, Publisher: 1, Date: 2}},
+			shouldReturnError: true,
+		},
+	}
+
+	t.Log("Given the need to test publisher rule set validation.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking the %s\n", i, tt.name)
+		_, err := NewPublisherRuleSet(tt.rules)
+		if (err != nil) != tt.shouldReturnError {
+			t.Errorf("\t\t%s\tShould get an error (%t) creating the rule set: %v", failed, tt.shouldReturnError, err)
+		} else {
+			t.Logf("\t\t%s\tShould be able to validate the rule set.", succeed)
+		}
+	}
+}
+
+func TestPublisherRuleSet_ParseAmbiguous(t *testing.T) {
+	ruleSet, err := NewPublisherRuleSet(append(DefaultPublisherRules(), PublisherRule{
+		Name:      "custom-date",
+		Pattern:   `(^[A-Z][^(;]+) \((\w+ \d+, \d+)\)`,
+		Publisher: 1,
+		Date:      2,
+		Priority:  publisherRulePriorityDefault,
+	}))
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create the rule set: %v", failed, err)
+	}
+
+	t.Log("Given the need to test ambiguous publisher string matches.")
+	t.Logf("\tTest: %d\tWhen two rules with the same priority match\n", 0)
+	result, err := ruleSet.Parse("No Starch Press (November 5, 2020)")
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to parse publisher string: %v", failed, err)
+	}
+	if result.Rule != "us-date" {
+		t.Errorf("\t\t%s\tShould match the first rule in the rule set order: %q", failed, result.Rule)
+	}
+	if !result.Ambiguous || !reflect.DeepEqual(result.Matched, []string{"us-date", "custom-date"}) {
+		t.Errorf("\t\t%s\tShould get an ambiguous result: %v", failed, result.Matched)
+	} else {
+		t.Logf("\t\t%s\tShould be able to flag the ambiguous result.", succeed)
+	}
+}
+
+func TestExtendPublisherRules(t *testing.T) {
+	defer func() {
+		if err := ExtendPublisherRules(nil); err != nil {
+			t.Fatalf("\t\t%s\tShould be able to restore the built-in rules: %v", failed, err)
+		}
+	}()
+	err := ExtendPublisherRules([]PublisherRule{
+		{
+			Name:      "plain",
+			Pattern:   `(^[A-Z][^(;]+?),? (\d{4})$`,
+			Publisher: 1,
+			Date:      2,
+			Priority:  publisherRulePriorityPlain,
+		},
+	})
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to extend the publisher rules: %v", failed, err)
+	}
+
+	t.Log("Given the need to test the publisher rules extension.")
+	t.Logf("\tTest: %d\tWhen a custom rule replaces the built-in one\n", 0)
+	result, err := TracePublisherString("Packt Publishing, 2021")
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to parse publisher string: %v", failed, err)
+	}
+	meta := BookPublishMeta{
+		Publisher:        "Packt Publishing",
+		PubDate:          time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		PubDatePrecision: DatePrecisionYear,
+	}
+	if result.Rule != "plain" || result.Meta != meta {
+		t.Errorf("\t\t%s\tShould get a %v publisher metadata by the custom rule: %v", failed, meta, result)
+	} else {
+		t.Logf("\t\t%s\tShould be able to parse by the custom rule.", succeed)
+	}
+}
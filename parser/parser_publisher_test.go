@@ -15,7 +15,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "Wiley; 1st edition (October 16, 2017)",
 			meta: BookPublishMeta{
 				Publisher: "Wiley",
-				Edition:   1,
+				Edition:   Edition{Number: 1},
 				PubDate:   time.Date(2017, 10, 16, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -23,7 +23,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "No Starch Press; 2nd edition (May 3, 2019)",
 			meta: BookPublishMeta{
 				Publisher: "No Starch Press",
-				Edition:   2,
+				Edition:   Edition{Number: 2},
 				PubDate:   time.Date(2019, 5, 3, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -31,7 +31,6 @@ func TestParsePublisherString(t *testing.T) {
 			input: "No Starch Press (November 5, 2020)",
 			meta: BookPublishMeta{
 				Publisher: "No Starch Press",
-				Edition:   1,
 				PubDate:   time.Date(2020, 11, 5, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -39,7 +38,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "Apress; 1st ed. edition (November 1, 2020)",
 			meta: BookPublishMeta{
 				Publisher: "Apress",
-				Edition:   1,
+				Edition:   Edition{Number: 1},
 				PubDate:   time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -47,7 +46,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "Packt Publishing; 3rd edition (17 May 2021)",
 			meta: BookPublishMeta{
 				Publisher: "Packt Publishing",
-				Edition:   3,
+				Edition:   Edition{Number: 3},
 				PubDate:   time.Date(2021, 5, 17, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -55,7 +54,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "Pearson; 3rd edition (2 Jun. 2022)",
 			meta: BookPublishMeta{
 				Publisher: "Pearson",
-				Edition:   3,
+				Edition:   Edition{Number: 3},
 				PubDate:   time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -63,7 +62,6 @@ func TestParsePublisherString(t *testing.T) {
 			input: "No Starch Press (25 May 2019)",
 			meta: BookPublishMeta{
 				Publisher: "No Starch Press",
-				Edition:   1,
 				PubDate:   time.Date(2019, 5, 25, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -71,7 +69,6 @@ func TestParsePublisherString(t *testing.T) {
 			input: "No Starch Press (1 Oct. 2020)",
 			meta: BookPublishMeta{
 				Publisher: "No Starch Press",
-				Edition:   1,
 				PubDate:   time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -79,7 +76,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "DK Children; Workbook edition (March 7, 2017)",
 			meta: BookPublishMeta{
 				Publisher: "DK Children",
-				Edition:   1,
+				Edition:   Edition{Qualifier: "Workbook"},
 				PubDate:   time.Date(2017, 3, 7, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -87,7 +84,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "DK Publishing (Dorling Kindersley); Workbook edition (7 Mar. 2017)",
 			meta: BookPublishMeta{
 				Publisher: "DK Publishing (Dorling Kindersley)",
-				Edition:   1,
+				Edition:   Edition{Qualifier: "Workbook"},
 				PubDate:   time.Date(2017, 3, 7, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -95,7 +92,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "Esri Press; Fourth edition (December 28, 2021)",
 			meta: BookPublishMeta{
 				Publisher: "Esri Press",
-				Edition:   4,
+				Edition:   Edition{Number: 4},
 				PubDate:   time.Date(2021, 12, 28, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -103,7 +100,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "Esri Press; Fourth edition (10 Feb. 2022)",
 			meta: BookPublishMeta{
 				Publisher: "Esri Press",
-				Edition:   4,
+				Edition:   Edition{Number: 4},
 				PubDate:   time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC),
 			},
 		},
@@ -111,7 +108,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "Springer; 2nd ed. 2023 edition",
 			meta: BookPublishMeta{
 				Publisher: "Springer",
-				Edition:   2,
+				Edition:   Edition{Number: 2, Year: 2023},
 			},
 			shouldReturnError: true,
 		},
@@ -119,7 +116,6 @@ func TestParsePublisherString(t *testing.T) {
 			input: "Packt Publishing",
 			meta: BookPublishMeta{
 				Publisher: "Packt Publishing",
-				Edition:   1,
 			},
 			shouldReturnError: true,
 		},
@@ -127,7 +123,7 @@ func TestParsePublisherString(t *testing.T) {
 			input: "Unknown; 2nd edition (Unknown 15, 2021)",
 			meta: BookPublishMeta{
 				Publisher: "Unknown",
-				Edition:   2,
+				Edition:   Edition{Number: 2},
 			},
 			shouldReturnError: true,
 		},
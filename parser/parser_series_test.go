@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestParseSeriesString(t *testing.T) {
+	tests := []struct {
+		input             string
+		series            BookSeries
+		shouldReturnError bool
+	}{
+		{
+			input:  "Book 3 of 5: The Pragmatic Programmers",
+			series: BookSeries{Name: "The Pragmatic Programmers", Volume: 3},
+		},
+		{
+			input:  "Book 12 of 40+: For Dummies",
+			series: BookSeries{Name: "For Dummies", Volume: 12},
+		},
+		{
+			input:  "Part of: Addison-Wesley Signature Series (Fowler) (22 books)",
+			series: BookSeries{Name: "Addison-Wesley Signature Series (Fowler)"},
+		},
+		{
+			input:  "Buch 2 von 4: Rheinwerk Computing",
+			series: BookSeries{Name: "Rheinwerk Computing", Volume: 2},
+		},
+		{
+			input:  "Tome 1 sur 3 : Les Guides",
+			series: BookSeries{Name: "Les Guides", Volume: 1},
+		},
+		{
+			input:  "",
+			series: BookSeries{},
+		},
+		{
+			input:             "Kindle Edition",
+			shouldReturnError: true,
+		},
+	}
+
+	t.Log("Given the need to test series string parsing.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for series %+v\n", i, tt.input, tt.series)
+		series, err := ParseSeriesString(tt.input)
+		if tt.shouldReturnError {
+			if err == nil {
+				t.Errorf("\t\t%s\tShould get an error for the series string %q", failed, tt.input)
+			} else {
+				t.Logf("\t\t%s\tShould get an error for the invalid series string.", succeed)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("\t\t%s\tShould be able to parse the series string: %v", failed, err)
+		} else if series != tt.series {
+			t.Errorf("\t\t%s\tShould get a %+v series: %+v", failed, tt.series, series)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct series.", succeed)
+		}
+	}
+}
+
+func TestParseTitleSeries(t *testing.T) {
+	tests := []struct {
+		input  string
+		series BookSeries
+	}{
+		{
+			input:  "Advanced Programming in the UNIX Environment (Addison-Wesley Professional Computing Series)",
+			series: BookSeries{Name: "Addison-Wesley Professional Computing Series"},
+		},
+		{
+			input:  "Blockchain Technology III (Computer Science, Technology) (Blockchain Technology, 3)",
+			series: BookSeries{Name: "Blockchain Technology", Volume: 3},
+		},
+		{
+			input:  "Head First Java (Head First, Book 2)",
+			series: BookSeries{Name: "Head First", Volume: 2},
+		},
+		{
+			input:  "Introduction to Graph Neural Networks (Synthesis Lectures on AI and Machine Learning)",
+			series: BookSeries{},
+		},
+		{
+			input:  "Kubernetes in Action",
+			series: BookSeries{},
+		},
+	}
+
+	t.Log("Given the need to test title series parsing.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for series %+v\n", i, tt.input, tt.series)
+		series := ParseTitleSeries(tt.input)
+		if series != tt.series {
+			t.Errorf("\t\t%s\tShould get a %+v series: %+v", failed, tt.series, series)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct series.", succeed)
+		}
+	}
+}
@@ -63,7 +63,17 @@ func TestParseTitleString(t *testing.T) {
 		{
 			input:    "Blockchain Technology III (Computer Science, Technology) (Blockchain Technology, 3)",
 			title:    "Blockchain Technology III",
-			subtitle: "(Computer Science, Technology) (Blockchain Technology, 3)",
+			subtitle: "(Computer Science, Technology)",
+		},
+		{
+			input:    "Advanced Programming in the UNIX Environment (Addison-Wesley Professional Computing Series)",
+			title:    "Advanced Programming in the UNIX Environment",
+			subtitle: "",
+		},
+		{
+			input:    "Design Patterns: Elements of Reusable Object-Oriented Software (Addison-Wesley Professional Computing Series)",
+			title:    "Design Patterns",
+			subtitle: "Elements of Reusable Object-Oriented Software",
 		},
 	}
 
@@ -0,0 +1,316 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// The publisher rule priorities, a rule with a higher priority wins over the other matching rules
+	publisherRulePriorityPlain   = 0
+	publisherRulePriorityDefault = 10
+	// The ordinal word editions, like: 'Fourth edition', are matched by the default rules too, but without the edition
+	publisherRulePriorityOrdinal = 20
+)
+
+var (
+	// The English date layouts of the US storefront, like: 'November 5, 2020'
+	usPublisherDateLayouts = []string{dateLayout01}
+	// The English date layouts of the EU storefronts, like: '17 May 2021' / '1 Oct. 2020'
+	euPublisherDateLayouts = []string{dateLayout02, dateLayout03}
+
+	publisherRuleSet   = mustNewPublisherRuleSet(DefaultPublisherRules())
+	publisherRuleSetMu sync.RWMutex
+)
+
+// PublisherRule is a publisher string parsing rule: the pattern, and the indexes of its capture groups, which hold
+// the publisher name, the edition (like: '3rd', or 'Fourth') and the publication date (0 - the part is not captured).
+// The date is parsed with the given layouts, or with ParsePartialDateString if there are none.
+// If several rules match a publisher string, the one with the highest priority wins, the rules with the same
+// priority are taken in the rule set order.
+type PublisherRule struct {
+	Name        string   `json:"name" yaml:"name"`
+	Pattern     string   `json:"pattern" yaml:"pattern"`
+	Publisher   int      `json:"publisher" yaml:"publisher"`
+	Edition     int      `json:"edition" yaml:"edition"`
+	Date        int      `json:"date" yaml:"date"`
+	DateLayouts []string `json:"dateLayouts" yaml:"dateLayouts"`
+	Priority    int      `json:"priority" yaml:"priority"`
+}
+
+// PublisherParseResult is the publisher metadata with the parsing trace: the name of the winning rule, and the names
+// of all matched rules. The result is ambiguous, if another rule with the same priority matched too.
+type PublisherParseResult struct {
+	Meta      BookPublishMeta
+	Rule      string
+	Matched   []string
+	Ambiguous bool
+}
+
+// PublisherRuleSet is an ordered set of the compiled publisher rules.
+type PublisherRuleSet struct {
+	rules []compiledPublisherRule
+}
+
+type compiledPublisherRule struct {
+	PublisherRule
+	regex *regexp.Regexp
+}
+
+type publisherRuleFile struct {
+	Rules []PublisherRule `json:"rules" yaml:"rules"`
+}
+
+// DefaultPublisherRules returns the built-in publisher rules of the English storefronts.
+// The 'parser/rules/publisher.yaml' file has the same rules, they are kept in sync by TestLoadPublisherRules.
+func DefaultPublisherRules() []PublisherRule {
+	return []PublisherRule{
+		// Apress; 1st ed. edition (November 1, 2020) / Wiley; 1st edition (October 16, 2017)
+		{
+			Name:        "us-edition",
+			Pattern:     `(^[A-Z][^;]+); ((\d+)(st|nd|rd|th))?([^(]+)\((\w+ \d+, \d+)\)`,
+			Publisher:   1,
+			Edition:     2,
+			Date:        6,
+			DateLayouts: usPublisherDateLayouts,
+			Priority:    publisherRulePriorityDefault,
+		},
+		// No Starch Press (November 5, 2020)
+		{
+			Name:        "us-date",
+			Pattern:     `(^[A-Z][^(;]+) \((\w+ \d+, \d+)\)`,
+			Publisher:   1,
+			Date:        2,
+			DateLayouts: usPublisherDateLayouts,
+			Priority:    publisherRulePriorityDefault,
+		},
+		// Esri Press; Fourth edition (December 28, 2021) / Esri Press; Fourth Bilingual edition (December 28, 2021)
+		{
+			Name:        "us-ordinal-edition",
+			Pattern:     `(?i)(^[A-Z][^(;]+); ([a-z-A-Z]+(st|nd|rd|th)) ?\w* edition \((\w+ \d+, \d+)\)`,
+			Publisher:   1,
+			Edition:     2,
+			Date:        4,
+			DateLayouts: usPublisherDateLayouts,
+			Priority:    publisherRulePriorityOrdinal,
+		},
+		// Packt Publishing; 3rd edition (17 May 2021)
+		{
+			Name:        "eu-edition",
+			Pattern:     `(^[A-Z][^;]+); ((\d+)(st|nd|rd|th))?([^(]+)\((\d+ \w+\.? \d+)\)`,
+			Publisher:   1,
+			Edition:     2,
+			Date:        6,
+			DateLayouts: euPublisherDateLayouts,
+			Priority:    publisherRulePriorityDefault,
+		},
+		// No Starch Press (1 Oct. 2020)
+		{
+			Name:        "eu-date",
+			Pattern:     `(^[A-Z][^(;]+) \((\d+ \w+\.? \d+)\)`,
+			Publisher:   1,
+			Date:        2,
+			DateLayouts: euPublisherDateLayouts,
+			Priority:    publisherRulePriorityDefault,
+		},
+		// Esri Press; Fourth edition (10 Feb. 2022) / Esri Press; Fourth Bilingual edition (10 Feb. 2022)
+		{
+			Name:        "eu-ordinal-edition",
+			Pattern:     `(?i)(^[A-Z][^(;]+); ([a-z-A-Z]+(st|nd|rd|th)) ?\w* edition \((\d+ \w+\.? \d+)\)`,
+			Publisher:   1,
+			Edition:     2,
+			Date:        4,
+			DateLayouts: euPublisherDateLayouts,
+			Priority:    publisherRulePriorityOrdinal,
+		},
+		// Springer; 2nd ed. 2023 edition
+		{
+			Name:      "year-edition",
+			Pattern:   `(^[A-Z][^;]+); ((\d+)(st|nd|rd|th))? (ed\. \d+ edition)$`,
+			Publisher: 1,
+			Edition:   2,
+			Priority:  publisherRulePriorityDefault,
+		},
+		// Packt Publishing
+		{
+			Name:      "plain",
+			Pattern:   `(^[A-Z][^(;]+)$`,
+			Publisher: 1,
+			Priority:  publisherRulePriorityPlain,
+		},
+	}
+}
+
+// NewPublisherRuleSet compiles the publisher rules. Every rule should have a unique name, a valid pattern,
+// and the capture group indexes within the pattern groups. The publisher name capture is required.
+func NewPublisherRuleSet(rules []PublisherRule) (*PublisherRuleSet, error) {
+	ruleSet := PublisherRuleSet{rules: make([]compiledPublisherRule, 0, len(rules))}
+	names := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if rule.Name == "" || names[rule.Name] {
+			return nil, fmt.Errorf("the publisher rule name %q is empty or not unique", rule.Name)
+		}
+		names[rule.Name] = true
+		regex, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("can not compile the publisher rule %q pattern: %w", rule.Name, err)
+		}
+		groups := regex.NumSubexp()
+		if rule.Publisher < 1 || rule.Publisher > groups || rule.Edition < 0 || rule.Edition > groups ||
+			rule.Date < 0 || rule.Date > groups {
+			return nil, fmt.Errorf("the publisher rule %q captures are out of the %d pattern groups", rule.Name,
+				groups)
+		}
+		ruleSet.rules = append(ruleSet.rules, compiledPublisherRule{PublisherRule: rule, regex: regex})
+	}
+	sort.SliceStable(ruleSet.rules, func(i, j int) bool {
+		return ruleSet.rules[i].Priority > ruleSet.rules[j].Priority
+	})
+
+	return &ruleSet, nil
+}
+
+func mustNewPublisherRuleSet(rules []PublisherRule) *PublisherRuleSet {
+	ruleSet, err := NewPublisherRuleSet(rules)
+	if err != nil {
+		panic(err)
+	}
+
+	return ruleSet
+}
+
+// LoadPublisherRules loads the publisher rules from a YAML (.yaml, .yml) or JSON (.json) file, like:
+// 'parser/rules/publisher.yaml'. The rules are checked by NewPublisherRuleSet.
+func LoadPublisherRules(path string) ([]PublisherRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can not read publisher rules file: %w", err)
+	}
+
+	var ruleFile publisherRuleFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &ruleFile)
+	case ".json":
+		err = json.Unmarshal(data, &ruleFile)
+	default:
+		return nil, fmt.Errorf("unsupported publisher rules file format: %q", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can not decode publisher rules file %q: %w", path, err)
+	}
+	if _, err := NewPublisherRuleSet(ruleFile.Rules); err != nil {
+		return nil, fmt.Errorf("invalid publisher rules file %q: %w", path, err)
+	}
+
+	return ruleFile.Rules, nil
+}
+
+// ExtendPublisherRules adds the rules to the built-in ones, used by ParsePublisherString. A rule with the name
+// of a built-in rule replaces it. It should be called on start, before any publisher string is parsed.
+func ExtendPublisherRules(rules []PublisherRule) error {
+	extended := make([]PublisherRule, 0, len(rules))
+	replaced := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		replaced[rule.Name] = true
+	}
+	for _, rule := range DefaultPublisherRules() {
+		if !replaced[rule.Name] {
+			extended = append(extended, rule)
+		}
+	}
+	ruleSet, err := NewPublisherRuleSet(append(extended, rules...))
+	if err != nil {
+		return err
+	}
+
+	publisherRuleSetMu.Lock()
+	defer publisherRuleSetMu.Unlock()
+	publisherRuleSet = ruleSet
+
+	return nil
+}
+
+// TracePublisherString parses a book publisher string with the current rule set, see PublisherRuleSet.Parse.
+func TracePublisherString(publisherString string) (PublisherParseResult, error) {
+	publisherRuleSetMu.RLock()
+	ruleSet := publisherRuleSet
+	publisherRuleSetMu.RUnlock()
+
+	return ruleSet.Parse(publisherString)
+}
+
+// Parse parses a book publisher string, and returns the publisher metadata of the winning rule, with the trace.
+// The edition qualifier and the year-style edition, like: 'Workbook edition', are taken from the whole edition part.
+// The metadata without the publication date is returned with an error, if the matched date can not be parsed.
+func (rs *PublisherRuleSet) Parse(publisherString string) (PublisherParseResult, error) {
+	var result PublisherParseResult
+	var winner compiledPublisherRule
+	var subMatch []string
+	for _, rule := range rs.rules {
+		ruleSubMatch := rule.regex.FindStringSubmatch(publisherString)
+		if ruleSubMatch == nil || strings.TrimSpace(ruleSubMatch[rule.Publisher]) == "" {
+			continue
+		}
+		result.Matched = append(result.Matched, rule.Name)
+		if subMatch == nil {
+			winner, subMatch = rule, ruleSubMatch
+			result.Rule = rule.Name
+		} else if rule.Priority == winner.Priority {
+			result.Ambiguous = true
+		}
+	}
+	if subMatch == nil {
+		return result, fmt.Errorf("the publisher string '%s' can not be parsed", publisherString)
+	}
+
+	result.Meta.Publisher = strings.TrimSpace(subMatch[winner.Publisher])
+	var edition int
+	if winner.Edition > 0 {
+		edition, _ = parseEditionNumber(strings.TrimSpace(subMatch[winner.Edition]))
+	}
+	result.Meta.Edition = NewEdition(edition)
+	if editionSubMatch := pubEditionRegexp.FindStringSubmatch(publisherString); editionSubMatch != nil {
+		editionPart, _ := ParseEdition(editionSubMatch[1])
+		result.Meta.Edition = result.Meta.Edition.Merge(editionPart)
+	}
+
+	var dateString string
+	if winner.Date > 0 {
+		dateString = strings.TrimSpace(subMatch[winner.Date])
+	}
+	date, precision, err := winner.parseDate(dateString)
+	if err != nil {
+		return result, fmt.Errorf("can not get publication date: %w", err)
+	}
+	result.Meta.PubDate, result.Meta.PubDatePrecision = date, precision
+
+	return result, nil
+}
+
+// parseDate parses the captured date with the rule date layouts.
+func (r compiledPublisherRule) parseDate(dateString string) (time.Time, DatePrecision, error) {
+	if r.Date == 0 {
+		return time.Time{}, DatePrecisionDay, fmt.Errorf("the %q rule does not capture the date", r.Name)
+	}
+	if len(r.DateLayouts) == 0 {
+		return ParsePartialDateString(dateString, LanguageEnglish)
+	}
+	dateString = normalizeMonthAbbreviation(dateString)
+	for _, layout := range r.DateLayouts {
+		if date, err := time.Parse(layout, dateString); err == nil {
+			return date, DatePrecisionDay, nil
+		}
+	}
+
+	return time.Time{}, DatePrecisionDay, fmt.Errorf("the date string '%s' does not match the %q rule layouts",
+		dateString, r.Name)
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Book 3 of 5: The Pragmatic Programmers / Buch 3 von 5: Rheinwerk Computing / Tome 3 sur 5 : Les Guides
+	seriesVolumeRegex = regexp.MustCompile(`(?i)^(?:Book|Buch|Band|Tome|Livre)\s+(\d+)\s+(?:of|von|sur)\s+\d+\+?\s*:\s*(.+)$`)
+	// Part of: Addison-Wesley Signature Series (Fowler) (22 books) / Teil von: ... / Fait partie de : ...
+	seriesPartOfRegex = regexp.MustCompile(`(?i)^(?:Part of|Teil von|Fait partie de)\s*:\s*(.+?)(?:\s*\(\d+\s+\p{L}+\))?$`)
+	// (Addison-Wesley Professional Computing Series) / (Blockchain Technology, 3) / (Head First, Book 2)
+	titleSeriesRegex = regexp.MustCompile(`\s*\(([^()]*\bSeries\b[^()]*|[^()]+?,\s*(?:Book\s+|Vol\.?\s*|Volume\s+)?\d+)\)\s*$`)
+	// Addison-Wesley Professional Computing Series, 21 / Head First, Book 2
+	seriesNameVolumeRegex = regexp.MustCompile(`^(.+?),\s*(?:Book\s+|Vol\.?\s*|Volume\s+)?(\d+)$`)
+)
+
+// ParseSeriesString parses a book series string, like 'Book 3 of 5: The Pragmatic Programmers',
+// and returns the series name and the book volume number in the series.
+func ParseSeriesString(seriesString string) (BookSeries, error) {
+	seriesString = strings.TrimSpace(seriesString)
+	if seriesString == "" {
+		return BookSeries{}, nil
+	}
+
+	if subMatch := seriesVolumeRegex.FindStringSubmatch(seriesString); subMatch != nil {
+		volume, err := strconv.ParseUint(subMatch[1], 10, 16)
+		if err != nil {
+			return BookSeries{}, fmt.Errorf("can not parse the series volume '%s': %w", subMatch[1], err)
+		}
+		return BookSeries{Name: strings.TrimSpace(subMatch[2]), Volume: uint16(volume)}, nil
+	}
+	if subMatch := seriesPartOfRegex.FindStringSubmatch(seriesString); subMatch != nil {
+		return BookSeries{Name: strings.TrimSpace(subMatch[1])}, nil
+	}
+
+	return BookSeries{}, fmt.Errorf("the series string '%s' can not be parsed", seriesString)
+}
+
+// ParseTitleSeries extracts a book series from the trailing title parenthetical, like
+// '(Addison-Wesley Professional Computing Series)' or '(Blockchain Technology, 3)'.
+func ParseTitleSeries(titleString string) BookSeries {
+	subMatch := titleSeriesRegex.FindStringSubmatch(titleString)
+	if subMatch == nil {
+		return BookSeries{}
+	}
+
+	seriesString := strings.TrimSpace(subMatch[1])
+	if nameSubMatch := seriesNameVolumeRegex.FindStringSubmatch(seriesString); nameSubMatch != nil {
+		volume, err := strconv.ParseUint(nameSubMatch[2], 10, 16)
+		if err == nil {
+			return BookSeries{Name: strings.TrimSpace(nameSubMatch[1]), Volume: uint16(volume)}
+		}
+	}
+
+	return BookSeries{Name: seriesString}
+}
+
+// cutTitleSeries removes the trailing series parenthetical from the title string.
+func cutTitleSeries(titleString string) string {
+	return titleSeriesRegex.ReplaceAllString(titleString, "")
+}
@@ -1,21 +1,27 @@
 package scrapper
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/gocolly/colly/v2"
-	cookiejar "github.com/juju/persistent-cookiejar"
 	"github.com/sdreger/lib-file-processor-go/domain/book"
 	"github.com/sdreger/lib-file-processor-go/domain/publisher"
+	"github.com/sdreger/lib-file-processor-go/isbn"
 	"github.com/sdreger/lib-file-processor-go/parser"
 	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
 const (
-	defaultBasePath = "https://www.amazon.com/dp/"
-	//defaultBasePath = "https://www.amazon.co.uk/dp/"
+	acceptLanguageContextKey = "acceptLanguage"
 
 	//userAgentSafari = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.3 Safari/605.1.15"
 	//userAgentEdge = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/106.0.0.0 Safari/537.36 Edg/106.0.1370.52"
@@ -42,55 +48,110 @@ const (
 	bookAuthorsSelector     = `div[id=bylineInfo]>span.author>span.a-declarative>a,div[id=bylineInfo]>span.author>a`
 	bookDetailsSelector     = `div[id=detailBullets_feature_div]>ul>li`
 	bookCarouserSelector    = `li.rpi-carousel-attribute-card>div.rpi-attribute-content`
+	bookSeriesSelector      = `div[id=seriesBulletWidget_feature_div] a`
+	bookFormatsSelector     = `div[id=tmmSwatches] li a`
 	bookISBNBlockSelector   = `div[id=isbn_feature_div]>div.a-section>div.a-row, div[id=printEditionIsbn_feature_div]>div.a-section>div.a-row`
-	bookCoverURLSelector    = `img[id=imgBlkFront], img[id=ebooksImgBlkFront], img[id=landingImage]`
 )
 
+// printEditionKeys are the format links, followed to get the print edition identifiers, in the order of preference
+var printEditionKeys = []string{paperbackKey, hardcoverKey}
+
+// bookLengthKeys are the details block keys with the book length, in the order of preference
+var bookLengthKeys = []string{pagesKey, paperbackKey, hardcoverKey, printLengthKey, printedAccessCodeKey}
+
+// productIDRegex extracts the ISBN10/ASIN from a product link, like: '/Test-Title/dp/1234567890/ref=tmm_pap_swatch_0'
+var productIDRegex = regexp.MustCompile(`/(?:dp|gp/product)/([0-9A-Z]{10})(?:[/?#]|$)`)
+
+var bookCoverURLSelectors = []string{`img[id=imgBlkFront]`, `img[id=ebooksImgBlkFront]`, `img[id=landingImage]`}
+
+var skippedCategories = map[string]bool{
+	"Books":                        true,
+	"Kindle Store":                 true,
+	"Kindle eBooks":                true,
+	"New, Used & Rental Textbooks": true,
+	"Bücher":                       true,
+	"Fremdsprachige Bücher":        true,
+	"Kindle-Shop":                  true,
+	"Livres":                       true,
+	"Livres anglais et étrangers":  true,
+	"Boutique Kindle":              true,
+}
+
+// AmazonScrapper scrapes book data from Amazon product pages. Every GetBookData call uses its own copy
+// of the collector and its own scrapped data, so the calls are safe to run in parallel. The HTTP transport,
+// request throttling and the identity pool (user agents, proxies and their cookie jars) are shared between the calls.
 type AmazonScrapper struct {
-	basePath        string
-	cookieJar       *cookiejar.Jar
-	collector       *colly.Collector
-	scrappedRawData *scrappedRawData
-	logger          *log.Logger
+	storefronts []Storefront
+	selectors   SelectorSet
+	authorPages bool
+	retryPolicy retryPolicy
+	transport   *throttledTransport
+	// identities is guarded by the transport lock. The default identity is created on the first request,
+	// unless the identities are set by SetIdentities.
+	identities   *identityPool
+	proxyBackoff time.Duration
+	collector    *colly.Collector
+	logger       *log.Logger
 }
 
+// NewAmazonScrapper creates a scrapper for a single storefront, identified by its base path.
+// The default storefront is used for an empty or invalid base path.
 func NewAmazonScrapper(basePath string, logger *log.Logger) (*AmazonScrapper, error) {
 	if basePath == "" || !(strings.HasPrefix(basePath, "http") || strings.HasPrefix(basePath, "file")) {
-		basePath = defaultBasePath
+		return newAmazonScrapper([]Storefront{amazonStorefronts[defaultStorefrontCode]}, logger)
 	}
 
-	cookieJar, err := cookiejar.New(&cookiejar.Options{Filename: "cookie.db"})
+	return newAmazonScrapper([]Storefront{getBasePathStorefront(basePath)}, logger)
+}
+
+// NewAmazonStorefrontScrapper creates a scrapper, which tries the given storefronts (like: 'com', 'co.uk', 'de')
+// in order, until one of them returns full book data.
+func NewAmazonStorefrontScrapper(storefrontCodes []string, logger *log.Logger) (*AmazonScrapper, error) {
+	storefronts, err := getStorefronts(storefrontCodes)
 	if err != nil {
 		return nil, err
 	}
 
+	return newAmazonScrapper(storefronts, logger)
+}
+
+func newAmazonScrapper(storefronts []Storefront, logger *log.Logger) (*AmazonScrapper, error) {
 	collector := colly.NewCollector()
 	collector.AllowURLRevisit = true
-	//extensions.RandomUserAgent(collector)
 	collector.UserAgent = userAgentFirefox
-	collector.SetCookieJar(cookieJar)
-
-	scrappedRawData := newScrappedRawData()
-	initCallbacks(collector, &scrappedRawData, logger)
+	// The cookies are kept by the identityTransport, in the cookie jar of the request identity
+	collector.DisableCookies()
+	transport := &throttledTransport{minDelay: defaultMinRequestDelay}
+	collector.WithTransport(transport)
 
 	return &AmazonScrapper{
-		basePath:        basePath,
-		cookieJar:       cookieJar,
-		collector:       collector,
-		scrappedRawData: &scrappedRawData,
-		logger:          logger,
+		storefronts:  storefronts,
+		selectors:    DefaultSelectorSet(),
+		retryPolicy:  retryPolicy{defaultMaxRetries, defaultRetryDelay, defaultMaxRetryDelay},
+		transport:    transport,
+		proxyBackoff: defaultProxyBackoff,
+		collector:    collector,
+		logger:       logger,
 	}, nil
 }
 
-func initCallbacks(collector *colly.Collector, scrappedRawData *scrappedRawData, logger *log.Logger) {
+// initCallbacks registers the request and response callbacks, which set the request-specific response error.
+func initCallbacks(ctx context.Context, collector *colly.Collector, responseErr *error, logger *log.Logger) {
 
 	collector.OnRequest(func(request *colly.Request) {
+		if err := ctx.Err(); err != nil {
+			*responseErr = err
+			request.Abort()
+			return
+		}
 		logger.Printf("[INFO] - Visiting: %q, using 'User-Agent': %q", request.URL, request.Headers.Get("User-Agent"))
 		request.Headers.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8")
 		request.Headers.Set("Accept-Encoding", "gzip, deflate, br")
-		request.Headers.Set("Accept-Language", "en-US,en;q=0.5")
+		request.Headers.Set("Accept-Language", request.Ctx.Get(acceptLanguageContextKey))
 		request.Headers.Set("Connection", "keep-alive")
-		request.Headers.Set("Host", "www.amazon.com")
+		if request.URL.Host != "" {
+			request.Headers.Set("Host", request.URL.Host)
+		}
 		request.Headers.Set("Sec-Fetch-Dest", "document")
 		request.Headers.Set("Sec-Fetch-Mode", "navigate")
 		request.Headers.Set("Sec-Fetch-Site", "none")
@@ -99,198 +160,572 @@ func initCallbacks(collector *colly.Collector, scrappedRawData *scrappedRawData,
 		request.Headers.Set("Upgrade-Insecure-Requests", "1")
 	})
 
-	collector.OnHTML(bookTitleSelector, func(element *colly.HTMLElement) {
-		scrappedRawData.titleString = strings.TrimSpace(element.Text)
-	})
-
-	collector.OnHTML(bookSubtitleSelector, func(element *colly.HTMLElement) {
-		scrappedRawData.subtitleString = strings.TrimSpace(element.Text)
+	collector.OnResponse(func(response *colly.Response) {
+		*responseErr = classifyResponse(response.StatusCode, response.Body)
 	})
 
-	collector.OnHTML(bookDescriptionSelector, func(element *colly.HTMLElement) {
-		//element.DOM.Find("span.a-text-italic").RemoveClass("a-text-italic").AddClass("text-italic")
-		//element.DOM.Find("span.a-text-bold").RemoveClass("a-text-bold").AddClass("text-bold")
-		html, _ := element.DOM.Html()
-		scrappedRawData.description = strings.ReplaceAll(strings.TrimSpace(html), "<p></p>", "")
-	})
-
-	collector.OnHTML(bookCategoriesSelector, func(element *colly.HTMLElement) {
-		text := strings.TrimSpace(element.Text)
-		if text == "Books" || text == "Kindle Store" || text == "Kindle eBooks" || text == "New, Used & Rental Textbooks" {
+	collector.OnError(func(response *colly.Response, err error) {
+		if response == nil || response.StatusCode == 0 {
+			*responseErr = err
 			return
 		}
-		scrappedRawData.categories = append(scrappedRawData.categories, text)
+		*responseErr = classifyResponse(response.StatusCode, response.Body)
 	})
+}
 
-	collector.OnHTML(bookAuthorsSelector, func(element *colly.HTMLElement) {
-		if element.Attr("role") == "button" || element.Text == "" {
-			return
-		}
-		scrappedRawData.authors = append(scrappedRawData.authors, strings.TrimSpace(element.Text))
-	})
+// EnableSnapshotCache makes the scrapper serve fresh pages from the snapshot cache, and store fetched pages into it.
+// In the offline mode only the cached pages are used, regardless of their age.
+func (s *AmazonScrapper) EnableSnapshotCache(cache *SnapshotCache, offline bool) {
+	s.collector.WithTransport(&snapshotTransport{cache: cache, transport: s.transport, offline: offline})
+}
 
-	collector.OnHTML(bookDetailsSelector, func(element *colly.HTMLElement) {
-		liText := element.Text
-		liParts := strings.Split(liText, ":")
-		if len(liParts) < 2 {
-			return
-		}
-		key := strings.TrimSpace(strings.Map(removeNonPrintable, liParts[0]))
-		value := strings.TrimSpace(strings.Map(removeNonPrintable, liParts[1]))
-		scrappedRawData.detailsBlock[key] = value
-	})
+// SetSelectorSet replaces the built-in CSS selectors, used to extract the book data from the product pages.
+func (s *AmazonScrapper) SetSelectorSet(selectors SelectorSet) {
+	s.selectors = selectors
+	s.logger.Printf("[INFO] - Using the selector set version: %q", selectors.Version)
+}
 
-	collector.OnHTML(bookCarouserSelector, func(element *colly.HTMLElement) {
-		key := strings.TrimSpace(strings.Map(removeNonPrintable, element.ChildText("div.rpi-attribute-label")))
-		value := strings.TrimSpace(strings.Map(removeNonPrintable, element.ChildText("div.rpi-attribute-value")))
-		if key != "" {
-			scrappedRawData.detailsCarousel[key] = value
-		}
-	})
+// SetFetchAuthorPages enables visiting the author pages, linked from the product page,
+// to get the author bio, photo URL and the Amazon author ID.
+func (s *AmazonScrapper) SetFetchAuthorPages(enabled bool) {
+	s.authorPages = enabled
+}
+
+// SetRetryPolicy sets the number of retries for blocked and rate limited requests,
+// and the initial backoff delay, which is doubled for each next retry.
+func (s *AmazonScrapper) SetRetryPolicy(maxRetries int, retryDelay time.Duration) {
+	s.retryPolicy.maxRetries = maxRetries
+	s.retryPolicy.baseDelay = retryDelay
+}
+
+// SetMinRequestDelay sets the minimum delay between consecutive requests to the storefronts.
+// Pages served from the snapshot cache are not delayed.
+func (s *AmazonScrapper) SetMinRequestDelay(delay time.Duration) {
+	s.transport.mu.Lock()
+	defer s.transport.mu.Unlock()
+	s.transport.minDelay = delay
+}
+
+// SetIdentities replaces the default identity (a single user agent and a direct connection) with the identities
+// for the given user agents and HTTP/SOCKS5 proxies, rotated per request. Every identity has its own persistent
+// cookie jar in the cookie folder. The default user agent and cookie folder are used, if they are empty.
+func (s *AmazonScrapper) SetIdentities(userAgents, proxies []string, cookieDir string) error {
+	if len(userAgents) == 0 {
+		userAgents = []string{userAgentFirefox}
+	}
+	if cookieDir == "" {
+		cookieDir = defaultCookieDir
+	}
+	identities, err := newIdentityPool(userAgents, proxies, cookieDir)
+	if err != nil {
+		return err
+	}
+
+	s.transport.mu.Lock()
+	defer s.transport.mu.Unlock()
+	s.setIdentities(identities)
+	s.logger.Printf("[INFO] - Using %d scrapper identities", len(identities.identities))
+
+	return nil
+}
 
-	collector.OnHTML(bookISBNBlockSelector, func(element *colly.HTMLElement) {
-		element.ChildText("span")
-		var key, value string
-		for i, node := range element.DOM.Children().Nodes {
-			if i == 0 {
-				key = strings.Trim(node.LastChild.Data, ":")
-			}
-			value = strings.TrimSpace(node.LastChild.Data)
+// SetProxyBackoff sets the initial back-off delay of a blocked identity (its proxy), which is doubled
+// for each next block in a row, up to an hour.
+func (s *AmazonScrapper) SetProxyBackoff(backoff time.Duration) {
+	s.transport.mu.Lock()
+	defer s.transport.mu.Unlock()
+	s.proxyBackoff = backoff
+	if s.identities != nil {
+		s.identities.setBackoff(backoff)
+	}
+}
+
+// Close saves the identity cookie jars, if any request has been made.
+func (s *AmazonScrapper) Close() error {
+	s.transport.mu.Lock()
+	identities := s.identities
+	s.transport.mu.Unlock()
+	if identities == nil {
+		return nil
+	}
+
+	return identities.save()
+}
+
+// getIdentities returns the identity pool, the default identity is created on the first call,
+// if the identities are not set.
+func (s *AmazonScrapper) getIdentities() (*identityPool, error) {
+	s.transport.mu.Lock()
+	defer s.transport.mu.Unlock()
+	if s.identities != nil {
+		return s.identities, nil
+	}
+
+	identities, err := newIdentityPool([]string{userAgentFirefox}, nil, defaultCookieDir)
+	if err != nil {
+		return nil, err
+	}
+	s.setIdentities(identities)
+
+	return identities, nil
+}
+
+// setIdentities makes the transport send the requests through the identity pool, the transport lock should be held.
+func (s *AmazonScrapper) setIdentities(identities *identityPool) {
+	identities.setBackoff(s.proxyBackoff)
+	s.identities = identities
+	s.transport.transport = &identityTransport{pool: identities}
+}
+
+// request requests the page using the next identity of the pool. The identity is backed off,
+// if the request is blocked or rate limited, so the retries are made with the other identities.
+func (s *AmazonScrapper) request(collector *colly.Collector, pageURL string, requestCtx *colly.Context,
+	responseErr *error) error {
+	identities, err := s.getIdentities()
+	if err != nil {
+		return err
+	}
+	requestIdentity := identities.acquire()
+	headers := http.Header{
+		"User-Agent":   []string{requestIdentity.userAgent},
+		identityHeader: []string{strconv.Itoa(requestIdentity.id)},
+	}
+	err = collector.Request(http.MethodGet, pageURL, nil, requestCtx, headers)
+	switch {
+	case errors.Is(*responseErr, ErrBlocked), errors.Is(*responseErr, ErrRateLimited):
+		delay := identities.reportBlocked(requestIdentity)
+		s.logger.Printf("[WARN] - The %s is blocked, backing off for %s", requestIdentity, delay)
+	case *responseErr == nil && err == nil:
+		identities.reportSuccess(requestIdentity)
+	}
+
+	return err
+}
+
+// GetBookData tries the configured storefronts in order, and returns the first full book data.
+// If none of the storefronts has returned full data, the first successfully scrapped data is returned.
+func (s *AmazonScrapper) GetBookData(ctx context.Context, bookID string) (book.ParsedData, error) {
+	bookID, err := amazonLookupID(bookID)
+	if err != nil {
+		return book.ParsedData{}, err
+	}
+	var partialData *book.ParsedData
+	var lastErr error
+	for _, storefront := range s.storefronts {
+		bookData, err := s.getStorefrontBookDataWithRetry(ctx, storefront, bookID)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return book.ParsedData{}, ctxErr
 		}
-		if key == ISBN10Key {
-			scrappedRawData.ISBN10String = value
+		if err != nil {
+			s.logger.Printf("[WARN] - Can not get book data from the %q storefront: %v", storefront.Code, err)
+			lastErr = err
+			continue
 		}
-		if key == ISBN13Key {
-			scrappedRawData.ISBN13String = value
+		if isFullBookData(bookData) {
+			return bookData, nil
 		}
-	})
+		if partialData == nil {
+			partialData = &bookData
+		}
+	}
 
-	collector.OnHTML(bookCoverURLSelector, func(element *colly.HTMLElement) {
-		scrappedRawData.coverURL = element.Attr("src")
-	})
+	if partialData != nil {
+		return *partialData, nil
+	}
+
+	return book.ParsedData{}, lastErr
 }
 
-func (s *AmazonScrapper) Close() error {
-	return s.cookieJar.Save()
+// getStorefrontBookDataWithRetry retries blocked and rate limited requests with the exponential backoff.
+func (s *AmazonScrapper) getStorefrontBookDataWithRetry(ctx context.Context, storefront Storefront,
+	bookID string) (book.ParsedData, error) {
+	for attempt := 0; ; attempt++ {
+		bookData, err := s.getStorefrontBookData(ctx, storefront, bookID)
+		if err == nil || !isRetryable(err) || attempt >= s.retryPolicy.maxRetries {
+			return bookData, err
+		}
+		delay := s.retryPolicy.delay(attempt)
+		s.logger.Printf("[WARN] - %v, retrying in %s", err, delay)
+		select {
+		case <-ctx.Done():
+			return book.ParsedData{}, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// getStorefrontBookData scrapes the storefront page in a separate goroutine, and returns as soon as
+// the context is cancelled. The collector does not support cancellation of an in-flight request,
+// so the abandoned request completes in the background, and its result is discarded.
+func (s *AmazonScrapper) getStorefrontBookData(ctx context.Context, storefront Storefront,
+	bookID string) (book.ParsedData, error) {
+	type scrapeResult struct {
+		bookData book.ParsedData
+		err      error
+	}
+	resultChan := make(chan scrapeResult, 1)
+	go func() {
+		bookData, err := s.scrapeStorefrontPage(ctx, storefront, bookID)
+		resultChan <- scrapeResult{bookData: bookData, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return book.ParsedData{}, ctx.Err()
+	case result := <-resultChan:
+		return result.bookData, result.err
+	}
 }
 
-func (s *AmazonScrapper) GetBookData(bookID string) (book.ParsedData, error) {
-	s.scrappedRawData.init()
-	err := s.collector.Visit(s.basePath + bookID)
+// scrapeStorefrontPage scrapes the product page. If the page has no ISBNs (like Kindle editions),
+// the missing identifiers and the page count are taken from the linked print edition page.
+// The author profiles are taken from the author pages, if enabled.
+func (s *AmazonScrapper) scrapeStorefrontPage(ctx context.Context, storefront Storefront,
+	bookID string) (book.ParsedData, error) {
+	rawData, err := s.fetchRawData(ctx, storefront, bookID)
+	if err != nil {
+		return book.ParsedData{}, err
+	}
+	bookData, err := parseRawData(storefront, rawData, s.logger)
 	if err != nil {
 		return book.ParsedData{}, err
 	}
+	if bookData.ISBN10 == "" || bookData.ISBN13 == 0 {
+		s.enrichWithPrintEdition(ctx, storefront, bookID, rawData.formatLinks, &bookData)
+	}
+	if s.authorPages {
+		bookData.AuthorProfiles = s.fetchAuthorProfiles(ctx, storefront,
+			getAuthorProfiles(storefront, bookData.GetAuthorNames(), rawData.authorLinks))
+	}
+
+	return bookData, nil
+}
+
+// enrichWithPrintEdition fills the missing book identifiers from the linked print edition page.
+// The print edition errors are not fatal, the book data is kept as is.
+func (s *AmazonScrapper) enrichWithPrintEdition(ctx context.Context, storefront Storefront, bookID string,
+	formatLinks map[string]string, bookData *book.ParsedData) {
+	printEditionID := getPrintEditionID(storefront, formatLinks)
+	if printEditionID == "" || printEditionID == bookID {
+		return
+	}
+	s.logger.Printf("[INFO] - Following the print edition %q of the book %q", printEditionID, bookID)
+	printRawData, err := s.fetchRawData(ctx, storefront, printEditionID)
+	if err != nil {
+		s.logger.Printf("[WARN] - Can not get the print edition %q: %v", printEditionID, err)
+		return
+	}
+	printData, err := parseRawData(storefront, printRawData, s.logger)
+	if err != nil {
+		s.logger.Printf("[WARN] - Can not parse the print edition %q: %v", printEditionID, err)
+		return
+	}
+	mergePrintEditionData(bookData, printData, storefront.BasePath)
+}
+
+// fetchRawData requests the product page, and extracts the raw book data from it.
+func (s *AmazonScrapper) fetchRawData(ctx context.Context, storefront Storefront,
+	bookID string) (scrappedRawData, error) {
+	rawData := newScrappedRawData()
+	collector := s.collector.Clone()
+	initCallbacks(ctx, collector, &rawData.responseErr, s.logger)
+	collector.OnHTML("html", func(element *colly.HTMLElement) {
+		s.selectors.extractRawData(element.DOM, &rawData)
+	})
+
+	requestCtx := colly.NewContext()
+	requestCtx.Put(acceptLanguageContextKey, storefront.AcceptLanguage)
+	err := s.request(collector, storefront.BasePath+bookID, requestCtx, &rawData.responseErr)
+	if rawData.responseErr != nil {
+		return scrappedRawData{}, rawData.responseErr
+	}
+	if err != nil {
+		return scrappedRawData{}, err
+	}
+	if rawData.titleString == "" {
+		return scrappedRawData{}, fmt.Errorf("%w: empty product page", ErrNotFound)
+	}
+
+	return rawData, nil
+}
 
-	authors := s.scrappedRawData.authors
-	categories := s.scrappedRawData.categories
-	detailsBlock := s.scrappedRawData.detailsBlock
-	detailsCarousel := s.scrappedRawData.detailsCarousel
-	titleString := s.scrappedRawData.titleString
-	subtitleString := s.scrappedRawData.subtitleString
-	description := s.scrappedRawData.description
-	ISBN10String := s.scrappedRawData.ISBN10String
-	ISBN13String := s.scrappedRawData.ISBN13String
-	coverURL := s.scrappedRawData.coverURL
+// parseRawData parses the raw data, extracted from the storefront product page, into the book data.
+// The diagnostics record the selector, the raw value and the parsing rule of every field.
+func parseRawData(storefront Storefront, rawData scrappedRawData, logger *log.Logger) (book.ParsedData, error) {
+	contributors := rawData.contributors
+	categories := rawData.categories
+	detailsBlock := storefront.canonicalDetails(rawData.detailsBlock)
+	detailsCarousel := storefront.canonicalDetails(rawData.detailsCarousel)
+	titleString := rawData.titleString
+	subtitleString := rawData.subtitleString
+	seriesString := rawData.seriesString
+	description := rawData.description
+	ISBN10String := rawData.ISBN10String
+	ISBN13String := rawData.ISBN13String
+	coverURL := rawData.coverURL
+	var diagnostics book.Diagnostics
 
 	// -------------------- Book title / subtitle --------------------
 	title, subtitle := parser.ParseTitleString(titleString)
+	diagnostics.AddField(book.FieldDiagnostic{Field: FieldTitle, Selector: SelectorTitle, Raw: titleString,
+		Rule: "ParseTitleString"})
+	if subtitle != "" {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldSubtitle, Selector: SelectorTitle, Raw: titleString,
+			Rule: "ParseTitleString"})
+	}
+
+	// -------------------- Book series --------------------
+	series, err := parser.ParseSeriesString(seriesString)
+	if err != nil {
+		logger.Printf("[WARN] - %v", err)
+		diagnostics.AddWarning("%v", err)
+	}
+	if series.Name != "" {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldSeries, Selector: SelectorSeries, Raw: seriesString,
+			Rule: "ParseSeriesString"})
+	} else {
+		series = parser.ParseTitleSeries(titleString)
+		if series.Name != "" {
+			diagnostics.AddField(book.FieldDiagnostic{Field: FieldSeries, Selector: SelectorTitle, Raw: titleString,
+				Rule: "ParseTitleSeries", LowConfidence: true})
+		}
+	}
 
 	// -------------------- Book publisher metadata --------------------
-	publishMeta, err := parser.ParsePublisherString(detailsBlock[publisherKey])
+	publisherString := detailsBlock[publisherKey]
+	publishResult, err := parser.TraceLocalizedPublisherString(publisherString, storefront.Language)
+	publishMeta := publishResult.Meta
+	publisherLowConfidence := err != nil || publishResult.Ambiguous
+	publisherRule := fmt.Sprintf("ParseLocalizedPublisherString(%s)", publishResult.Rule)
 	if err != nil {
-		s.logger.Printf("[WARN] - %v", err)
+		logger.Printf("[WARN] - %v", err)
+		diagnostics.AddWarning("%v", err)
+	}
+	if publishResult.Ambiguous {
+		logger.Printf("[WARN] - the publisher string '%s' matches several rules: %v", publisherString,
+			publishResult.Matched)
+		diagnostics.AddWarning("the publisher string '%s' matches several rules: %v", publisherString,
+			publishResult.Matched)
+	}
+	if publishMeta.Publisher != "" {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldPublisher, Selector: keySelector(SelectorDetails,
+			publisherKey), Raw: publisherString, Rule: publisherRule + ", MapPublisherName",
+			LowConfidence: publisherLowConfidence})
 	}
 
 	if publishMeta.PubDate.IsZero() {
-		pubDate, err := parser.ParseDateString(detailsBlock[pubDateKey])
+		pubDate, precision, err := parser.ParsePartialDateString(detailsBlock[pubDateKey], storefront.Language)
 		if err != nil {
-			s.logger.Printf("[WARN] - %v", err)
+			logger.Printf("[WARN] - %v", err)
+			diagnostics.AddWarning("%v", err)
 		}
-		publishMeta.PubDate = pubDate
+		publishMeta.PubDate, publishMeta.PubDatePrecision = pubDate, precision
+		if !pubDate.IsZero() {
+			diagnostics.AddField(book.FieldDiagnostic{Field: FieldPubDate, Selector: keySelector(SelectorDetails,
+				pubDateKey), Raw: detailsBlock[pubDateKey], Rule: "ParsePartialDateString"})
+		}
+	} else {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldPubDate, Selector: keySelector(SelectorDetails,
+			publisherKey), Raw: publisherString, Rule: publisherRule,
+			LowConfidence: publisherLowConfidence})
 	}
 
 	// -------------------- Book ISBN10 --------------------
-	s.logger.Printf("ISBN10String: %s; ISBN10Key: %s; sourceISBNKey: %s", ISBN10String, detailsBlock[ISBN10Key], detailsBlock[sourceISBNKey])
+	logger.Printf("ISBN10String: %s; ISBN10Key: %s; sourceISBNKey: %s", ISBN10String, detailsBlock[ISBN10Key], detailsBlock[sourceISBNKey])
 	isbn10 := getISBN10(ISBN10String, detailsBlock[ISBN10Key], detailsBlock[sourceISBNKey])
+	if isbn10 != "" {
+		selector, raw := keySelector(SelectorISBNBlock, ISBN10Key), ISBN10String
+		if detailsBlock[ISBN10Key] != "" {
+			selector, raw = keySelector(SelectorDetails, ISBN10Key), detailsBlock[ISBN10Key]
+		}
+		if detailsBlock[sourceISBNKey] != "" {
+			selector, raw = keySelector(SelectorDetails, sourceISBNKey), detailsBlock[sourceISBNKey]
+		}
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldISBN10, Selector: selector, Raw: raw, Rule: "getISBN10"})
+	}
 
 	// -------------------- Book ISBN13 --------------------
 	isbn13, err := getISBN13(ISBN13String, detailsBlock[ISBN13Key])
 	if err != nil {
 		return book.ParsedData{}, fmt.Errorf("can not get ISBN13 value: %w", err)
 	}
+	if isbn13 != 0 {
+		selector, raw := keySelector(SelectorISBNBlock, ISBN13Key), ISBN13String
+		if detailsBlock[ISBN13Key] != "" {
+			selector, raw = keySelector(SelectorDetails, ISBN13Key), detailsBlock[ISBN13Key]
+		}
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldISBN13, Selector: selector, Raw: raw, Rule: "getISBN13"})
+	}
+
+	// -------------------- Book pages / language / edition --------------------
+	pagesKey := getBookLengthKey(detailsBlock)
+	if pagesKey != "" {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldPages, Selector: keySelector(SelectorDetails, pagesKey),
+			Raw: detailsBlock[pagesKey], Rule: "ParseLengthString"})
+	}
+	language := storefront.languageName(detailsBlock[languageKey])
+	if language != "" {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldLanguage, Selector: keySelector(SelectorDetails,
+			languageKey), Raw: detailsBlock[languageKey], Rule: "languageName"})
+	}
+	edition, editionSelector, editionRaw := getBookEdition(titleString, subtitleString, publishMeta.Edition)
+	if !edition.IsEmpty() {
+		if editionSelector == SelectorDetails {
+			editionSelector, editionRaw = keySelector(SelectorDetails, publisherKey), publisherString
+		}
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldEdition, Selector: editionSelector, Raw: editionRaw,
+			Rule: "ParseEdition"})
+	}
 
 	metadata := book.ParsedData{
-		Title:         title,
-		Subtitle:      subtitle,
-		Description:   description,
-		ISBN10:        isbn10,
-		ISBN13:        int64(isbn13),
-		ASIN:          detailsBlock[ASINKey],
-		Pages:         getBookLength(detailsBlock),
-		Language:      detailsBlock[languageKey],
-		PublisherURL:  "",
-		Publisher:     publisher.MapPublisherName(publishMeta.Publisher),
-		Edition:       getBookEdition(titleString, subtitleString, publishMeta.Edition),
-		PubDate:       publishMeta.PubDate,
-		Authors:       authors,
-		Categories:    categories,
-		Tags:          nil,
-		Formats:       nil,
-		BookFileSize:  0,
-		BookFileName:  "",
-		CoverFileName: "",
-		CoverURL:      coverURL,
-	}
-
-	enrichWithOptionalCarouselData(&metadata, detailsCarousel)
-	metadata.PublisherURL = getPublisherURL(s.basePath, metadata.ISBN10, metadata.ASIN)
+		Title:            title,
+		Subtitle:         subtitle,
+		ISBN10:           isbn10,
+		ISBN13:           int64(isbn13),
+		ASIN:             detailsBlock[ASINKey],
+		Pages:            getBookLength(detailsBlock),
+		Language:         language,
+		PublisherURL:     "",
+		Publisher:        publisher.MapPublisherName(publishMeta.Publisher),
+		Edition:          edition,
+		PubDate:          publishMeta.PubDate,
+		PubDatePrecision: publishMeta.PubDatePrecision,
+		Series:           series.Name,
+		SeriesVolume:     series.Volume,
+		Contributors:     contributors,
+		Categories:       categories,
+		Tags:             nil,
+		Formats:          nil,
+		BookFileSize:     0,
+		BookFileName:     "",
+		CoverFileName:    "",
+		CoverURL:         coverURL,
+		Diagnostics:      diagnostics,
+	}
+
+	metadata.SetDescription(description)
+	if metadata.Description != "" {
+		metadata.Diagnostics.AddField(book.FieldDiagnostic{Field: FieldDescription, Selector: SelectorDescription,
+			Raw: description, Rule: "SanitizeDescription"})
+	}
+	enrichWithOptionalCarouselData(&metadata, detailsCarousel, storefront.Language)
+	metadata.PublisherURL = getPublisherURL(storefront.BasePath, metadata.ISBN10, metadata.ASIN)
 	primaryBookId := metadata.GetPrimaryId()
 	metadata.CoverFileName = fmt.Sprint(primaryBookId, getCoverExtension(metadata.CoverURL))
 	metadata.BookFileName = metadata.GetBookFileName()
+	completeDiagnostics(&metadata, AmazonSourceName+"."+storefront.Code, map[string]string{
+		FieldASIN:       keySelector(SelectorDetails, ASINKey),
+		FieldAuthors:    SelectorAuthors,
+		FieldCategories: SelectorCategories,
+		FieldCoverURL:   SelectorCoverURL,
+	})
 
 	return metadata, nil
 }
 
-func enrichWithOptionalCarouselData(parsedData *book.ParsedData, detailsCarousel map[string]string) {
+// getPrintEditionID returns the ISBN10/ASIN of the paperback or hardcover edition, linked from the product page.
+func getPrintEditionID(storefront Storefront, formatLinks map[string]string) string {
+	links := storefront.canonicalDetails(formatLinks)
+	for _, key := range printEditionKeys {
+		if subMatch := productIDRegex.FindStringSubmatch(links[key]); subMatch != nil {
+			return subMatch[1]
+		}
+	}
+
+	return ""
+}
+
+// mergePrintEditionData fills the missing identifiers and the page count from the print edition data.
+// The ASIN of the original edition is kept, so all identifiers of the same work end up in a single record.
+func mergePrintEditionData(bookData *book.ParsedData, printData book.ParsedData, basePath string) {
+	printEditionField := func(field string, lowConfidence bool) {
+		diagnostic, _ := printData.Diagnostics.GetField(field)
+		diagnostic.Field = field
+		diagnostic.Selector = "print edition " + diagnostic.Selector
+		diagnostic.LowConfidence = diagnostic.LowConfidence || lowConfidence
+		bookData.Diagnostics.AddField(diagnostic)
+	}
+	if bookData.ISBN10 == "" && printData.ISBN10 != "" {
+		bookData.ISBN10 = printData.ISBN10
+		printEditionField(FieldISBN10, false)
+	}
+	if bookData.ISBN13 == 0 && printData.ISBN13 != 0 {
+		bookData.ISBN13 = printData.ISBN13
+		printEditionField(FieldISBN13, false)
+	}
+	if bookData.ASIN == "" && printData.ASIN != "" {
+		bookData.ASIN = printData.ASIN
+		printEditionField(FieldASIN, false)
+	}
+	// The print edition page count may differ from the original edition one
+	if bookData.Pages == 0 && printData.Pages != 0 {
+		bookData.Pages = printData.Pages
+		printEditionField(FieldPages, true)
+	}
+
+	bookData.PublisherURL = getPublisherURL(basePath, bookData.ISBN10, bookData.ASIN)
+	bookData.CoverFileName = fmt.Sprint(bookData.GetPrimaryId(), getCoverExtension(bookData.CoverURL))
+	bookData.BookFileName = bookData.GetBookFileName()
+	checkRequiredFields(bookData)
+}
+
+// isFullBookData checks if the scrapped book data has all the essential fields filled.
+func isFullBookData(bookData book.ParsedData) bool {
+	return len(missingRequiredFields(bookData)) == 0
+}
+
+// enrichWithOptionalCarouselData fills the missing publisher metadata from the details carousel.
+// The carousel values are fallbacks, so they are marked as low confidence ones.
+func enrichWithOptionalCarouselData(parsedData *book.ParsedData, detailsCarousel map[string]string, language string) {
 	if len(detailsCarousel) == 0 {
 		return
 	}
 	if parsedData.Publisher == "" {
 		parsedData.Publisher = publisher.MapPublisherName(detailsCarousel[publisherKey])
+		if parsedData.Publisher != "" {
+			parsedData.Diagnostics.AddField(book.FieldDiagnostic{Field: FieldPublisher,
+				Selector: keySelector(SelectorCarousel, publisherKey), Raw: detailsCarousel[publisherKey],
+				Rule: "MapPublisherName", LowConfidence: true})
+		}
 	}
-	if parsedData.Edition == 0 {
-		edition, err := parser.ParseEditionString(detailsCarousel[editionKey] + " Edition")
-		if err != nil {
-			parsedData.Edition = edition
-		} else {
-			parsedData.Edition = 1
+	if parsedData.Edition.IsEmpty() {
+		edition, err := parser.ParseEdition(detailsCarousel[editionKey] + " Edition")
+		rule := "ParseEdition"
+		if err != nil || edition.IsEmpty() {
+			edition, rule = parser.Edition{Number: 1}, "default edition"
 		}
+		parsedData.Edition = edition
+		parsedData.Diagnostics.AddField(book.FieldDiagnostic{Field: FieldEdition,
+			Selector: keySelector(SelectorCarousel, editionKey), Raw: detailsCarousel[editionKey], Rule: rule,
+			LowConfidence: true})
 	}
 	if parsedData.PubDate.IsZero() {
-		pubDate, err := parser.ParseDateString(detailsCarousel[pubDateKey])
+		pubDate, precision, err := parser.ParsePartialDateString(detailsCarousel[pubDateKey], language)
 		if err == nil {
-			parsedData.PubDate = pubDate
+			parsedData.PubDate, parsedData.PubDatePrecision = pubDate, precision
+			parsedData.Diagnostics.AddField(book.FieldDiagnostic{Field: FieldPubDate,
+				Selector: keySelector(SelectorCarousel, pubDateKey), Raw: detailsCarousel[pubDateKey],
+				Rule: "ParsePartialDateString", LowConfidence: true})
 		}
 	}
 }
 
-func getBookEdition(titleString, subtitleString string, publisherEdition uint8) uint8 {
-	titleEdition, err := parser.ParseEditionString(titleString)
-	if err == nil && titleEdition > 0 && titleEdition < 200 {
-		return titleEdition
+// getBookEdition returns the book edition, the selector and the raw value the edition is taken from.
+// The edition from the title has precedence over the publisher details one, the missing edition parts
+// (like the year of Springer's '2nd ed. 2023 edition') are completed from the publisher details.
+func getBookEdition(titleString, subtitleString string, publisherEdition parser.Edition) (parser.Edition, string,
+	string) {
+	titleEdition, err := parser.ParseEdition(titleString)
+	if err == nil && !titleEdition.IsEmpty() {
+		return titleEdition.Merge(publisherEdition), SelectorTitle, titleString
 	}
 
-	subtitleEdition, err := parser.ParseEditionString(subtitleString)
-	if err == nil && subtitleEdition > 0 && subtitleEdition < 200 {
-		return subtitleEdition
+	subtitleEdition, err := parser.ParseEdition(subtitleString)
+	if err == nil && !subtitleEdition.IsEmpty() {
+		return subtitleEdition.Merge(publisherEdition), SelectorSubtitle, subtitleString
 	}
 
-	// Springer specific error
-	if publisherEdition > 200 {
-		publisherEdition = 1
-	}
-
-	return publisherEdition
+	return publisherEdition, SelectorDetails, ""
 }
 
 func getISBN10(ISBN10, metaISBN, metaASIN string) string {
@@ -305,7 +740,7 @@ func getISBN10(ISBN10, metaISBN, metaASIN string) string {
 		effectiveISBN10 = metaASIN
 	}
 
-	effectiveISBN10 = strings.ReplaceAll(effectiveISBN10, "-", "")
+	effectiveISBN10 = isbn.Normalize(effectiveISBN10)
 	if len(effectiveISBN10) == 10 {
 		return effectiveISBN10
 	}
@@ -322,7 +757,7 @@ func getISBN13(ISBN13, metaISBN13 string) (int, error) {
 		effectiveISBN13 = metaISBN13
 	}
 
-	effectiveISBN13 = strings.ReplaceAll(effectiveISBN13, "-", "")
+	effectiveISBN13 = isbn.Normalize(effectiveISBN13)
 	if len(effectiveISBN13) == 13 {
 		return strconv.Atoi(effectiveISBN13)
 	}
@@ -339,36 +774,44 @@ func getPublisherURL(basePath, ISBN10, ASIN string) string {
 }
 
 func getBookLength(detailsBlock map[string]string) uint16 {
-	pagesString, paperbackString, hardcoverString, printLength, printedAccessCode :=
-		detailsBlock[pagesKey], detailsBlock[paperbackKey],
-		detailsBlock[hardcoverKey], detailsBlock[printLengthKey],
-		detailsBlock[printedAccessCodeKey]
-	if pagesString != "" {
-		return parser.ParseLengthString(pagesString)
-	}
-	if paperbackString != "" {
-		return parser.ParseLengthString(paperbackString)
-	}
-	if hardcoverString != "" {
-		return parser.ParseLengthString(hardcoverString)
-	}
-	if printLength != "" {
-		return parser.ParseLengthString(printLength)
-	}
-	if printedAccessCode != "" {
-		return parser.ParseLengthString(printedAccessCode)
+	if key := getBookLengthKey(detailsBlock); key != "" {
+		return parser.ParseLengthString(detailsBlock[key])
 	}
 
 	return 0
 }
 
+// getBookLengthKey returns the first details block key with the book length, according to bookLengthKeys order.
+func getBookLengthKey(detailsBlock map[string]string) string {
+	for _, key := range bookLengthKeys {
+		if detailsBlock[key] != "" {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// keySelector returns the diagnostic selector of a details block / carousel / ISBN block key.
+func keySelector(selector, key string) string {
+	return fmt.Sprintf("%s[%s]", selector, key)
+}
+
+// getCoverExtension returns the cover file extension from the cover URL path, like: '.jpg' or '.png'.
+// The cover URLs without an extension in the path, like the Google Books content links, are the JPEG images.
 func getCoverExtension(coverURL string) string {
-	lastDotIndex := strings.LastIndex(coverURL, ".")
-	if lastDotIndex == -1 {
+	if coverURL == "" {
 		return ""
 	}
+	coverPath := coverURL
+	if parsedURL, err := url.Parse(coverURL); err == nil {
+		coverPath = parsedURL.Path
+	}
+	if extension := path.Ext(coverPath); extension != "" {
+		return extension
+	}
 
-	return coverURL[lastDotIndex:]
+	return ".jpg"
 }
 
 func removeNonPrintable(r rune) rune {
@@ -0,0 +1,83 @@
+package scrapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAmazonScrapper_GetBookDataConcurrent(t *testing.T) {
+	t.Log("Given the need to test concurrent book page scrapping.")
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		bookID := strings.TrimPrefix(req.URL.Path, "/")
+		rw.Header().Set("Content-Type", "text/html")
+		_, _ = fmt.Fprintf(rw, `<html><body><span id="productTitle">Title %s</span></body></html>`, bookID)
+	}))
+	defer server.Close()
+
+	amazonScrapper, err := NewAmazonScrapper(server.URL+"/", log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+	}
+	amazonScrapper.SetMinRequestDelay(0)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(bookID string) {
+			defer wg.Done()
+			bookMeta, err := amazonScrapper.GetBookData(context.Background(), bookID)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if bookMeta.Title != "Title "+bookID {
+				errs <- fmt.Errorf("got %q title for %q book ID", bookMeta.Title, bookID)
+			}
+		}(fmt.Sprintf("%010d", i))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("\t\t%s\tShould get the book data for every book ID: %v", failed, err)
+	}
+
+	t.Logf("\t\t%s\tShould be able to scrape book pages concurrently", succeed)
+}
+
+func TestAmazonScrapper_GetBookDataCancelled(t *testing.T) {
+	t.Log("Given the need to test cancelled book page scrapping.")
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-release
+		http.ServeFile(rw, req, testBookResponse01)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	amazonScrapper, err := NewAmazonScrapper(server.URL+"/", log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = amazonScrapper.GetBookData(ctx, testBookID01)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("\t\t%s\tShould get a %v error: %v", failed, context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("\t\t%s\tShould return right after the deadline: %s", failed, elapsed)
+	}
+
+	t.Logf("\t\t%s\tShould be able to cancel book page scrapping", succeed)
+}
@@ -1,11 +1,14 @@
 package scrapper
 
 import (
+	"context"
+	"github.com/sdreger/lib-file-processor-go/parser"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -17,7 +20,7 @@ const (
 	testBookID01           = "1234567890"
 	testBookTitle          = "Test Title"
 	testBookSubtitle       = "Test Subtitle"
-	testBookDescription    = `<p><span>Test description</span></p>`
+	testBookDescription    = `<p>Test description</p>`
 	testBookISBN10         = "1234567890"
 	testBookISBN13         = 9781234567890
 	testBookASIN           = "B08HG2JYS2"
@@ -25,6 +28,8 @@ const (
 	testBookLanguage       = "English"
 	testBookPublisher      = "Test Publisher"
 	testBookEdition        = 4
+	testBookSeries         = "Test Series"
+	testBookSeriesVolume   = 2
 	testBookFileName       = "Test.Publisher.Test.Title.4th.Edition.1234567890.Apr.2022.zip"
 	testCoverFileName      = "1234567890.png"
 	testCoverURL           = "https://cover.com/1.png"
@@ -34,7 +39,8 @@ const (
 	testBookCategoryName01 = "Computers & Technology"
 	testBookCategoryName02 = "Programming"
 
-	testBookResponse01 = "testdata/book_full.html"
+	testBookResponse01     = "testdata/book_full.html"
+	testBookResponseKindle = "testdata/book_kindle.html"
 )
 
 var (
@@ -57,7 +63,7 @@ func TestGetBookDataISBN10(t *testing.T) {
 	if err != nil {
 		log.Fatalln(err)
 	}
-	bookMeta, err := amazonScrapper.GetBookData(testBookID01)
+	bookMeta, err := amazonScrapper.GetBookData(context.Background(), testBookID01)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -71,6 +77,10 @@ func TestGetBookDataISBN10(t *testing.T) {
 	if bookMeta.Description != testBookDescription {
 		t.Fatalf("\t\t%s\tShould get a %q book description: %q", failed, testBookDescription, bookMeta.Description)
 	}
+	if bookMeta.DescriptionText != "Test description" {
+		t.Fatalf("\t\t%s\tShould get a %q book text description: %q", failed, "Test description",
+			bookMeta.DescriptionText)
+	}
 	if bookMeta.ISBN10 != testBookISBN10 {
 		t.Fatalf("\t\t%s\tShould get a %q book ISBN10: %q", failed, testBookISBN10, bookMeta.ISBN10)
 	}
@@ -92,14 +102,26 @@ func TestGetBookDataISBN10(t *testing.T) {
 	if bookMeta.PublisherURL != testBookPublisherURL {
 		t.Fatalf("\t\t%s\tShould get a %q book publisher URL: %q", failed, testBookPublisherURL, bookMeta.PublisherURL)
 	}
-	if bookMeta.Edition != testBookEdition {
-		t.Fatalf("\t\t%s\tShould get a %d book edition: %d", failed, testBookEdition, bookMeta.Edition)
+	if bookMeta.Edition.Number != testBookEdition {
+		t.Fatalf("\t\t%s\tShould get a %d book edition: %d", failed, testBookEdition, bookMeta.Edition.Number)
+	}
+	if bookMeta.Series != testBookSeries {
+		t.Fatalf("\t\t%s\tShould get a %q book series: %q", failed, testBookSeries, bookMeta.Series)
+	}
+	if bookMeta.SeriesVolume != testBookSeriesVolume {
+		t.Fatalf("\t\t%s\tShould get a %d book series volume: %d", failed, testBookSeriesVolume,
+			bookMeta.SeriesVolume)
 	}
 	if !bookMeta.PubDate.Equal(testBookPubDate) {
 		t.Fatalf("\t\t%s\tShould get a %v book publish date: %v", failed, testBookPubDate, bookMeta.PubDate)
 	}
-	if !reflect.DeepEqual(bookMeta.Authors, testBookAuthors) {
-		t.Fatalf("\t\t%s\tShould get %v book authors: %v", failed, testBookAuthors, bookMeta.Authors)
+	if !reflect.DeepEqual(bookMeta.GetAuthorNames(), testBookAuthors) {
+		t.Fatalf("\t\t%s\tShould get %v book authors: %v", failed, testBookAuthors, bookMeta.GetAuthorNames())
+	}
+	// The same person could be both an author and an editor
+	roles := bookMeta.GetAuthorRoles(testBookAuthorName03)
+	if !reflect.DeepEqual(roles, []string{parser.ContributorRoleAuthor, parser.ContributorRoleEditor}) {
+		t.Fatalf("\t\t%s\tShould get the author and editor contributor roles: %q", failed, roles)
 	}
 	if !reflect.DeepEqual(bookMeta.Categories, testBookCategories) {
 		t.Fatalf("\t\t%s\tShould get %v book categories: %v", failed, testBookCategories, bookMeta.Categories)
@@ -116,10 +138,143 @@ func TestGetBookDataISBN10(t *testing.T) {
 	if bookMeta.CoverURL != testCoverURL {
 		t.Fatalf("\t\t%s\tShould get a %q book cover URL: %q", failed, testCoverURL, bookMeta.CoverURL)
 	}
+	if diagnostic, ok := bookMeta.Diagnostics.GetField(FieldISBN13); !ok ||
+		diagnostic.Selector != keySelector(SelectorDetails, ISBN13Key) || diagnostic.Source != "amazon.com" {
+		t.Fatalf("\t\t%s\tShould get the ISBN13 diagnostic from the details block: %+v", failed, diagnostic)
+	}
+	if diagnostic, ok := bookMeta.Diagnostics.GetField(FieldEdition); !ok || diagnostic.Selector != SelectorSubtitle {
+		t.Fatalf("\t\t%s\tShould get the edition diagnostic from the subtitle: %+v", failed, diagnostic)
+	}
+	if len(bookMeta.Diagnostics.MissingRequired) != 0 {
+		t.Fatalf("\t\t%s\tShould not miss required fields: %v", failed, bookMeta.Diagnostics.MissingRequired)
+	}
 
 	t.Logf("\t\t%s\tShould be able to scrape book data.", succeed)
 }
 
+func TestGetBookDataPrintEdition(t *testing.T) {
+	t.Log("Given the need to test following the print edition of a Kindle book.")
+	server := testStorefrontMockServer(t, map[string]string{
+		"/" + testBookASIN: testBookResponseKindle,
+		"/" + testBookID01: testBookResponse01,
+	}, nil)
+	defer server.Close()
+
+	amazonScrapper, err := NewAmazonScrapper(server.URL+"/", log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+	}
+	amazonScrapper.SetMinRequestDelay(0)
+	bookMeta, err := amazonScrapper.GetBookData(context.Background(), testBookASIN)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+	}
+
+	if bookMeta.ASIN != testBookASIN {
+		t.Fatalf("\t\t%s\tShould keep the %q Kindle ASIN: %q", failed, testBookASIN, bookMeta.ASIN)
+	}
+	if bookMeta.ISBN10 != testBookISBN10 {
+		t.Fatalf("\t\t%s\tShould get a %q print ISBN10: %q", failed, testBookISBN10, bookMeta.ISBN10)
+	}
+	if bookMeta.ISBN13 != testBookISBN13 {
+		t.Fatalf("\t\t%s\tShould get a %d print ISBN13: %d", failed, testBookISBN13, bookMeta.ISBN13)
+	}
+	if bookMeta.Pages != testBookPages {
+		t.Fatalf("\t\t%s\tShould get %d print pages: %d", failed, testBookPages, bookMeta.Pages)
+	}
+	if diagnostic, _ := bookMeta.Diagnostics.GetField(FieldISBN10); !strings.HasPrefix(diagnostic.Selector, "print edition") {
+		t.Fatalf("\t\t%s\tShould get the ISBN10 diagnostic from the print edition: %+v", failed, diagnostic)
+	}
+	// The test ISBNs have invalid checksums, so they are flagged as well
+	if lowConfidence := bookMeta.Diagnostics.LowConfidenceFields(); !reflect.DeepEqual(lowConfidence,
+		[]string{FieldISBN10, FieldISBN13, FieldPages}) {
+		t.Fatalf("\t\t%s\tShould get the print edition pages as a low confidence field: %v", failed, lowConfidence)
+	}
+	if bookMeta.BookFileName != testBookFileName {
+		t.Fatalf("\t\t%s\tShould get a %q book file name: %q", failed, testBookFileName, bookMeta.BookFileName)
+	}
+
+	t.Logf("\t\t%s\tShould fill the missing identifiers from the print edition.", succeed)
+}
+
+func TestGetPrintEditionID(t *testing.T) {
+	t.Log("Given the need to test print edition links.")
+	tests := []struct {
+		storefront string
+		links      map[string]string
+		want       string
+	}{
+		{"com", map[string]string{"Kindle": "javascript:void(0)"}, ""},
+		{"com", map[string]string{"Paperback": "/Title/dp/1234567890/ref=tmm_pap_swatch_0"}, "1234567890"},
+		{"com", map[string]string{"Hardcover": "/gp/product/1234567890?ie=UTF8",
+			"Paperback": "/Title/dp/0987654321"}, "0987654321"},
+		{"de", map[string]string{"Gebundenes Buch": "/Titel/dp/1234567890"}, "1234567890"},
+		{"fr", map[string]string{"Broché": "javascript:void(0)"}, ""},
+	}
+
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %v links on the %q storefront\n", i, tt.links, tt.storefront)
+		if got := getPrintEditionID(amazonStorefronts[tt.storefront], tt.links); got != tt.want {
+			t.Errorf("\t\t%s\tShould get a %q print edition ID: %q", failed, tt.want, got)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct print edition ID.", succeed)
+		}
+	}
+}
+
+func TestGetBookEdition(t *testing.T) {
+	t.Log("Given the need to test book edition sources.")
+	tests := []struct {
+		title            string
+		subtitle         string
+		publisherEdition parser.Edition
+		want             parser.Edition
+		selector         string
+	}{
+		{"Go Programming, 3rd Edition", "", parser.Edition{Number: 2}, parser.Edition{Number: 3}, SelectorTitle},
+		{"Go Programming", "20th Anniversary Edition", parser.Edition{Number: 1},
+			parser.Edition{Number: 1, Qualifier: "20th Anniversary"}, SelectorSubtitle},
+		{"Linear Algebra", "", parser.Edition{Number: 2, Year: 2023}, parser.Edition{Number: 2, Year: 2023},
+			SelectorDetails},
+		{"Linear Algebra (Revised Edition)", "", parser.Edition{Year: 2023},
+			parser.Edition{Qualifier: "Revised", Year: 2023}, SelectorTitle},
+	}
+
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q title with %+v publisher edition\n", i, tt.title, tt.publisherEdition)
+		edition, selector, _ := getBookEdition(tt.title, tt.subtitle, tt.publisherEdition)
+		if edition != tt.want || selector != tt.selector {
+			t.Errorf("\t\t%s\tShould get a %+v edition from %q: %+v from %q", failed, tt.want, tt.selector, edition,
+				selector)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct book edition.", succeed)
+		}
+	}
+}
+
+func TestGetCoverExtension(t *testing.T) {
+	tests := []struct {
+		coverURL  string
+		extension string
+	}{
+		{coverURL: "https://m.media-amazon.com/images/I/51AbCdEfGhL._SX379_BO1,204,203,200_.jpg", extension: ".jpg"},
+		{coverURL: "https://covers.openlibrary.org/b/id/12345-L.png", extension: ".png"},
+		{coverURL: "https://example.com/covers/book.webp?size=large", extension: ".webp"},
+		{coverURL: "https://books.google.com/books/content?id=TestVolumeID&zoom=1", extension: ".jpg"},
+		{coverURL: "", extension: ""},
+	}
+
+	t.Log("Given the need to test book cover extension.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for %q extension\n", i, tt.coverURL, tt.extension)
+		if extension := getCoverExtension(tt.coverURL); extension != tt.extension {
+			t.Errorf("\t\t%s\tShould get a %q cover extension: %q", failed, tt.extension, extension)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct cover extension.", succeed)
+		}
+	}
+}
+
 func testMockServer(t *testing.T) *httptest.Server {
 	mux := http.NewServeMux()
 	server := httptest.NewServer(mux)
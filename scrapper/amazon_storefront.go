@@ -0,0 +1,161 @@
+package scrapper
+
+import (
+	"fmt"
+	"github.com/sdreger/lib-file-processor-go/parser"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultStorefrontCode = "com"
+)
+
+// Storefront describes a regional Amazon storefront: its base path, request headers,
+// the page language, and the mapping of localized detail bullet keys to the canonical (English) ones.
+type Storefront struct {
+	Code           string
+	BasePath       string
+	AcceptLanguage string
+	Language       string
+	detailKeys     map[string]string
+}
+
+var (
+	germanDetailKeys = map[string]string{
+		"Herausgeber":                       publisherKey,
+		"Verlag":                            publisherKey,
+		"Auflage":                           editionKey,
+		"Erscheinungstermin":                pubDateKey,
+		"Seitenzahl der Print-Ausgabe":      printLengthKey,
+		"Seitenzahl":                        pagesKey,
+		"Taschenbuch":                       paperbackKey,
+		"Gebundene Ausgabe":                 hardcoverKey,
+		"Gebundenes Buch":                   hardcoverKey,
+		"Sprache":                           languageKey,
+		"ISBN-Quelle für Seitenzahl":        sourceISBNKey,
+		"ISBN-Quelle für Seitenzahlen":      sourceISBNKey,
+		"Gedruckter Zugangscode":            printedAccessCodeKey,
+		"Broschiert":                        paperbackKey,
+		"Seitenzahl der gedruckten Ausgabe": printLengthKey,
+	}
+	frenchDetailKeys = map[string]string{
+		"Éditeur":             publisherKey,
+		"Édition":             editionKey,
+		"Date de publication": pubDateKey,
+		"Nombre de pages de l'édition imprimée": printLengthKey,
+		"Longueur du livre imprimé":             printLengthKey,
+		"Broché":                                paperbackKey,
+		"Relié":                                 hardcoverKey,
+		"Langue":                                languageKey,
+		"ISBN de la source du numéro de page":   sourceISBNKey,
+	}
+
+	amazonStorefronts = map[string]Storefront{
+		"com": {
+			Code:           "com",
+			BasePath:       "https://www.amazon.com/dp/",
+			AcceptLanguage: "en-US,en;q=0.5",
+			Language:       parser.LanguageEnglish,
+		},
+		"co.uk": {
+			Code:           "co.uk",
+			BasePath:       "https://www.amazon.co.uk/dp/",
+			AcceptLanguage: "en-GB,en;q=0.5",
+			Language:       parser.LanguageEnglish,
+		},
+		"ca": {
+			Code:           "ca",
+			BasePath:       "https://www.amazon.ca/dp/",
+			AcceptLanguage: "en-CA,en;q=0.5",
+			Language:       parser.LanguageEnglish,
+		},
+		"in": {
+			Code:           "in",
+			BasePath:       "https://www.amazon.in/dp/",
+			AcceptLanguage: "en-IN,en;q=0.5",
+			Language:       parser.LanguageEnglish,
+		},
+		"de": {
+			Code:           "de",
+			BasePath:       "https://www.amazon.de/dp/",
+			AcceptLanguage: "de-DE,de;q=0.8,en;q=0.3",
+			Language:       parser.LanguageGerman,
+			detailKeys:     germanDetailKeys,
+		},
+		"fr": {
+			Code:           "fr",
+			BasePath:       "https://www.amazon.fr/dp/",
+			AcceptLanguage: "fr-FR,fr;q=0.8,en;q=0.3",
+			Language:       parser.LanguageFrench,
+			detailKeys:     frenchDetailKeys,
+		},
+	}
+)
+
+// GetStorefront returns a known Amazon storefront by its domain suffix code, like: 'com', 'co.uk', 'de'.
+func GetStorefront(code string) (Storefront, error) {
+	storefront, ok := amazonStorefronts[strings.TrimPrefix(strings.ToLower(code), ".")]
+	if !ok {
+		return Storefront{}, fmt.Errorf("unknown Amazon storefront: %q", code)
+	}
+
+	return storefront, nil
+}
+
+// getStorefronts returns the storefronts by their codes, or the default storefront for an empty list.
+func getStorefronts(storefrontCodes []string) ([]Storefront, error) {
+	storefronts := make([]Storefront, 0, len(storefrontCodes))
+	for _, code := range storefrontCodes {
+		storefront, err := GetStorefront(code)
+		if err != nil {
+			return nil, err
+		}
+		storefronts = append(storefronts, storefront)
+	}
+	if len(storefronts) == 0 {
+		storefronts = append(storefronts, amazonStorefronts[defaultStorefrontCode])
+	}
+
+	return storefronts, nil
+}
+
+// getBasePathStorefront returns a storefront for the custom base path. If the base path belongs to one of the known
+// storefronts - its settings are used, otherwise the default storefront settings are used.
+func getBasePathStorefront(basePath string) Storefront {
+	storefront := amazonStorefronts[defaultStorefrontCode]
+	if basePathURL, err := url.Parse(basePath); err == nil {
+		for _, knownStorefront := range amazonStorefronts {
+			if strings.HasSuffix(basePathURL.Host, "amazon."+knownStorefront.Code) {
+				storefront = knownStorefront
+				break
+			}
+		}
+	}
+	storefront.BasePath = basePath
+
+	return storefront
+}
+
+// canonicalDetails maps localized detail keys to the canonical ones. Unknown keys are kept as is.
+func (s Storefront) canonicalDetails(details map[string]string) map[string]string {
+	if len(s.detailKeys) == 0 {
+		return details
+	}
+
+	canonical := make(map[string]string, len(details))
+	for key, value := range details {
+		if canonicalKey, ok := s.detailKeys[key]; ok {
+			key = canonicalKey
+		}
+		canonical[key] = value
+	}
+
+	return canonical
+}
+
+// languageName returns the English name of a book language, shown on the storefront page in the storefront locale.
+func (s Storefront) languageName(language string) string {
+	name, _ := parser.NormalizeLanguage(language)
+	return name
+}
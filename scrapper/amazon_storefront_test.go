@@ -0,0 +1,183 @@
+package scrapper
+
+import (
+	"context"
+	"github.com/sdreger/lib-file-processor-go/parser"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+const (
+	testBookResponseDE      = "testdata/book_full_de.html"
+	testBookResponsePartial = "testdata/book_partial.html"
+)
+
+func TestGetStorefront(t *testing.T) {
+	t.Log("Given the need to test Amazon storefront lookup.")
+	storefront, err := GetStorefront(".co.uk")
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get a known storefront: %v", failed, err)
+	}
+	if storefront.BasePath != "https://www.amazon.co.uk/dp/" {
+		t.Fatalf("\t\t%s\tShould get a %q base path: %q", failed, "https://www.amazon.co.uk/dp/", storefront.BasePath)
+	}
+	if _, err := GetStorefront("xx"); err == nil {
+		t.Fatalf("\t\t%s\tShould get an error for an unknown storefront", failed)
+	}
+	if storefront := getBasePathStorefront("https://www.amazon.de/dp/"); storefront.Code != "de" {
+		t.Fatalf("\t\t%s\tShould get a %q storefront for the base path: %q", failed, "de", storefront.Code)
+	}
+
+	t.Logf("\t\t%s\tShould be able to get Amazon storefronts.", succeed)
+}
+
+func TestAmazonScrapper_GetBookDataLocalized(t *testing.T) {
+	t.Log("Given the need to test localized book page scrapping.")
+	var acceptLanguage string
+	server := testStorefrontMockServer(t, map[string]string{"/de/" + testBookID01: testBookResponseDE}, &acceptLanguage)
+	defer server.Close()
+
+	storefront := amazonStorefronts["de"]
+	storefront.BasePath = server.URL + "/de/"
+	amazonScrapper, err := newAmazonScrapper([]Storefront{storefront}, log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+	}
+	amazonScrapper.SetMinRequestDelay(0)
+	bookMeta, err := amazonScrapper.GetBookData(context.Background(), testBookID01)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+	}
+
+	if acceptLanguage != storefront.AcceptLanguage {
+		t.Fatalf("\t\t%s\tShould send a %q 'Accept-Language' header: %q", failed, storefront.AcceptLanguage, acceptLanguage)
+	}
+	if bookMeta.Title != testBookTitle {
+		t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+	}
+	if bookMeta.Pages != testBookPages {
+		t.Fatalf("\t\t%s\tShould get a %d book pages: %d", failed, testBookPages, bookMeta.Pages)
+	}
+	if bookMeta.Language != testBookLanguage {
+		t.Fatalf("\t\t%s\tShould get a %q book language: %q", failed, testBookLanguage, bookMeta.Language)
+	}
+	if bookMeta.Publisher != testBookPublisher {
+		t.Fatalf("\t\t%s\tShould get a %q book publisher: %q", failed, testBookPublisher, bookMeta.Publisher)
+	}
+	if bookMeta.Edition.Number != testBookEdition {
+		t.Fatalf("\t\t%s\tShould get a %d book edition: %d", failed, testBookEdition, bookMeta.Edition.Number)
+	}
+	if bookMeta.Series != "Test-Reihe" || bookMeta.SeriesVolume != testBookSeriesVolume {
+		t.Fatalf("\t\t%s\tShould get a %q book series, volume %d: %q, volume %d", failed, "Test-Reihe",
+			testBookSeriesVolume, bookMeta.Series, bookMeta.SeriesVolume)
+	}
+	roles := bookMeta.GetAuthorRoles(testBookAuthorName03)
+	if !reflect.DeepEqual(roles, []string{parser.ContributorRoleEditor}) {
+		t.Fatalf("\t\t%s\tShould get a %q contributor role: %q", failed, parser.ContributorRoleEditor, roles)
+	}
+	if !bookMeta.PubDate.Equal(testBookPubDate) {
+		t.Fatalf("\t\t%s\tShould get a %v book publish date: %v", failed, testBookPubDate, bookMeta.PubDate)
+	}
+	if !reflect.DeepEqual(bookMeta.Categories, testBookCategories) {
+		t.Fatalf("\t\t%s\tShould get %v book categories: %v", failed, testBookCategories, bookMeta.Categories)
+	}
+	if bookMeta.PublisherURL != storefront.BasePath+testBookID01 {
+		t.Fatalf("\t\t%s\tShould get a %q book publisher URL: %q", failed, storefront.BasePath+testBookID01,
+			bookMeta.PublisherURL)
+	}
+
+	t.Logf("\t\t%s\tShould be able to scrape localized book data.", succeed)
+}
+
+func TestAmazonScrapper_GetBookDataStorefrontFallback(t *testing.T) {
+	t.Log("Given the need to test storefront fallback.")
+	server := testStorefrontMockServer(t, map[string]string{
+		"/com/" + testBookID01: testBookResponsePartial,
+		"/de/" + testBookID01:  testBookResponseDE,
+	}, nil)
+	defer server.Close()
+
+	missingStorefront := amazonStorefronts["co.uk"]
+	missingStorefront.BasePath = server.URL + "/uk/"
+	partialStorefront := amazonStorefronts["com"]
+	partialStorefront.BasePath = server.URL + "/com/"
+	fullStorefront := amazonStorefronts["de"]
+	fullStorefront.BasePath = server.URL + "/de/"
+
+	t.Logf("\t\tWhen checking for the full data storefront\n")
+	{
+		amazonScrapper, err := newAmazonScrapper(
+			[]Storefront{missingStorefront, partialStorefront, fullStorefront}, log.Default())
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+		}
+		amazonScrapper.SetMinRequestDelay(0)
+		bookMeta, err := amazonScrapper.GetBookData(context.Background(), testBookID01)
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+		}
+		if bookMeta.Publisher != testBookPublisher {
+			t.Fatalf("\t\t%s\tShould get a %q book publisher: %q", failed, testBookPublisher, bookMeta.Publisher)
+		}
+		t.Logf("\t\t%s\tShould be able to get book data from the next storefront.", succeed)
+	}
+
+	t.Logf("\t\tWhen checking for the partial data storefront only\n")
+	{
+		amazonScrapper, err := newAmazonScrapper([]Storefront{missingStorefront, partialStorefront}, log.Default())
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+		}
+		amazonScrapper.SetMinRequestDelay(0)
+		bookMeta, err := amazonScrapper.GetBookData(context.Background(), testBookID01)
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to get partial book data: %v", failed, err)
+		}
+		if bookMeta.Title != testBookTitle || bookMeta.Publisher != "" {
+			t.Fatalf("\t\t%s\tShould get partial book data: %+v", failed, bookMeta)
+		}
+		t.Logf("\t\t%s\tShould be able to get partial book data.", succeed)
+	}
+
+	t.Logf("\t\tWhen checking for all storefronts failed\n")
+	{
+		amazonScrapper, err := newAmazonScrapper([]Storefront{missingStorefront}, log.Default())
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+		}
+		amazonScrapper.SetMinRequestDelay(0)
+		if _, err = amazonScrapper.GetBookData(context.Background(), testBookID01); err == nil {
+			t.Fatalf("\t\t%s\tShould get an error if all storefronts failed", failed)
+		}
+		t.Logf("\t\t%s\tShould get an error if all storefronts failed.", succeed)
+	}
+}
+
+func testStorefrontMockServer(t *testing.T, handlers map[string]string, acceptLanguage *string) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	for path, responseFile := range handlers {
+		responseFile := responseFile
+		mux.HandleFunc(path, func(rw http.ResponseWriter, req *http.Request) {
+			if acceptLanguage != nil {
+				*acceptLanguage = req.Header.Get("Accept-Language")
+			}
+			file, err := os.ReadFile(responseFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rw.Header().Set("Content-Type", "text/html")
+			rw.WriteHeader(http.StatusOK)
+			_, err = rw.Write(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	return server
+}
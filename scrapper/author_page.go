@@ -0,0 +1,85 @@
+package scrapper
+
+import (
+	"context"
+	"github.com/gocolly/colly/v2"
+	"github.com/sdreger/lib-file-processor-go/domain/author"
+	"net/url"
+	"regexp"
+)
+
+var (
+	authorBioSelectors   = []string{`div[id=ap-bio] div.a-expander-content`, `div[id=authorBio]`}
+	authorPhotoSelectors = []string{`div[id=ap-image] img`, `img[id=authorImage]`}
+
+	// authorIDRegex extracts the Amazon author ID from an author page link, like: '/stores/Name/author/B000APF21M'
+	authorIDRegex = regexp.MustCompile(`/(?:author|e)/(B[0-9A-Z]{9})(?:[/?#]|$)`)
+)
+
+// getAuthorProfiles returns the profiles of the authors, which have a link to their author page.
+func getAuthorProfiles(storefront Storefront, authors []string, authorLinks map[string]string) []author.Profile {
+	profiles := make([]author.Profile, 0, len(authors))
+	for _, name := range authors {
+		subMatch := authorIDRegex.FindStringSubmatch(authorLinks[name])
+		if subMatch == nil {
+			continue
+		}
+		profiles = append(profiles, author.Profile{
+			Name:       name,
+			Source:     AmazonSourceName,
+			ExternalID: subMatch[1],
+			PageURL:    resolveURL(storefront.BasePath, authorLinks[name]),
+		})
+	}
+
+	return profiles
+}
+
+// fetchAuthorProfiles visits the author pages, and fills the author bio and photo URL.
+// The author page errors are not fatal, the profile is kept with the author ID only.
+func (s *AmazonScrapper) fetchAuthorProfiles(ctx context.Context, storefront Storefront,
+	profiles []author.Profile) []author.Profile {
+	for i := range profiles {
+		if err := s.fetchAuthorProfile(ctx, storefront, &profiles[i]); err != nil {
+			s.logger.Printf("[WARN] - Can not get the author page %q: %v", profiles[i].PageURL, err)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return profiles
+}
+
+func (s *AmazonScrapper) fetchAuthorProfile(ctx context.Context, storefront Storefront,
+	profile *author.Profile) error {
+	var responseErr error
+	collector := s.collector.Clone()
+	initCallbacks(ctx, collector, &responseErr, s.logger)
+	collector.OnHTML("html", func(element *colly.HTMLElement) {
+		s.selectors.extractAuthorProfile(element.DOM, profile)
+	})
+
+	requestCtx := colly.NewContext()
+	requestCtx.Put(acceptLanguageContextKey, storefront.AcceptLanguage)
+	err := s.request(collector, profile.PageURL, requestCtx, &responseErr)
+	if responseErr != nil {
+		return responseErr
+	}
+
+	return err
+}
+
+// resolveURL resolves a page link against the storefront base path.
+func resolveURL(basePath, link string) string {
+	baseURL, err := url.Parse(basePath)
+	if err != nil {
+		return link
+	}
+	linkURL, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+
+	return baseURL.ResolveReference(linkURL).String()
+}
@@ -0,0 +1,65 @@
+package scrapper
+
+import (
+	"context"
+	"github.com/sdreger/lib-file-processor-go/domain/author"
+	"log"
+	"testing"
+)
+
+const (
+	testAuthorPageResponse = "testdata/author_page.html"
+	testAuthorPagePath     = "/stores/First-Author/author/B000000001"
+	testAuthorExternalID   = "B000000001"
+	testAuthorBio          = "First Author is a test author."
+	testAuthorPhotoURL     = "https://photo.com/first-author.jpg"
+)
+
+func TestAmazonScrapper_GetBookDataAuthorPages(t *testing.T) {
+	t.Log("Given the need to test author pages scrapping.")
+	server := testStorefrontMockServer(t, map[string]string{
+		"/" + testBookID01: testBookResponse01,
+		testAuthorPagePath: testAuthorPageResponse,
+	}, nil)
+	defer server.Close()
+
+	amazonScrapper, err := NewAmazonScrapper(server.URL+"/", log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+	}
+	amazonScrapper.SetMinRequestDelay(0)
+
+	t.Logf("\t\tWhen checking for disabled author pages\n")
+	{
+		bookMeta, err := amazonScrapper.GetBookData(context.Background(), testBookID01)
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+		}
+		if len(bookMeta.AuthorProfiles) != 0 {
+			t.Fatalf("\t\t%s\tShould not get author profiles: %+v", failed, bookMeta.AuthorProfiles)
+		}
+		t.Logf("\t\t%s\tShould not visit author pages.", succeed)
+	}
+
+	t.Logf("\t\tWhen checking for enabled author pages\n")
+	{
+		amazonScrapper.SetFetchAuthorPages(true)
+		bookMeta, err := amazonScrapper.GetBookData(context.Background(), testBookID01)
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+		}
+		expectedProfile := author.Profile{
+			Name:       testBookAuthorName01,
+			Source:     AmazonSourceName,
+			ExternalID: testAuthorExternalID,
+			Bio:        testAuthorBio,
+			PhotoURL:   testAuthorPhotoURL,
+			PageURL:    server.URL + testAuthorPagePath + "?ref=ap_rdr",
+		}
+		if len(bookMeta.AuthorProfiles) != 1 || bookMeta.AuthorProfiles[0] != expectedProfile {
+			t.Fatalf("\t\t%s\tShould get a %+v author profile: %+v", failed, expectedProfile,
+				bookMeta.AuthorProfiles)
+		}
+		t.Logf("\t\t%s\tShould get author profiles from the author pages.", succeed)
+	}
+}
@@ -0,0 +1,52 @@
+package scrapper
+
+import (
+	"context"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"sync"
+)
+
+// BatchResult is a book data lookup result for a single book ID.
+type BatchResult struct {
+	BookID     string
+	ParsedData book.ParsedData
+	Err        error
+}
+
+// GetBookDataBatch looks up several book IDs in parallel, running at most 'parallelism' lookups at once.
+// The results are returned in the order of the book IDs. Lookups not started before the context cancellation
+// get the context error.
+func GetBookDataBatch(ctx context.Context, scrapper BookDataScrapper, bookIDs []string,
+	parallelism int) []BatchResult {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results := make([]BatchResult, len(bookIDs))
+	semaphore := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, bookID := range bookIDs {
+		results[i].BookID = bookID
+		// Both select cases could be ready, so the cancellation is checked first
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int, bookID string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i].ParsedData, results[i].Err = scrapper.GetBookData(ctx, bookID)
+		}(i, bookID)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,64 @@
+package scrapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"testing"
+)
+
+func TestGetBookDataBatch(t *testing.T) {
+	t.Log("Given the need to test batch book data lookup.")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bookIDs := []string{"0000000001", "0000000002", "0000000003", "0000000004"}
+	mockScrapper := NewMockBookDataScrapper(ctrl)
+	mockScrapper.EXPECT().GetBookData(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bookID string) (book.ParsedData, error) {
+			if bookID == bookIDs[2] {
+				return book.ParsedData{}, ErrNotFound
+			}
+			return book.ParsedData{Title: fmt.Sprintf("Title %s", bookID)}, nil
+		}).Times(len(bookIDs))
+
+	results := GetBookDataBatch(context.Background(), mockScrapper, bookIDs, 2)
+	if len(results) != len(bookIDs) {
+		t.Fatalf("\t\t%s\tShould get %d results: %d", failed, len(bookIDs), len(results))
+	}
+	for i, result := range results {
+		if result.BookID != bookIDs[i] {
+			t.Fatalf("\t\t%s\tShould get results in the book IDs order: %q", failed, result.BookID)
+		}
+		if i == 2 {
+			if !errors.Is(result.Err, ErrNotFound) {
+				t.Fatalf("\t\t%s\tShould get a %v error: %v", failed, ErrNotFound, result.Err)
+			}
+			continue
+		}
+		if result.Err != nil || result.ParsedData.Title != "Title "+bookIDs[i] {
+			t.Fatalf("\t\t%s\tShould get the book data for %q: %+v, %v", failed, bookIDs[i], result.ParsedData,
+				result.Err)
+		}
+	}
+
+	t.Logf("\t\t%s\tShould be able to look up book IDs in parallel", succeed)
+}
+
+func TestGetBookDataBatchCancelled(t *testing.T) {
+	t.Log("Given the need to test cancelled batch book data lookup.")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockScrapper := NewMockBookDataScrapper(ctrl)
+	results := GetBookDataBatch(ctx, mockScrapper, []string{testBookID01}, 1)
+	if !errors.Is(results[0].Err, context.Canceled) {
+		t.Fatalf("\t\t%s\tShould get a %v error: %v", failed, context.Canceled, results[0].Err)
+	}
+
+	t.Logf("\t\t%s\tShould not start lookups after the context cancellation", succeed)
+}
@@ -5,6 +5,7 @@
 package scrapper
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -49,16 +50,16 @@ func (mr *MockBookDataScrapperMockRecorder) Close() *gomock.Call {
 }
 
 // GetBookData mocks base method.
-func (m *MockBookDataScrapper) GetBookData(arg0 string) (book.ParsedData, error) {
+func (m *MockBookDataScrapper) GetBookData(arg0 context.Context, arg1 string) (book.ParsedData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBookData", arg0)
+	ret := m.ctrl.Call(m, "GetBookData", arg0, arg1)
 	ret0, _ := ret[0].(book.ParsedData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBookData indicates an expected call of GetBookData.
-func (mr *MockBookDataScrapperMockRecorder) GetBookData(arg0 interface{}) *gomock.Call {
+func (mr *MockBookDataScrapperMockRecorder) GetBookData(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookData", reflect.TypeOf((*MockBookDataScrapper)(nil).GetBookData), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookData", reflect.TypeOf((*MockBookDataScrapper)(nil).GetBookData), arg0, arg1)
 }
@@ -0,0 +1,27 @@
+package scrapper
+
+import (
+	"fmt"
+	"github.com/sdreger/lib-file-processor-go/isbn"
+)
+
+// isbnLookupID returns the book ID, the ISBN based APIs are queried by: both ISBN10 and ISBN13 are accepted,
+// the hyphens and spaces are removed.
+func isbnLookupID(bookID string) string {
+	return isbn.Normalize(bookID)
+}
+
+// amazonLookupID returns the product ID, the Amazon product pages are requested by: an ISBN10 or an ASIN.
+// A '978' prefixed ISBN13 is converted to its ISBN10, the '979' prefixed ones have no Amazon product pages.
+func amazonLookupID(bookID string) (string, error) {
+	productID := isbn.Normalize(bookID)
+	if len(productID) != 13 {
+		return productID, nil
+	}
+	isbn10, err := isbn.ToISBN10(productID)
+	if err != nil {
+		return "", fmt.Errorf("%w: the Amazon product page requires an ISBN10 or ASIN: %v", ErrNotFound, err)
+	}
+
+	return isbn10, nil
+}
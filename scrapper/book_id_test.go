@@ -0,0 +1,34 @@
+package scrapper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAmazonLookupID(t *testing.T) {
+	tests := []struct {
+		bookID    string
+		productID string
+		err       error
+	}{
+		{bookID: "0-306-40615-2", productID: "0306406152"},
+		{bookID: "978-0-306-40615-7", productID: "0306406152"},
+		{bookID: "B08HG2JYS2", productID: "B08HG2JYS2"},
+		{bookID: "979-10-90636-07-1", err: ErrNotFound},
+	}
+
+	t.Log("Given the need to test the Amazon product ID lookup.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q for the %q product ID\n", i, tt.bookID, tt.productID)
+		productID, err := amazonLookupID(tt.bookID)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("\t\t%s\tShould get the %v error: %v", failed, tt.err, err)
+			continue
+		}
+		if productID != tt.productID {
+			t.Errorf("\t\t%s\tShould get the %q product ID: %q", failed, tt.productID, productID)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get the product ID.", succeed)
+		}
+	}
+}
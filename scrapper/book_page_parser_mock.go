@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sdreger/lib-file-processor-go/scrapper (interfaces: BookPageParser)
+
+// Package scrapper is a generated GoMock package.
+package scrapper
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	book "github.com/sdreger/lib-file-processor-go/domain/book"
+)
+
+// MockBookPageParser is a mock of BookPageParser interface.
+type MockBookPageParser struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookPageParserMockRecorder
+}
+
+// MockBookPageParserMockRecorder is the mock recorder for MockBookPageParser.
+type MockBookPageParserMockRecorder struct {
+	mock *MockBookPageParser
+}
+
+// NewMockBookPageParser creates a new mock instance.
+func NewMockBookPageParser(ctrl *gomock.Controller) *MockBookPageParser {
+	mock := &MockBookPageParser{ctrl: ctrl}
+	mock.recorder = &MockBookPageParserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookPageParser) EXPECT() *MockBookPageParserMockRecorder {
+	return m.recorder
+}
+
+// ParseBookPageFile mocks base method.
+func (m *MockBookPageParser) ParseBookPageFile(arg0 string) (book.ParsedData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseBookPageFile", arg0)
+	ret0, _ := ret[0].(book.ParsedData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseBookPageFile indicates an expected call of ParseBookPageFile.
+func (mr *MockBookPageParserMockRecorder) ParseBookPageFile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseBookPageFile", reflect.TypeOf((*MockBookPageParser)(nil).ParseBookPageFile), arg0)
+}
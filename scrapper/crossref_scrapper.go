@@ -0,0 +1,253 @@
+package scrapper
+
+import (
+	"context"
+	"fmt"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"github.com/sdreger/lib-file-processor-go/domain/publisher"
+	"github.com/sdreger/lib-file-processor-go/isbn"
+	"github.com/sdreger/lib-file-processor-go/parser"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CrossrefSourceName = "crossref"
+
+	defaultCrossrefBasePath = "https://api.crossref.org"
+
+	crossrefRequestTimeout = 15 * time.Second
+	crossrefDOIPrefix      = "10."
+)
+
+type crossrefWorkResponse struct {
+	Message crossrefWork `json:"message"`
+}
+
+type crossrefWorksResponse struct {
+	Message struct {
+		Items []crossrefWork `json:"items"`
+	} `json:"message"`
+}
+
+type crossrefWork struct {
+	DOI            string             `json:"DOI"`
+	URL            string             `json:"URL"`
+	Title          []string           `json:"title"`
+	Subtitle       []string           `json:"subtitle"`
+	Abstract       string             `json:"abstract"`
+	Author         []crossrefPerson   `json:"author"`
+	Editor         []crossrefPerson   `json:"editor"`
+	Publisher      string             `json:"publisher"`
+	Published      crossrefDate       `json:"published"`
+	PublishedPrint crossrefDate       `json:"published-print"`
+	ISBN           []string           `json:"ISBN"`
+	ISBNType       []crossrefISBNType `json:"isbn-type"`
+	Subject        []string           `json:"subject"`
+	Language       string             `json:"language"`
+	EditionNumber  string             `json:"edition-number"`
+}
+
+type crossrefPerson struct {
+	Given  string `json:"given"`
+	Family string `json:"family"`
+	Name   string `json:"name"`
+}
+
+type crossrefDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+type crossrefISBNType struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// CrossrefScrapper gets a book data from the Crossref works API. The book ID could be either a DOI or an ISBN.
+type CrossrefScrapper struct {
+	basePath string
+	client   *http.Client
+	logger   *log.Logger
+}
+
+func NewCrossrefScrapper(basePath string, logger *log.Logger) *CrossrefScrapper {
+	if basePath == "" {
+		basePath = defaultCrossrefBasePath
+	}
+
+	return &CrossrefScrapper{
+		basePath: strings.TrimSuffix(basePath, "/"),
+		client:   &http.Client{Timeout: crossrefRequestTimeout},
+		logger:   logger,
+	}
+}
+
+func (s *CrossrefScrapper) GetBookData(ctx context.Context, bookID string) (book.ParsedData, error) {
+	work, err := s.getWork(ctx, bookID)
+	if err != nil {
+		return book.ParsedData{}, err
+	}
+
+	isbn10, isbn13 := getCrossrefISBNs(work)
+	contributors := book.NewAuthors(getCrossrefPersonNames(work.Author))
+	if len(contributors) == 0 {
+		// The edited volumes have no authors
+		for _, name := range getCrossrefPersonNames(work.Editor) {
+			contributors = append(contributors, book.Contributor{Name: name, Role: parser.ContributorRoleEditor})
+		}
+	}
+
+	var edition parser.Edition
+	if editionNumber, err := strconv.Atoi(work.EditionNumber); err == nil {
+		edition = parser.NewEdition(editionNumber)
+	}
+
+	var diagnostics book.Diagnostics
+	pubDateSelector, pubDateParts := "published-print", work.PublishedPrint
+	pubDate, pubDatePrecision := pubDateParts.toTime()
+	if pubDate.IsZero() {
+		pubDateSelector, pubDateParts = "published", work.Published
+		pubDate, pubDatePrecision = pubDateParts.toTime()
+	}
+	if !pubDate.IsZero() {
+		// A year or a month only date is completed with the first day
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldPubDate, Selector: pubDateSelector,
+			Raw: fmt.Sprint(pubDateParts.DateParts[0]), Rule: "date-parts",
+			LowConfidence: pubDatePrecision != parser.DatePrecisionDay})
+	}
+	if parser.ParseLanguage(work.Language) != "" {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldLanguage, Selector: "language", Raw: work.Language,
+			Rule: "ParseLanguage"})
+	}
+	if work.Publisher != "" {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldPublisher, Selector: "publisher", Raw: work.Publisher,
+			Rule: "MapPublisherName"})
+	}
+
+	metadata := book.ParsedData{
+		Title:            firstValue(work.Title),
+		Subtitle:         firstValue(work.Subtitle),
+		ISBN10:           isbn10,
+		ISBN13:           isbn13,
+		DOI:              work.DOI,
+		Language:         parser.GetLanguageName(parser.ParseLanguage(work.Language)),
+		Publisher:        publisher.MapPublisherName(work.Publisher),
+		PublisherURL:     work.URL,
+		Edition:          edition,
+		PubDate:          pubDate,
+		PubDatePrecision: pubDatePrecision,
+		Contributors:     contributors,
+		Categories:       work.Subject,
+		Diagnostics:      diagnostics,
+	}
+	metadata.SetDescription(work.Abstract)
+	metadata.BookFileName = metadata.GetBookFileName()
+	completeDiagnostics(&metadata, CrossrefSourceName, map[string]string{
+		FieldTitle:        "title",
+		FieldSubtitle:     "subtitle",
+		FieldDescription:  "abstract",
+		FieldISBN10:       "isbn-type",
+		FieldISBN13:       "isbn-type",
+		FieldDOI:          "DOI",
+		FieldPublisherURL: "URL",
+		FieldEdition:      "edition-number",
+		FieldAuthors:      "author",
+		FieldCategories:   "subject",
+	})
+
+	return metadata, nil
+}
+
+func (s *CrossrefScrapper) Close() error {
+	return nil
+}
+
+func (s *CrossrefScrapper) getWork(ctx context.Context, bookID string) (crossrefWork, error) {
+	if isDOI(bookID) {
+		var response crossrefWorkResponse
+		// DOIs could contain '#', '?' and ';' characters
+		workURL := s.basePath + "/works/" + url.PathEscape(bookID)
+		if err := fetchJSON(ctx, s.client, workURL, &response, s.logger); err != nil {
+			return crossrefWork{}, fmt.Errorf("can not get Crossref work: %w", err)
+		}
+		return response.Message, nil
+	}
+
+	query := url.Values{}
+	query.Set("filter", "isbn:"+isbnLookupID(bookID))
+	query.Set("rows", "1")
+	var response crossrefWorksResponse
+	if err := fetchJSON(ctx, s.client, s.basePath+"/works?"+query.Encode(), &response, s.logger); err != nil {
+		return crossrefWork{}, fmt.Errorf("can not get Crossref works: %w", err)
+	}
+	if len(response.Message.Items) == 0 {
+		return crossrefWork{}, fmt.Errorf("there are no Crossref works for the ID: %q", bookID)
+	}
+
+	return response.Message.Items[0], nil
+}
+
+// toTime returns the date of the date parts, and its precision: the month and the day parts are optional.
+func (d crossrefDate) toTime() (time.Time, parser.DatePrecision) {
+	if len(d.DateParts) == 0 || len(d.DateParts[0]) == 0 {
+		return time.Time{}, parser.DatePrecisionDay
+	}
+	parts := d.DateParts[0]
+	year, month, day, precision := parts[0], 1, 1, parser.DatePrecisionYear
+	if len(parts) > 1 {
+		month, precision = parts[1], parser.DatePrecisionMonth
+	}
+	if len(parts) > 2 {
+		day, precision = parts[2], parser.DatePrecisionDay
+	}
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), precision
+}
+
+// getCrossrefISBNs picks ISBN10 and ISBN13 values, the print ISBNs are preferred over the electronic ones.
+func getCrossrefISBNs(work crossrefWork) (string, int64) {
+	isbns := make([]string, 0, len(work.ISBN))
+	for _, isbnType := range work.ISBNType {
+		if isbnType.Type == "print" {
+			isbns = append(isbns, isbnType.Value)
+		}
+	}
+	isbns = append(isbns, work.ISBN...)
+
+	var isbn10 string
+	var isbn13 int64
+	for _, value := range isbns {
+		value = isbn.Normalize(value)
+		if len(value) == 10 && isbn10 == "" {
+			isbn10 = value
+		}
+		if len(value) == 13 && isbn13 == 0 {
+			isbn13 = parseISBN13(value)
+		}
+	}
+
+	return isbn10, isbn13
+}
+
+func getCrossrefPersonNames(persons []crossrefPerson) []string {
+	names := make([]string, 0, len(persons))
+	for _, person := range persons {
+		name := strings.TrimSpace(person.Given + " " + person.Family)
+		if name == "" {
+			name = person.Name
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func isDOI(bookID string) bool {
+	return strings.HasPrefix(bookID, crossrefDOIPrefix) && strings.Contains(bookID, "/")
+}
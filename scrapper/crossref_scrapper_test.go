@@ -0,0 +1,94 @@
+package scrapper
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"testing"
+)
+
+const (
+	testBookDOI = "10.1007/978-1-4842-6579-5"
+	// DOIs could contain the URL special characters
+	testBookSpecialDOI = "10.1002/(SICI)1097-4571#1?2;3"
+
+	testCrossrefWorkResponse  = "testdata/crossref_work.json"
+	testCrossrefWorksResponse = "testdata/crossref_works.json"
+)
+
+var (
+	crossrefHandlersMap = map[string]string{
+		"/works/" + testBookDOI:        testCrossrefWorkResponse,
+		"/works/" + testBookSpecialDOI: testCrossrefWorkResponse,
+		"/works":                       testCrossrefWorksResponse,
+	}
+)
+
+func TestCrossrefScrapper_GetBookData(t *testing.T) {
+	t.Log("Given the need to test Crossref book data fetching.")
+	server := testJSONMockServer(t, crossrefHandlersMap)
+	defer server.Close()
+
+	crossrefScrapper := NewCrossrefScrapper(server.URL, log.Default())
+	for _, bookID := range []string{testBookDOI, testBookID01} {
+		t.Logf("\tWhen checking the %q book ID\n", bookID)
+		bookMeta, err := crossrefScrapper.GetBookData(context.Background(), bookID)
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+		}
+
+		testAuthors := []string{testBookAuthorName01, testBookAuthorName02}
+		if bookMeta.Title != testBookTitle {
+			t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+		}
+		if bookMeta.Subtitle != testBookSubtitle {
+			t.Fatalf("\t\t%s\tShould get a %q book subtitle: %q", failed, testBookSubtitle, bookMeta.Subtitle)
+		}
+		if bookMeta.DOI != testBookDOI {
+			t.Fatalf("\t\t%s\tShould get a %q book DOI: %q", failed, testBookDOI, bookMeta.DOI)
+		}
+		if bookMeta.ISBN10 != testBookISBN10 {
+			t.Fatalf("\t\t%s\tShould get a %q book ISBN10: %q", failed, testBookISBN10, bookMeta.ISBN10)
+		}
+		if bookMeta.ISBN13 != testBookISBN13 {
+			t.Fatalf("\t\t%s\tShould get a %d book ISBN13: %d", failed, testBookISBN13, bookMeta.ISBN13)
+		}
+		if bookMeta.Publisher != testBookPublisher {
+			t.Fatalf("\t\t%s\tShould get a %q book publisher: %q", failed, testBookPublisher, bookMeta.Publisher)
+		}
+		if bookMeta.Edition.Number != testBookEdition {
+			t.Fatalf("\t\t%s\tShould get a %d book edition: %d", failed, testBookEdition, bookMeta.Edition.Number)
+		}
+		if !bookMeta.PubDate.Equal(testBookPubDate) {
+			t.Fatalf("\t\t%s\tShould get a %v book publish date: %v", failed, testBookPubDate, bookMeta.PubDate)
+		}
+		if bookMeta.Language != testBookLanguage {
+			t.Fatalf("\t\t%s\tShould get a %q book language: %q", failed, testBookLanguage, bookMeta.Language)
+		}
+		if !reflect.DeepEqual(bookMeta.GetAuthorNames(), testAuthors) {
+			t.Fatalf("\t\t%s\tShould get %v book authors: %v", failed, testAuthors, bookMeta.GetAuthorNames())
+		}
+		if !reflect.DeepEqual(bookMeta.Categories, testBookCategories) {
+			t.Fatalf("\t\t%s\tShould get %v book categories: %v", failed, testBookCategories, bookMeta.Categories)
+		}
+	}
+
+	t.Logf("\t\t%s\tShould be able to get Crossref book data.", succeed)
+}
+
+func TestCrossrefScrapper_GetBookDataSpecialDOI(t *testing.T) {
+	t.Log("Given the need to test Crossref book data fetching by a DOI with the URL special characters.")
+	server := testJSONMockServer(t, crossrefHandlersMap)
+	defer server.Close()
+
+	crossrefScrapper := NewCrossrefScrapper(server.URL, log.Default())
+	bookMeta, err := crossrefScrapper.GetBookData(context.Background(), testBookSpecialDOI)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+	}
+	if bookMeta.Title != testBookTitle {
+		t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+	}
+
+	t.Logf("\t\t%s\tShould be able to get Crossref book data by the escaped DOI.", succeed)
+}
@@ -0,0 +1,141 @@
+package scrapper
+
+import (
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"github.com/sdreger/lib-file-processor-go/isbn"
+	"strconv"
+	"strings"
+)
+
+const fieldBookID = "ISBN10/ISBN13/ASIN"
+
+// diagnosticFields are the book data fields, which are taken from the book data sources
+var diagnosticFields = []string{
+	FieldTitle, FieldSubtitle, FieldDescription, FieldISBN10, FieldISBN13, FieldASIN, FieldDOI, FieldPages,
+	FieldLanguage, FieldPublisher, FieldPublisherURL, FieldEdition, FieldPubDate, FieldSeries, FieldAuthors,
+	FieldCategories, FieldTags, FieldCoverURL,
+}
+
+// fieldValue returns the book data field value as a string, or an empty string if the field is not set.
+func fieldValue(parsedData book.ParsedData, field string) string {
+	switch field {
+	case FieldTitle:
+		return parsedData.Title
+	case FieldSubtitle:
+		return parsedData.Subtitle
+	case FieldDescription:
+		return parsedData.Description
+	case FieldISBN10:
+		return parsedData.ISBN10
+	case FieldISBN13:
+		if parsedData.ISBN13 != 0 {
+			return strconv.FormatInt(parsedData.ISBN13, 10)
+		}
+	case FieldASIN:
+		return parsedData.ASIN
+	case FieldDOI:
+		return parsedData.DOI
+	case FieldPages:
+		if parsedData.Pages != 0 {
+			return strconv.Itoa(int(parsedData.Pages))
+		}
+	case FieldLanguage:
+		return parsedData.Language
+	case FieldPublisher:
+		return parsedData.Publisher
+	case FieldPublisherURL:
+		return parsedData.PublisherURL
+	case FieldEdition:
+		return parsedData.Edition.String()
+	case FieldPubDate:
+		if !parsedData.PubDate.IsZero() {
+			return parsedData.PubDate.Format("2006-01-02")
+		}
+	case FieldSeries:
+		return parsedData.Series
+	case FieldAuthors:
+		return strings.Join(parsedData.GetContributors(), ";")
+	case FieldCategories:
+		return strings.Join(parsedData.Categories, ";")
+	case FieldTags:
+		return strings.Join(parsedData.Tags, ";")
+	case FieldCoverURL:
+		return parsedData.CoverURL
+	}
+
+	return ""
+}
+
+// missingRequiredFields returns the names of the essential book data fields, which are not set.
+// A book should have at least one of the identifiers.
+func missingRequiredFields(parsedData book.ParsedData) []string {
+	missing := make([]string, 0)
+	for _, field := range []string{FieldTitle, FieldPublisher, FieldPubDate, FieldAuthors} {
+		if fieldValue(parsedData, field) == "" {
+			missing = append(missing, field)
+		}
+	}
+	if parsedData.ISBN10 == "" && parsedData.ISBN13 == 0 && parsedData.ASIN == "" {
+		missing = append(missing, fieldBookID)
+	}
+
+	return missing
+}
+
+// checkRequiredFields updates the missing required fields of the book data diagnostics.
+func checkRequiredFields(parsedData *book.ParsedData) {
+	parsedData.Diagnostics.MissingRequired = missingRequiredFields(*parsedData)
+}
+
+// checkIdentifiers validates the book ISBN checksums, and derives the missing ISBN13 from a valid ISBN10.
+// The ISBNs with an invalid checksum are kept as is, but flagged as low confidence ones.
+func checkIdentifiers(parsedData *book.ParsedData) {
+	if parsedData.ISBN10 != "" {
+		if err := isbn.ValidateISBN10(parsedData.ISBN10); err != nil {
+			flagLowConfidence(parsedData, FieldISBN10, err)
+		}
+	}
+	if parsedData.ISBN13 != 0 {
+		if err := isbn.ValidateISBN13(isbn.FormatISBN13(parsedData.ISBN13)); err != nil {
+			flagLowConfidence(parsedData, FieldISBN13, err)
+		}
+	}
+	if parsedData.ISBN13 == 0 && parsedData.ISBN10 != "" {
+		if isbn13, err := isbn.ToISBN13(parsedData.ISBN10); err == nil {
+			parsedData.ISBN13, _ = isbn.ParseISBN13(isbn13)
+			parsedData.Diagnostics.AddField(book.FieldDiagnostic{Field: FieldISBN13, Selector: FieldISBN10,
+				Raw: parsedData.ISBN10, Rule: "isbn.ToISBN13"})
+		}
+	}
+}
+
+// flagLowConfidence marks the field diagnostic as a low confidence one, and adds the warning.
+func flagLowConfidence(parsedData *book.ParsedData, field string, err error) {
+	diagnostic, ok := parsedData.Diagnostics.GetField(field)
+	if !ok {
+		diagnostic = book.FieldDiagnostic{Field: field, Raw: fieldValue(*parsedData, field)}
+	}
+	diagnostic.LowConfidence = true
+	parsedData.Diagnostics.AddField(diagnostic)
+	parsedData.Diagnostics.AddWarning("%s: %v", field, err)
+}
+
+// completeDiagnostics records the set fields without a diagnostic as taken as is from the given selectors
+// (like API response fields), sets the data source and checks the required fields.
+func completeDiagnostics(parsedData *book.ParsedData, source string, selectors map[string]string) {
+	checkIdentifiers(parsedData)
+	for _, field := range diagnosticFields {
+		selector, ok := selectors[field]
+		if !ok {
+			continue
+		}
+		if _, ok := parsedData.Diagnostics.GetField(field); ok {
+			continue
+		}
+		if value := fieldValue(*parsedData, field); value != "" {
+			parsedData.Diagnostics.AddField(book.FieldDiagnostic{Field: field, Selector: selector, Raw: value})
+		}
+	}
+	parsedData.Diagnostics.SetSource(source)
+	checkRequiredFields(parsedData)
+}
@@ -0,0 +1,45 @@
+package scrapper
+
+import (
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"testing"
+)
+
+func TestCheckIdentifiers(t *testing.T) {
+	t.Log("Given the need to test the book identifiers check.")
+
+	t.Logf("\t\tWhen checking a valid ISBN10 without an ISBN13\n")
+	{
+		parsedData := book.ParsedData{ISBN10: "0306406152"}
+		checkIdentifiers(&parsedData)
+		if parsedData.ISBN13 != 9780306406157 {
+			t.Fatalf("\t\t%s\tShould derive the ISBN13: %d", failed, parsedData.ISBN13)
+		}
+		if diagnostic, _ := parsedData.Diagnostics.GetField(FieldISBN13); diagnostic.Rule != "isbn.ToISBN13" {
+			t.Fatalf("\t\t%s\tShould get the derived ISBN13 diagnostic: %+v", failed, diagnostic)
+		}
+		if len(parsedData.Diagnostics.Warnings) != 0 {
+			t.Fatalf("\t\t%s\tShould not get any warnings: %v", failed, parsedData.Diagnostics.Warnings)
+		}
+		t.Logf("\t\t%s\tShould be able to derive the ISBN13", succeed)
+	}
+
+	t.Logf("\t\tWhen checking ISBNs with invalid checksums\n")
+	{
+		parsedData := book.ParsedData{ISBN10: "0306406153", ISBN13: 9780306406158}
+		parsedData.Diagnostics.AddField(book.FieldDiagnostic{Field: FieldISBN10, Selector: "details[ISBN-10]"})
+		checkIdentifiers(&parsedData)
+		if parsedData.ISBN10 != "0306406153" || parsedData.ISBN13 != 9780306406158 {
+			t.Fatalf("\t\t%s\tShould keep the invalid ISBNs: %q, %d", failed, parsedData.ISBN10, parsedData.ISBN13)
+		}
+		lowConfidence := parsedData.Diagnostics.LowConfidenceFields()
+		if len(lowConfidence) != 2 || len(parsedData.Diagnostics.Warnings) != 2 {
+			t.Fatalf("\t\t%s\tShould flag the invalid ISBNs: %v, %v", failed, lowConfidence,
+				parsedData.Diagnostics.Warnings)
+		}
+		if diagnostic, _ := parsedData.Diagnostics.GetField(FieldISBN10); diagnostic.Selector != "details[ISBN-10]" {
+			t.Fatalf("\t\t%s\tShould keep the ISBN10 diagnostic selector: %+v", failed, diagnostic)
+		}
+		t.Logf("\t\t%s\tShould be able to flag the invalid ISBNs", succeed)
+	}
+}
@@ -0,0 +1,131 @@
+package scrapper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrBlocked is returned when the storefront serves a robot check (CAPTCHA) page, or denies the access.
+	ErrBlocked = errors.New("the request is blocked by a robot check")
+	// ErrNotFound is returned when the storefront has no product page for the book ID, or the page is empty.
+	ErrNotFound = errors.New("the book page is not found")
+	// ErrRateLimited is returned when the storefront throttles the requests.
+	ErrRateLimited = errors.New("the request is rate limited")
+	// ErrSearchNotSupported is returned when none of the book data sources supports the free text search.
+	ErrSearchNotSupported = errors.New("there are no book data sources supporting search")
+)
+
+var (
+	captchaPageMarkers = [][]byte{
+		[]byte("/errors/validateCaptcha"),
+		[]byte("Type the characters you see in this image"),
+		[]byte("Geben Sie die unten angezeigten Zeichen ein"),
+		[]byte("Saisissez les caractères que vous voyez"),
+		[]byte("api-services-support@amazon.com"),
+	}
+	// "Dogs of Amazon" no such page, could be served with 200 status
+	notFoundPageMarkers = [][]byte{
+		[]byte("Sorry! We couldn't find that page"),
+		[]byte("The Web address you entered is not a functioning page on our site"),
+	}
+	// "Dogs of Amazon" throttling page, could be served with 200 status. The not found page has the dogs too,
+	// so it should be checked first.
+	dogsPageMarkers = [][]byte{
+		[]byte("Sorry! Something went wrong!"),
+		[]byte("Meet the dogs of Amazon"),
+	}
+)
+
+// SourceError is the error of a particular book data source.
+type SourceError struct {
+	Source string
+	Err    error
+}
+
+func (e SourceError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Source, e.Err)
+}
+
+func (e SourceError) Unwrap() error {
+	return e.Err
+}
+
+// SourceErrors is the list of errors of all failed book data sources. It matches any of the source errors
+// with errors.Is, like: errors.Is(err, ErrBlocked).
+type SourceErrors []SourceError
+
+func (e SourceErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, sourceError := range e {
+		messages = append(messages, sourceError.Error())
+	}
+
+	return fmt.Sprintf("all book data sources failed: %s", strings.Join(messages, "; "))
+}
+
+// Is checks if any of the source errors matches the target.
+func (e SourceErrors) Is(target error) bool {
+	for _, sourceError := range e {
+		if errors.Is(sourceError, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Unwrap returns the first source error, the other ones are matched by Is.
+func (e SourceErrors) Unwrap() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e[0]
+}
+
+// classifyResponse checks the response status code and body, and returns a typed error for the blocked,
+// rate limited, and missing pages. Returns nil for a regular product page.
+func classifyResponse(statusCode int, body []byte) error {
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusGone:
+		return fmt.Errorf("%w: response status %d", ErrNotFound, statusCode)
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return fmt.Errorf("%w: response status %d", ErrRateLimited, statusCode)
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: response status %d", ErrBlocked, statusCode)
+	default:
+		return fmt.Errorf("unexpected response status: %d", statusCode)
+	}
+
+	if containsAny(body, captchaPageMarkers) {
+		return fmt.Errorf("%w: CAPTCHA page", ErrBlocked)
+	}
+	if containsAny(body, notFoundPageMarkers) {
+		return fmt.Errorf("%w: error page", ErrNotFound)
+	}
+	if containsAny(body, dogsPageMarkers) {
+		return fmt.Errorf("%w: error page", ErrRateLimited)
+	}
+
+	return nil
+}
+
+// isRetryable checks if the request could succeed after a delay.
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrBlocked) || errors.Is(err, ErrRateLimited)
+}
+
+func containsAny(body []byte, markers [][]byte) bool {
+	for _, marker := range markers {
+		if bytes.Contains(body, marker) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,211 @@
+package scrapper
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+const (
+	testCaptchaResponse = "testdata/captcha.html"
+)
+
+func TestClassifyResponse(t *testing.T) {
+	captchaPage, err := os.ReadFile(testCaptchaResponse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	productPage, err := os.ReadFile(testBookResponse01)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       []byte
+		err        error
+	}{
+		{name: "Product page", statusCode: http.StatusOK, body: productPage, err: nil},
+		{name: "CAPTCHA page", statusCode: http.StatusOK, body: captchaPage, err: ErrBlocked},
+		{name: "Dogs page", statusCode: http.StatusOK, body: []byte("Sorry! Something went wrong!"), err: ErrRateLimited},
+		{name: "Dogs not found page", statusCode: http.StatusOK,
+			body: []byte("Sorry! We couldn't find that page. Meet the dogs of Amazon"), err: ErrNotFound},
+		{name: "Error pages link", statusCode: http.StatusOK,
+			body: append(productPage, []byte(`<a href="/error-pages/help">`)...), err: nil},
+		{name: "Not found", statusCode: http.StatusNotFound, err: ErrNotFound},
+		{name: "Too many requests", statusCode: http.StatusTooManyRequests, err: ErrRateLimited},
+		{name: "Service unavailable", statusCode: http.StatusServiceUnavailable, err: ErrRateLimited},
+		{name: "Forbidden", statusCode: http.StatusForbidden, err: ErrBlocked},
+	}
+
+	t.Log("Given the need to test response classification.")
+	for i, tt := range tests {
+		t.Logf("\tTest: %d\tWhen checking %q response\n", i, tt.name)
+		err := classifyResponse(tt.statusCode, tt.body)
+		if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+			t.Errorf("\t\t%s\tShould get a %v error: %v", failed, tt.err, err)
+		} else {
+			t.Logf("\t\t%s\tShould be able to get correct error.", succeed)
+		}
+	}
+
+	if err := classifyResponse(http.StatusInternalServerError, nil); err == nil {
+		t.Errorf("\t\t%s\tShould get an error for unexpected status", failed)
+	}
+}
+
+func TestAmazonScrapper_GetBookDataRetry(t *testing.T) {
+	t.Log("Given the need to test blocked request retries.")
+	t.Run("Blocked request is retried", testAmazonScrapperBlockedRetry)
+	t.Run("Missing page is not retried", testAmazonScrapperNotFoundNoRetry)
+	t.Run("Empty page is not found", testAmazonScrapperEmptyPage)
+}
+
+func testAmazonScrapperBlockedRetry(t *testing.T) {
+	t.Logf("\t\tWhen checking for a CAPTCHA page\n")
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requestCount++
+		if requestCount < 3 {
+			http.ServeFile(rw, req, testCaptchaResponse)
+			return
+		}
+		http.ServeFile(rw, req, testBookResponse01)
+	}))
+	defer server.Close()
+
+	amazonScrapper := testRetryAmazonScrapper(t, server.URL+"/", 2)
+	bookMeta, err := amazonScrapper.GetBookData(context.Background(), testBookID01)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get book data after retries: %v", failed, err)
+	}
+	if bookMeta.Title != testBookTitle || requestCount != 3 {
+		t.Fatalf("\t\t%s\tShould get a %q book title after 3 requests: %q, %d", failed, testBookTitle,
+			bookMeta.Title, requestCount)
+	}
+
+	requestCount = -10
+	if _, err = amazonScrapper.GetBookData(context.Background(), testBookID01); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("\t\t%s\tShould get a %v error after all retries: %v", failed, ErrBlocked, err)
+	}
+
+	t.Logf("\t\t%s\tShould be able to retry blocked requests", succeed)
+}
+
+func testAmazonScrapperNotFoundNoRetry(t *testing.T) {
+	t.Logf("\t\tWhen checking for a missing page\n")
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requestCount++
+		http.NotFound(rw, req)
+	}))
+	defer server.Close()
+
+	amazonScrapper := testRetryAmazonScrapper(t, server.URL+"/", 2)
+	if _, err := amazonScrapper.GetBookData(context.Background(), testBookID01); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("\t\t%s\tShould get a %v error: %v", failed, ErrNotFound, err)
+	}
+	if requestCount != 1 {
+		t.Fatalf("\t\t%s\tShould not retry a missing page: %d requests", failed, requestCount)
+	}
+
+	t.Logf("\t\t%s\tShould not retry missing pages", succeed)
+}
+
+func testAmazonScrapperEmptyPage(t *testing.T) {
+	t.Logf("\t\tWhen checking for an empty product page\n")
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/html")
+		_, _ = rw.Write([]byte("<html><body></body></html>"))
+	}))
+	defer server.Close()
+
+	amazonScrapper := testRetryAmazonScrapper(t, server.URL+"/", 2)
+	if _, err := amazonScrapper.GetBookData(context.Background(), testBookID01); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("\t\t%s\tShould get a %v error: %v", failed, ErrNotFound, err)
+	}
+
+	t.Logf("\t\t%s\tShould get an error for an empty page", succeed)
+}
+
+func testRetryAmazonScrapper(t *testing.T, basePath string, maxRetries int) *AmazonScrapper {
+	amazonScrapper, err := NewAmazonScrapper(basePath, log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+	}
+	amazonScrapper.SetRetryPolicy(maxRetries, time.Millisecond)
+	amazonScrapper.SetMinRequestDelay(0)
+
+	return amazonScrapper
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	t.Log("Given the need to test retry backoff delays.")
+	policy := retryPolicy{maxRetries: 5, baseDelay: time.Second, maxDelay: 5 * time.Second}
+	for attempt, maxDelay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		delay := policy.delay(attempt)
+		if delay < maxDelay/2 || delay > maxDelay {
+			t.Fatalf("\t\t%s\tShould get a delay in [%s, %s] range: %s", failed, maxDelay/2, maxDelay, delay)
+		}
+	}
+
+	t.Logf("\t\t%s\tShould be able to get exponential backoff delays", succeed)
+}
+
+func TestThrottledTransport_MinDelay(t *testing.T) {
+	t.Log("Given the need to test the minimum delay between requests.")
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	minDelay := 50 * time.Millisecond
+	client := &http.Client{Transport: &throttledTransport{transport: http.DefaultTransport, minDelay: minDelay}}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		response, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to send a request: %v", failed, err)
+		}
+		_ = response.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 2*minDelay {
+		t.Fatalf("\t\t%s\tShould wait at least %s between requests: %s total", failed, minDelay, elapsed)
+	}
+
+	t.Logf("\t\t%s\tShould be able to delay requests", succeed)
+}
+
+func TestThrottledTransport_Cancel(t *testing.T) {
+	t.Log("Given the need to test the cancelled request throttling.")
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	minDelay := time.Minute
+	client := &http.Client{Transport: &throttledTransport{transport: http.DefaultTransport, minDelay: minDelay}}
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to send a request: %v", failed, err)
+	}
+	_ = response.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a request: %v", failed, err)
+	}
+	start := time.Now()
+	if _, err := client.Do(request); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("\t\t%s\tShould get a %v error: %v", failed, context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("\t\t%s\tShould stop waiting after the request cancellation: %s", failed, elapsed)
+	}
+
+	t.Logf("\t\t%s\tShould be able to stop waiting for a cancelled request", succeed)
+}
@@ -0,0 +1,247 @@
+package scrapper
+
+import (
+	"context"
+	"fmt"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"github.com/sdreger/lib-file-processor-go/domain/publisher"
+	"github.com/sdreger/lib-file-processor-go/isbn"
+	"github.com/sdreger/lib-file-processor-go/parser"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	GoogleBooksSourceName = "googlebooks"
+
+	defaultGoogleBooksBasePath = "https://www.googleapis.com/books/v1"
+
+	googleBooksRequestTimeout = 15 * time.Second
+	googleBooksISBN10Type     = "ISBN_10"
+	googleBooksISBN13Type     = "ISBN_13"
+	googleBooksMaxResults     = 40
+)
+
+type googleBooksResponse struct {
+	TotalItems int               `json:"totalItems"`
+	Items      []googleBooksItem `json:"items"`
+}
+
+type googleBooksItem struct {
+	VolumeInfo googleBooksVolumeInfo `json:"volumeInfo"`
+}
+
+type googleBooksVolumeInfo struct {
+	Title               string                  `json:"title"`
+	Subtitle            string                  `json:"subtitle"`
+	Authors             []string                `json:"authors"`
+	Publisher           string                  `json:"publisher"`
+	PublishedDate       string                  `json:"publishedDate"`
+	Description         string                  `json:"description"`
+	IndustryIdentifiers []googleBooksIdentifier `json:"industryIdentifiers"`
+	PageCount           uint16                  `json:"pageCount"`
+	Categories          []string                `json:"categories"`
+	Language            string                  `json:"language"`
+	ImageLinks          map[string]string       `json:"imageLinks"`
+	InfoLink            string                  `json:"infoLink"`
+}
+
+type googleBooksIdentifier struct {
+	Type       string `json:"type"`
+	Identifier string `json:"identifier"`
+}
+
+// GoogleBooksScrapper gets a book data from the Google Books volumes API. The API key is optional.
+type GoogleBooksScrapper struct {
+	basePath string
+	apiKey   string
+	client   *http.Client
+	logger   *log.Logger
+}
+
+func NewGoogleBooksScrapper(basePath, apiKey string, logger *log.Logger) *GoogleBooksScrapper {
+	if basePath == "" {
+		basePath = defaultGoogleBooksBasePath
+	}
+
+	return &GoogleBooksScrapper{
+		basePath: strings.TrimSuffix(basePath, "/"),
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: googleBooksRequestTimeout},
+		logger:   logger,
+	}
+}
+
+func (s *GoogleBooksScrapper) GetBookData(ctx context.Context, bookID string) (book.ParsedData, error) {
+	query := url.Values{}
+	query.Set("q", "isbn:"+isbnLookupID(bookID))
+	if s.apiKey != "" {
+		query.Set("key", s.apiKey)
+	}
+
+	var response googleBooksResponse
+	if err := fetchJSON(ctx, s.client, s.basePath+"/volumes?"+query.Encode(), &response, s.logger); err != nil {
+		return book.ParsedData{}, fmt.Errorf("can not get Google Books volume: %w", err)
+	}
+	if len(response.Items) == 0 {
+		return book.ParsedData{}, fmt.Errorf("there are no Google Books volumes for the ID: %q", bookID)
+	}
+	volumeInfo := response.Items[0].VolumeInfo
+
+	var isbn10, isbn13String string
+	for _, identifier := range volumeInfo.IndustryIdentifiers {
+		switch identifier.Type {
+		case googleBooksISBN10Type:
+			isbn10 = identifier.Identifier
+		case googleBooksISBN13Type:
+			isbn13String = identifier.Identifier
+		}
+	}
+
+	var diagnostics book.Diagnostics
+	pubDate, pubDatePrecision, err := parseGoogleBooksDate(volumeInfo.PublishedDate)
+	if err != nil {
+		s.logger.Printf("[WARN] - %v", err)
+		diagnostics.AddWarning("%v", err)
+	}
+	if !pubDate.IsZero() {
+		// A year or a month only date is completed with the first day
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldPubDate, Selector: "volumeInfo.publishedDate",
+			Raw: volumeInfo.PublishedDate, Rule: "parseGoogleBooksDate",
+			LowConfidence: pubDatePrecision != parser.DatePrecisionDay})
+	}
+
+	title, subtitle := volumeInfo.Title, volumeInfo.Subtitle
+	edition, err := parser.ParseEdition(title + " " + subtitle)
+	if err != nil {
+		s.logger.Printf("[WARN] - %v", err)
+	}
+	if !edition.IsEmpty() {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldEdition, Selector: "volumeInfo.title",
+			Raw: title + " " + subtitle, Rule: "ParseEdition"})
+	}
+	if parser.ParseLanguage(volumeInfo.Language) != "" {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldLanguage, Selector: "volumeInfo.language",
+			Raw: volumeInfo.Language, Rule: "ParseLanguage"})
+	}
+	if volumeInfo.Publisher != "" {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldPublisher, Selector: "volumeInfo.publisher",
+			Raw: volumeInfo.Publisher, Rule: "MapPublisherName"})
+	}
+
+	metadata := book.ParsedData{
+		Title:            title,
+		Subtitle:         subtitle,
+		ISBN10:           isbn10,
+		ISBN13:           parseISBN13(isbn13String),
+		Pages:            volumeInfo.PageCount,
+		Language:         parser.GetLanguageName(parser.ParseLanguage(volumeInfo.Language)),
+		Publisher:        publisher.MapPublisherName(volumeInfo.Publisher),
+		PublisherURL:     volumeInfo.InfoLink,
+		Edition:          edition,
+		PubDate:          pubDate,
+		PubDatePrecision: pubDatePrecision,
+		Contributors:     book.NewAuthors(volumeInfo.Authors),
+		Categories:       volumeInfo.Categories,
+		CoverURL:         getGoogleBooksCoverURL(volumeInfo.ImageLinks),
+		Diagnostics:      diagnostics,
+	}
+	metadata.SetDescription(volumeInfo.Description)
+	metadata.CoverFileName = fmt.Sprint(metadata.GetPrimaryId(), getCoverExtension(metadata.CoverURL))
+	metadata.BookFileName = metadata.GetBookFileName()
+	completeDiagnostics(&metadata, GoogleBooksSourceName, map[string]string{
+		FieldTitle:        "volumeInfo.title",
+		FieldSubtitle:     "volumeInfo.subtitle",
+		FieldDescription:  "volumeInfo.description",
+		FieldISBN10:       "volumeInfo.industryIdentifiers",
+		FieldISBN13:       "volumeInfo.industryIdentifiers",
+		FieldPages:        "volumeInfo.pageCount",
+		FieldPublisherURL: "volumeInfo.infoLink",
+		FieldAuthors:      "volumeInfo.authors",
+		FieldCategories:   "volumeInfo.categories",
+		FieldCoverURL:     "volumeInfo.imageLinks",
+	})
+
+	return metadata, nil
+}
+
+// SearchBooks searches Google Books volumes by a free text. Volumes without an ISBN10 are skipped.
+func (s *GoogleBooksScrapper) SearchBooks(ctx context.Context, query string, limit int) ([]SearchCandidate, error) {
+	values := url.Values{}
+	values.Set("q", query)
+	values.Set("printType", "books")
+	// The API limits the page size to 40 results
+	if limit > 0 && limit <= googleBooksMaxResults {
+		values.Set("maxResults", strconv.Itoa(limit))
+	}
+	if s.apiKey != "" {
+		values.Set("key", s.apiKey)
+	}
+
+	var response googleBooksResponse
+	if err := fetchJSON(ctx, s.client, s.basePath+"/volumes?"+values.Encode(), &response, s.logger); err != nil {
+		return nil, fmt.Errorf("can not search Google Books volumes: %w", err)
+	}
+
+	candidates := make([]SearchCandidate, 0, len(response.Items))
+	for _, item := range response.Items {
+		volumeInfo := item.VolumeInfo
+		var bookID string
+		for _, identifier := range volumeInfo.IndustryIdentifiers {
+			if identifier.Type == googleBooksISBN10Type {
+				bookID = isbn.Normalize(identifier.Identifier)
+			}
+		}
+		// The ISBN13 only volumes are searched by the converted ISBN10
+		for _, identifier := range volumeInfo.IndustryIdentifiers {
+			if identifier.Type == googleBooksISBN13Type && bookID == "" {
+				bookID, _ = isbn.ToISBN10(identifier.Identifier)
+			}
+		}
+		if bookID == "" {
+			continue
+		}
+		var year int
+		if pubDate, _, err := parseGoogleBooksDate(volumeInfo.PublishedDate); err == nil {
+			year = pubDate.Year()
+		}
+		candidates = append(candidates, SearchCandidate{
+			BookID:   bookID,
+			Title:    volumeInfo.Title,
+			Authors:  volumeInfo.Authors,
+			Year:     year,
+			CoverURL: getGoogleBooksCoverURL(volumeInfo.ImageLinks),
+		})
+	}
+
+	return candidates, nil
+}
+
+func (s *GoogleBooksScrapper) Close() error {
+	return nil
+}
+
+// getGoogleBooksCoverURL returns the largest available cover image link.
+func getGoogleBooksCoverURL(imageLinks map[string]string) string {
+	for _, size := range []string{"extraLarge", "large", "medium", "small", "thumbnail", "smallThumbnail"} {
+		if link, ok := imageLinks[size]; ok && link != "" {
+			return strings.Replace(link, "http://", "https://", 1)
+		}
+	}
+
+	return ""
+}
+
+// parseGoogleBooksDate parses the Google Books date, like: '2020-10-01', or a partial one: '2020-10' / '2020'.
+func parseGoogleBooksDate(dateString string) (time.Time, parser.DatePrecision, error) {
+	date, precision, err := parser.ParsePartialDateString(dateString, parser.LanguageEnglish)
+	if err != nil {
+		return time.Time{}, precision, fmt.Errorf("the Google Books date string %q can not be parsed", dateString)
+	}
+
+	return date, precision, nil
+}
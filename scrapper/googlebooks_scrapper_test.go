@@ -0,0 +1,104 @@
+package scrapper
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const (
+	testGoogleBooksAPIKey   = "test-api-key"
+	testGoogleBooksCoverURL = "https://books.google.com/books/content?id=TestVolumeID&zoom=1"
+
+	testGoogleBooksVolumesResponse = "testdata/googlebooks_volumes.json"
+	testGoogleBooksEmptyResponse   = "testdata/googlebooks_empty.json"
+)
+
+func TestGoogleBooksScrapper_GetBookData(t *testing.T) {
+	t.Log("Given the need to test Google Books book data fetching.")
+	server := testJSONMockServer(t, map[string]string{"/volumes": testGoogleBooksVolumesResponse})
+	defer server.Close()
+
+	googleBooksScrapper := NewGoogleBooksScrapper(server.URL, "", log.Default())
+	bookMeta, err := googleBooksScrapper.GetBookData(context.Background(), testBookID01)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+	}
+
+	if bookMeta.Title != testBookTitle {
+		t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+	}
+	if bookMeta.ISBN10 != testBookISBN10 {
+		t.Fatalf("\t\t%s\tShould get a %q book ISBN10: %q", failed, testBookISBN10, bookMeta.ISBN10)
+	}
+	if bookMeta.ISBN13 != testBookISBN13 {
+		t.Fatalf("\t\t%s\tShould get a %d book ISBN13: %d", failed, testBookISBN13, bookMeta.ISBN13)
+	}
+	if bookMeta.Pages != testBookPages {
+		t.Fatalf("\t\t%s\tShould get a %d book pages: %d", failed, testBookPages, bookMeta.Pages)
+	}
+	if bookMeta.Language != testBookLanguage {
+		t.Fatalf("\t\t%s\tShould get a %q book language: %q", failed, testBookLanguage, bookMeta.Language)
+	}
+	if bookMeta.Publisher != testBookPublisher {
+		t.Fatalf("\t\t%s\tShould get a %q book publisher: %q", failed, testBookPublisher, bookMeta.Publisher)
+	}
+	if bookMeta.Edition.Number != testBookEdition {
+		t.Fatalf("\t\t%s\tShould get a %d book edition: %d", failed, testBookEdition, bookMeta.Edition.Number)
+	}
+	if !bookMeta.PubDate.Equal(testBookPubDate) {
+		t.Fatalf("\t\t%s\tShould get a %v book publish date: %v", failed, testBookPubDate, bookMeta.PubDate)
+	}
+	if !reflect.DeepEqual(bookMeta.GetAuthorNames(), testBookAuthors) {
+		t.Fatalf("\t\t%s\tShould get %v book authors: %v", failed, testBookAuthors, bookMeta.GetAuthorNames())
+	}
+	if !reflect.DeepEqual(bookMeta.Categories, testBookCategories) {
+		t.Fatalf("\t\t%s\tShould get %v book categories: %v", failed, testBookCategories, bookMeta.Categories)
+	}
+	if bookMeta.CoverURL != testGoogleBooksCoverURL {
+		t.Fatalf("\t\t%s\tShould get a %q book cover URL: %q", failed, testGoogleBooksCoverURL, bookMeta.CoverURL)
+	}
+
+	t.Logf("\t\t%s\tShould be able to get Google Books book data.", succeed)
+}
+
+func TestGoogleBooksScrapper_GetBookDataWithAPIKey(t *testing.T) {
+	t.Log("Given the need to test Google Books API key usage.")
+	var query string
+	var apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		query = req.URL.Query().Get("q")
+		apiKey = req.URL.Query().Get("key")
+		http.ServeFile(rw, req, testGoogleBooksVolumesResponse)
+	}))
+	defer server.Close()
+
+	googleBooksScrapper := NewGoogleBooksScrapper(server.URL, testGoogleBooksAPIKey, log.Default())
+	if _, err := googleBooksScrapper.GetBookData(context.Background(), testBookID01); err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+	}
+	if query != "isbn:"+testBookID01 {
+		t.Fatalf("\t\t%s\tShould get a %q query: %q", failed, "isbn:"+testBookID01, query)
+	}
+	if apiKey != testGoogleBooksAPIKey {
+		t.Fatalf("\t\t%s\tShould get a %q API key: %q", failed, testGoogleBooksAPIKey, apiKey)
+	}
+
+	t.Logf("\t\t%s\tShould be able to pass the API key.", succeed)
+}
+
+func TestGoogleBooksScrapper_GetBookDataNotFound(t *testing.T) {
+	t.Log("Given the need to test Google Books unknown book fetching.")
+	server := testJSONMockServer(t, map[string]string{"/volumes": testGoogleBooksEmptyResponse})
+	defer server.Close()
+
+	googleBooksScrapper := NewGoogleBooksScrapper(server.URL, "", log.Default())
+	if _, err := googleBooksScrapper.GetBookData(context.Background(), testBookID01); err == nil {
+		t.Fatalf("\t\t%s\tShould get an error for an unknown book", failed)
+	}
+
+	t.Logf("\t\t%s\tShould get an error for an unknown book.", succeed)
+}
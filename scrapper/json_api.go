@@ -0,0 +1,41 @@
+package scrapper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// fetchJSON performs a GET request to the provided URL, and decodes a JSON response body into the target value.
+func fetchJSON(ctx context.Context, client *http.Client, url string, target any, logger *log.Logger) error {
+	logger.Printf("[INFO] - Visiting: %q", url)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer closeResponseBody(response.Body, logger)
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status for %q: %s", url, response.Status)
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(target); err != nil {
+		return fmt.Errorf("can not decode %q response: %w", url, err)
+	}
+
+	return nil
+}
+
+func closeResponseBody(body io.Closer, logger *log.Logger) {
+	err := body.Close()
+	if err != nil {
+		logger.Printf("[ERROR] - %v", err)
+	}
+}
@@ -1,17 +1,23 @@
 package scrapper
 
+import "github.com/sdreger/lib-file-processor-go/domain/book"
+
 type scrappedRawData struct {
-	authors         []string
+	contributors    []book.Contributor
 	categories      []string
 	tags            []string
 	detailsBlock    map[string]string
 	detailsCarousel map[string]string
+	formatLinks     map[string]string
+	authorLinks     map[string]string
 	titleString     string
 	subtitleString  string
+	seriesString    string
 	description     string
 	ISBN10String    string
 	ISBN13String    string
 	coverURL        string
+	responseErr     error
 }
 
 func newScrappedRawData() scrappedRawData {
@@ -21,15 +27,19 @@ func newScrappedRawData() scrappedRawData {
 }
 
 func (srd *scrappedRawData) init() {
-	srd.authors = make([]string, 0)
+	srd.contributors = make([]book.Contributor, 0)
 	srd.categories = make([]string, 0)
 	srd.tags = make([]string, 0)
 	srd.detailsBlock = make(map[string]string)
 	srd.detailsCarousel = make(map[string]string)
+	srd.formatLinks = make(map[string]string)
+	srd.authorLinks = make(map[string]string)
 	srd.titleString = ""
 	srd.subtitleString = ""
+	srd.seriesString = ""
 	srd.description = ""
 	srd.ISBN10String = ""
 	srd.ISBN13String = ""
 	srd.coverURL = ""
+	srd.responseErr = nil
 }
@@ -0,0 +1,331 @@
+package scrapper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"github.com/sdreger/lib-file-processor-go/domain/publisher"
+	"github.com/sdreger/lib-file-processor-go/isbn"
+	"github.com/sdreger/lib-file-processor-go/parser"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	OpenLibrarySourceName = "openlibrary"
+
+	defaultOpenLibraryBasePath      = "https://openlibrary.org"
+	defaultOpenLibraryCoverBasePath = "https://covers.openlibrary.org"
+
+	openLibraryRequestTimeout = 15 * time.Second
+)
+
+type openLibraryKey struct {
+	Key string `json:"key"`
+}
+
+type openLibraryEdition struct {
+	Title         string           `json:"title"`
+	Subtitle      string           `json:"subtitle"`
+	Authors       []openLibraryKey `json:"authors"`
+	Works         []openLibraryKey `json:"works"`
+	Publishers    []string         `json:"publishers"`
+	PublishDate   string           `json:"publish_date"`
+	NumberOfPages uint16           `json:"number_of_pages"`
+	EditionName   string           `json:"edition_name"`
+	Subjects      []string         `json:"subjects"`
+	Covers        []int64          `json:"covers"`
+	Languages     []openLibraryKey `json:"languages"`
+	ISBN10        []string         `json:"isbn_10"`
+	ISBN13        []string         `json:"isbn_13"`
+}
+
+type openLibraryWork struct {
+	Title       string           `json:"title"`
+	Description openLibraryText  `json:"description"`
+	Subjects    []string         `json:"subjects"`
+	Covers      []int64          `json:"covers"`
+	Authors     []openLibraryRef `json:"authors"`
+}
+
+type openLibraryRef struct {
+	Author openLibraryKey `json:"author"`
+}
+
+type openLibrarySearchResponse struct {
+	Docs []openLibrarySearchDoc `json:"docs"`
+}
+
+type openLibrarySearchDoc struct {
+	Title            string   `json:"title"`
+	AuthorName       []string `json:"author_name"`
+	FirstPublishYear int      `json:"first_publish_year"`
+	ISBN             []string `json:"isbn"`
+	CoverID          int64    `json:"cover_i"`
+}
+
+type openLibraryAuthor struct {
+	Name string `json:"name"`
+}
+
+// openLibraryText handles text fields, which could be either a plain string, or a typed '{"value": "..."}' object.
+type openLibraryText string
+
+func (t *openLibraryText) UnmarshalJSON(data []byte) error {
+	var plain string
+	if err := json.Unmarshal(data, &plain); err == nil {
+		*t = openLibraryText(plain)
+		return nil
+	}
+
+	var typed struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	*t = openLibraryText(typed.Value)
+
+	return nil
+}
+
+// OpenLibraryScrapper gets a book data from the Open Library books/works JSON API.
+type OpenLibraryScrapper struct {
+	basePath      string
+	coverBasePath string
+	client        *http.Client
+	logger        *log.Logger
+}
+
+func NewOpenLibraryScrapper(basePath, coverBasePath string, logger *log.Logger) *OpenLibraryScrapper {
+	if basePath == "" {
+		basePath = defaultOpenLibraryBasePath
+	}
+	if coverBasePath == "" {
+		coverBasePath = defaultOpenLibraryCoverBasePath
+	}
+
+	return &OpenLibraryScrapper{
+		basePath:      strings.TrimSuffix(basePath, "/"),
+		coverBasePath: strings.TrimSuffix(coverBasePath, "/"),
+		client:        &http.Client{Timeout: openLibraryRequestTimeout},
+		logger:        logger,
+	}
+}
+
+func (s *OpenLibraryScrapper) GetBookData(ctx context.Context, bookID string) (book.ParsedData, error) {
+	lookupID := isbnLookupID(bookID)
+	var edition openLibraryEdition
+	if err := fetchJSON(ctx, s.client, fmt.Sprintf("%s/isbn/%s.json", s.basePath, lookupID), &edition,
+		s.logger); err != nil {
+		return book.ParsedData{}, fmt.Errorf("can not get Open Library edition: %w", err)
+	}
+
+	var work openLibraryWork
+	if len(edition.Works) > 0 {
+		if err := fetchJSON(ctx, s.client, s.basePath+edition.Works[0].Key+".json", &work, s.logger); err != nil {
+			s.logger.Printf("[WARN] - Can not get Open Library work: %v", err)
+		}
+	}
+
+	authorKeys := make([]string, 0)
+	for _, author := range edition.Authors {
+		authorKeys = append(authorKeys, author.Key)
+	}
+	if len(authorKeys) == 0 {
+		for _, author := range work.Authors {
+			authorKeys = append(authorKeys, author.Author.Key)
+		}
+	}
+	authors := s.getAuthorNames(ctx, authorKeys)
+
+	title := edition.Title
+	if title == "" {
+		title = work.Title
+	}
+
+	var publisherName string
+	if len(edition.Publishers) > 0 {
+		publisherName = publisher.MapPublisherName(edition.Publishers[0])
+	}
+
+	var diagnostics book.Diagnostics
+	pubDate, pubDatePrecision, err := parser.ParsePartialDateString(edition.PublishDate, parser.LanguageEnglish)
+	if err != nil {
+		s.logger.Printf("[WARN] - %v", err)
+		diagnostics.AddWarning("%v", err)
+	} else {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldPubDate, Selector: "publish_date",
+			Raw: edition.PublishDate, Rule: "ParsePartialDateString"})
+	}
+
+	editionMeta, err := parser.ParseEdition(edition.EditionName)
+	if err != nil {
+		s.logger.Printf("[WARN] - %v", err)
+	}
+	if len(edition.Publishers) > 0 {
+		diagnostics.AddField(book.FieldDiagnostic{Field: FieldPublisher, Selector: "publishers",
+			Raw: edition.Publishers[0], Rule: "MapPublisherName"})
+	}
+
+	categories := edition.Subjects
+	if len(categories) == 0 {
+		categories = work.Subjects
+	}
+
+	covers := edition.Covers
+	if len(covers) == 0 {
+		covers = work.Covers
+	}
+
+	metadata := book.ParsedData{
+		Title:            title,
+		Subtitle:         edition.Subtitle,
+		ISBN10:           firstValue(edition.ISBN10),
+		ISBN13:           parseISBN13(firstValue(edition.ISBN13)),
+		Pages:            edition.NumberOfPages,
+		Language:         getOpenLibraryLanguage(edition.Languages),
+		Publisher:        publisherName,
+		PublisherURL:     fmt.Sprintf("%s/isbn/%s", s.basePath, lookupID),
+		Edition:          editionMeta,
+		PubDate:          pubDate,
+		PubDatePrecision: pubDatePrecision,
+		Contributors:     book.NewAuthors(authors),
+		Categories:       categories,
+		CoverURL:         s.getCoverURL(covers),
+		Diagnostics:      diagnostics,
+	}
+	metadata.SetDescription(string(work.Description))
+	metadata.CoverFileName = fmt.Sprint(metadata.GetPrimaryId(), getCoverExtension(metadata.CoverURL))
+	metadata.BookFileName = metadata.GetBookFileName()
+	completeDiagnostics(&metadata, OpenLibrarySourceName, map[string]string{
+		FieldTitle:        "title",
+		FieldSubtitle:     "subtitle",
+		FieldDescription:  "work.description",
+		FieldISBN10:       "isbn_10",
+		FieldISBN13:       "isbn_13",
+		FieldPages:        "number_of_pages",
+		FieldLanguage:     "languages",
+		FieldPublisherURL: "isbn",
+		FieldEdition:      "edition_name",
+		FieldAuthors:      "authors",
+		FieldCategories:   "subjects",
+		FieldCoverURL:     "covers",
+	})
+
+	return metadata, nil
+}
+
+// SearchBooks searches Open Library works by a free text. Works without an ISBN10 are skipped.
+func (s *OpenLibraryScrapper) SearchBooks(ctx context.Context, query string, limit int) ([]SearchCandidate, error) {
+	values := url.Values{}
+	values.Set("q", query)
+	values.Set("fields", "title,author_name,first_publish_year,isbn,cover_i")
+	values.Set("limit", strconv.Itoa(limit))
+
+	var response openLibrarySearchResponse
+	if err := fetchJSON(ctx, s.client, s.basePath+"/search.json?"+values.Encode(), &response, s.logger); err != nil {
+		return nil, fmt.Errorf("can not search Open Library books: %w", err)
+	}
+
+	candidates := make([]SearchCandidate, 0, len(response.Docs))
+	for _, doc := range response.Docs {
+		bookID := firstISBN10(doc.ISBN)
+		if bookID == "" {
+			continue
+		}
+		candidates = append(candidates, SearchCandidate{
+			BookID:   bookID,
+			Title:    doc.Title,
+			Authors:  doc.AuthorName,
+			Year:     doc.FirstPublishYear,
+			CoverURL: s.getCoverURL([]int64{doc.CoverID}),
+		})
+	}
+
+	return candidates, nil
+}
+
+func (s *OpenLibraryScrapper) Close() error {
+	return nil
+}
+
+func (s *OpenLibraryScrapper) getAuthorNames(ctx context.Context, authorKeys []string) []string {
+	authors := make([]string, 0, len(authorKeys))
+	for _, key := range authorKeys {
+		var author openLibraryAuthor
+		if err := fetchJSON(ctx, s.client, s.basePath+key+".json", &author, s.logger); err != nil {
+			s.logger.Printf("[WARN] - Can not get Open Library author: %v", err)
+			continue
+		}
+		if author.Name != "" {
+			authors = append(authors, author.Name)
+		}
+	}
+
+	return authors
+}
+
+func (s *OpenLibraryScrapper) getCoverURL(covers []int64) string {
+	for _, coverID := range covers {
+		// Negative IDs are used by Open Library for removed covers
+		if coverID > 0 {
+			return fmt.Sprintf("%s/b/id/%d-L.jpg", s.coverBasePath, coverID)
+		}
+	}
+
+	return ""
+}
+
+func getOpenLibraryLanguage(languages []openLibraryKey) string {
+	if len(languages) == 0 {
+		return ""
+	}
+
+	return parser.GetLanguageName(parser.ParseLanguage(languages[0].Key))
+}
+
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// firstISBN10 returns the first 10-character identifier from the list of ISBN10 and ISBN13 values.
+// If there is none, the first '978' prefixed ISBN13 is converted to the ISBN10.
+func firstISBN10(isbns []string) string {
+	for _, value := range isbns {
+		if value = isbn.Normalize(value); len(value) == 10 {
+			return value
+		}
+	}
+	for _, value := range isbns {
+		if isbn10, err := isbn.ToISBN10(value); err == nil {
+			return isbn10
+		}
+	}
+
+	return ""
+}
+
+// parseISBN13 converts an ISBN13 string (possibly hyphenated) into a numeric value. Returns 0 for invalid values.
+// The checksum is not checked here, the invalid ISBNs are reported by the checkIdentifiers.
+func parseISBN13(isbn13String string) int64 {
+	isbn13String = isbn.Normalize(isbn13String)
+	if len(isbn13String) != 13 {
+		return 0
+	}
+	isbn13, err := strconv.ParseInt(isbn13String, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return isbn13
+}
@@ -0,0 +1,143 @@
+package scrapper
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+const (
+	testOpenLibraryCoverURL = "/b/id/12345-L.jpg"
+
+	testOpenLibraryEditionResponse  = "testdata/openlibrary_edition.json"
+	testOpenLibraryWorkResponse     = "testdata/openlibrary_work.json"
+	testOpenLibraryAuthor01Response = "testdata/openlibrary_author_01.json"
+	testOpenLibraryAuthor02Response = "testdata/openlibrary_author_02.json"
+)
+
+var (
+	openLibraryHandlersMap = map[string]string{
+		"/isbn/" + testBookID01 + ".json": testOpenLibraryEditionResponse,
+		"/works/OL1W.json":                testOpenLibraryWorkResponse,
+		"/authors/OL1A.json":              testOpenLibraryAuthor01Response,
+		"/authors/OL2A.json":              testOpenLibraryAuthor02Response,
+	}
+)
+
+func TestOpenLibraryScrapper_GetBookData(t *testing.T) {
+	t.Log("Given the need to test Open Library book data fetching.")
+	server := testJSONMockServer(t, openLibraryHandlersMap)
+	defer server.Close()
+
+	openLibraryScrapper := NewOpenLibraryScrapper(server.URL, server.URL, log.Default())
+	bookMeta, err := openLibraryScrapper.GetBookData(context.Background(), testBookID01)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+	}
+
+	testAuthors := []string{testBookAuthorName01, testBookAuthorName02}
+	testCoverURL := server.URL + testOpenLibraryCoverURL
+	if bookMeta.Title != testBookTitle {
+		t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+	}
+	if bookMeta.Subtitle != testBookSubtitle {
+		t.Fatalf("\t\t%s\tShould get a %q book subtitle: %q", failed, testBookSubtitle, bookMeta.Subtitle)
+	}
+	if bookMeta.Description != "<p>Test description</p>" {
+		t.Fatalf("\t\t%s\tShould get a %q book description: %q", failed, "<p>Test description</p>",
+			bookMeta.Description)
+	}
+	if bookMeta.ISBN10 != testBookISBN10 {
+		t.Fatalf("\t\t%s\tShould get a %q book ISBN10: %q", failed, testBookISBN10, bookMeta.ISBN10)
+	}
+	if bookMeta.ISBN13 != testBookISBN13 {
+		t.Fatalf("\t\t%s\tShould get a %d book ISBN13: %d", failed, testBookISBN13, bookMeta.ISBN13)
+	}
+	if bookMeta.Pages != testBookPages {
+		t.Fatalf("\t\t%s\tShould get a %d book pages: %d", failed, testBookPages, bookMeta.Pages)
+	}
+	if bookMeta.Language != testBookLanguage {
+		t.Fatalf("\t\t%s\tShould get a %q book language: %q", failed, testBookLanguage, bookMeta.Language)
+	}
+	if bookMeta.Publisher != testBookPublisher {
+		t.Fatalf("\t\t%s\tShould get a %q book publisher: %q", failed, testBookPublisher, bookMeta.Publisher)
+	}
+	if bookMeta.Edition.Number != testBookEdition {
+		t.Fatalf("\t\t%s\tShould get a %d book edition: %d", failed, testBookEdition, bookMeta.Edition.Number)
+	}
+	if !bookMeta.PubDate.Equal(testBookPubDate) {
+		t.Fatalf("\t\t%s\tShould get a %v book publish date: %v", failed, testBookPubDate, bookMeta.PubDate)
+	}
+	if !reflect.DeepEqual(bookMeta.GetAuthorNames(), testAuthors) {
+		t.Fatalf("\t\t%s\tShould get %v book authors: %v", failed, testAuthors, bookMeta.GetAuthorNames())
+	}
+	if !reflect.DeepEqual(bookMeta.Categories, testBookCategories) {
+		t.Fatalf("\t\t%s\tShould get %v book categories: %v", failed, testBookCategories, bookMeta.Categories)
+	}
+	if bookMeta.CoverURL != testCoverURL {
+		t.Fatalf("\t\t%s\tShould get a %q book cover URL: %q", failed, testCoverURL, bookMeta.CoverURL)
+	}
+	if bookMeta.CoverFileName != "1234567890.jpg" {
+		t.Fatalf("\t\t%s\tShould get a %q book cover file name: %q", failed, "1234567890.jpg", bookMeta.CoverFileName)
+	}
+
+	t.Logf("\t\t%s\tShould be able to get Open Library book data.", succeed)
+}
+
+func TestOpenLibraryScrapper_GetBookDataPublisherURL(t *testing.T) {
+	t.Log("Given the need to test Open Library book URL for a hyphenated book ID.")
+	server := testJSONMockServer(t, openLibraryHandlersMap)
+	defer server.Close()
+
+	openLibraryScrapper := NewOpenLibraryScrapper(server.URL, server.URL, log.Default())
+	bookMeta, err := openLibraryScrapper.GetBookData(context.Background(), "1-2345-6789-0")
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+	}
+
+	expectedURL := server.URL + "/isbn/" + testBookID01
+	if bookMeta.PublisherURL != expectedURL {
+		t.Fatalf("\t\t%s\tShould get a %q book URL: %q", failed, expectedURL, bookMeta.PublisherURL)
+	}
+
+	t.Logf("\t\t%s\tShould be able to get Open Library book URL by the normalized book ID.", succeed)
+}
+
+func TestOpenLibraryScrapper_GetBookDataNotFound(t *testing.T) {
+	t.Log("Given the need to test Open Library unknown book fetching.")
+	server := testJSONMockServer(t, openLibraryHandlersMap)
+	defer server.Close()
+
+	openLibraryScrapper := NewOpenLibraryScrapper(server.URL, server.URL, log.Default())
+	if _, err := openLibraryScrapper.GetBookData(context.Background(), "0000000000"); err == nil {
+		t.Fatalf("\t\t%s\tShould get an error for an unknown book", failed)
+	}
+
+	t.Logf("\t\t%s\tShould get an error for an unknown book.", succeed)
+}
+
+func testJSONMockServer(t *testing.T, handlers map[string]string) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	for path, responseFile := range handlers {
+		responseFile := responseFile
+		mux.HandleFunc(path, func(rw http.ResponseWriter, req *http.Request) {
+			file, err := os.ReadFile(responseFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusOK)
+			_, err = rw.Write(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	return server
+}
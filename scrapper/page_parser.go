@@ -0,0 +1,102 @@
+package scrapper
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const canonicalLinkSelector = `link[rel=canonical]`
+
+// AmazonPageParser parses Amazon product pages saved in a browser, using the same selectors and parsing rules
+// as the AmazonScrapper, without any network requests.
+type AmazonPageParser struct {
+	storefronts []Storefront
+	selectors   SelectorSet
+	logger      *log.Logger
+}
+
+// NewAmazonPageParser creates a page parser. The page storefront is detected by the page canonical link,
+// the first of the given storefronts is used for pages without a known canonical link.
+func NewAmazonPageParser(storefrontCodes []string, selectors SelectorSet, logger *log.Logger) (*AmazonPageParser, error) {
+	storefronts, err := getStorefronts(storefrontCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AmazonPageParser{storefronts: storefronts, selectors: selectors, logger: logger}, nil
+}
+
+// ParseBookPageFile parses a saved product page file.
+func (p *AmazonPageParser) ParseBookPageFile(path string) (book.ParsedData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return book.ParsedData{}, fmt.Errorf("can not open the book page file: %w", err)
+	}
+	defer closeResponseBody(file, p.logger)
+
+	p.logger.Printf("[INFO] - Parsing the book page file: %q", path)
+	return p.ParseBookPage(file)
+}
+
+// ParseBookPage parses a product page. Robot check pages and pages without a book title are rejected.
+func (p *AmazonPageParser) ParseBookPage(page io.Reader) (book.ParsedData, error) {
+	body, err := io.ReadAll(page)
+	if err != nil {
+		return book.ParsedData{}, fmt.Errorf("can not read the book page: %w", err)
+	}
+	if err := classifyResponse(http.StatusOK, body); err != nil {
+		return book.ParsedData{}, err
+	}
+	document, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return book.ParsedData{}, fmt.Errorf("can not parse the book page: %w", err)
+	}
+
+	rawData := newScrappedRawData()
+	p.selectors.extractRawData(document.Selection, &rawData)
+	if rawData.titleString == "" {
+		return book.ParsedData{}, fmt.Errorf("%w: empty product page", ErrNotFound)
+	}
+
+	canonicalURL, _ := document.Find(canonicalLinkSelector).First().Attr("href")
+	storefront := p.getPageStorefront(canonicalURL)
+	p.logger.Printf("[INFO] - Using the %q storefront for the book page", storefront.Code)
+
+	return parseRawData(storefront, rawData, p.logger)
+}
+
+// getPageStorefront detects the storefront by the page canonical URL host.
+func (p *AmazonPageParser) getPageStorefront(canonicalURL string) Storefront {
+	if pageURL, err := url.Parse(canonicalURL); err == nil && pageURL.Host != "" {
+		for _, storefront := range amazonStorefronts {
+			if strings.HasSuffix(pageURL.Host, "amazon."+storefront.Code) {
+				return storefront
+			}
+		}
+	}
+
+	return p.storefronts[0]
+}
+
+// MissingFields returns the names of the book data fields, which have not been extracted.
+func MissingFields(parsedData book.ParsedData) []string {
+	fields := []string{FieldTitle, FieldSubtitle, FieldDescription, FieldISBN10, FieldISBN13, FieldASIN, FieldPages,
+		FieldLanguage, FieldPublisher, FieldEdition, FieldPubDate, FieldAuthors, FieldCategories, FieldCoverURL}
+
+	missing := make([]string, 0)
+	for _, field := range fields {
+		if fieldValue(parsedData, field) == "" {
+			missing = append(missing, field)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,107 @@
+package scrapper
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+)
+
+const testCanonicalLinkDE = `<link rel="canonical" href="https://www.amazon.de/Test-Title/dp/1234567890">`
+
+func TestAmazonPageParser_ParseBookPageFile(t *testing.T) {
+	t.Log("Given the need to test saved book page parsing.")
+	pageParser, err := NewAmazonPageParser(nil, DefaultSelectorSet(), log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a page parser: %v", failed, err)
+	}
+
+	bookMeta, err := pageParser.ParseBookPageFile(testBookResponse01)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to parse the book page file: %v", failed, err)
+	}
+	if bookMeta.Title != testBookTitle {
+		t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+	}
+	if !reflect.DeepEqual(bookMeta.GetAuthorNames(), testBookAuthors) {
+		t.Fatalf("\t\t%s\tShould get %v book authors: %v", failed, testBookAuthors, bookMeta.GetAuthorNames())
+	}
+	if bookMeta.ISBN10 != testBookISBN10 {
+		t.Fatalf("\t\t%s\tShould get a %q book ISBN10: %q", failed, testBookISBN10, bookMeta.ISBN10)
+	}
+	expectedPublisherURL := amazonStorefronts[defaultStorefrontCode].BasePath + testBookISBN10
+	if bookMeta.PublisherURL != expectedPublisherURL {
+		t.Fatalf("\t\t%s\tShould get a %q publisher URL: %q", failed, expectedPublisherURL, bookMeta.PublisherURL)
+	}
+	t.Logf("\t\t%s\tShould be able to parse the book page file.", succeed)
+
+	t.Logf("\t\tWhen parsing a localized page with a canonical link\n")
+	{
+		page, err := os.ReadFile(testBookResponseDE)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page = bytes.Replace(page, []byte("<head>"), []byte("<head>"+testCanonicalLinkDE), 1)
+		bookMeta, err := pageParser.ParseBookPage(bytes.NewReader(page))
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to parse the book page: %v", failed, err)
+		}
+		if bookMeta.Publisher != testBookPublisher || !bookMeta.PubDate.Equal(testBookPubDate) {
+			t.Fatalf("\t\t%s\tShould parse the page with the German storefront rules: %q, %v", failed,
+				bookMeta.Publisher, bookMeta.PubDate)
+		}
+		t.Logf("\t\t%s\tShould detect the page storefront by the canonical link.", succeed)
+	}
+
+	t.Logf("\t\tWhen parsing a robot check page\n")
+	{
+		if _, err := pageParser.ParseBookPageFile(testCaptchaResponse); !errors.Is(err, ErrBlocked) {
+			t.Fatalf("\t\t%s\tShould get a %v error: %v", failed, ErrBlocked, err)
+		}
+		t.Logf("\t\t%s\tShould reject the robot check page.", succeed)
+	}
+}
+
+func TestMissingFields(t *testing.T) {
+	t.Log("Given the need to test missing book data fields reporting.")
+	pageParser, err := NewAmazonPageParser(nil, DefaultSelectorSet(), log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a page parser: %v", failed, err)
+	}
+
+	fullMeta, err := pageParser.ParseBookPageFile(testBookResponse01)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to parse the book page file: %v", failed, err)
+	}
+	if missing := MissingFields(fullMeta); len(missing) != 0 {
+		t.Fatalf("\t\t%s\tShould get no missing fields for the full page, but got: %v", failed, missing)
+	}
+	t.Logf("\t\t%s\tShould get no missing fields for the full page.", succeed)
+
+	partialMeta, err := pageParser.ParseBookPageFile(testBookResponsePartial)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to parse the partial book page file: %v", failed, err)
+	}
+	missing := MissingFields(partialMeta)
+	for _, field := range []string{FieldAuthors, FieldPublisher, FieldPubDate} {
+		if !contains(missing, field) {
+			t.Fatalf("\t\t%s\tShould report the %q field as missing: %v", failed, field, missing)
+		}
+	}
+	if contains(missing, FieldTitle) {
+		t.Fatalf("\t\t%s\tShould not report the extracted title as missing: %v", failed, missing)
+	}
+	t.Logf("\t\t%s\tShould report the fields missing in the partial page.", succeed)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
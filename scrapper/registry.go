@@ -0,0 +1,229 @@
+package scrapper
+
+import (
+	"context"
+	"fmt"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"log"
+	"strings"
+	"sync"
+)
+
+const (
+	AmazonSourceName = "amazon"
+
+	FieldTitle        = "Title"
+	FieldSubtitle     = "Subtitle"
+	FieldDescription  = "Description"
+	FieldISBN10       = "ISBN10"
+	FieldISBN13       = "ISBN13"
+	FieldASIN         = "ASIN"
+	FieldDOI          = "DOI"
+	FieldPages        = "Pages"
+	FieldLanguage     = "Language"
+	FieldPublisher    = "Publisher"
+	FieldPublisherURL = "PublisherURL"
+	FieldEdition      = "Edition"
+	FieldPubDate      = "PubDate"
+	FieldSeries       = "Series"
+	FieldAuthors      = "Authors"
+	FieldCategories   = "Categories"
+	FieldTags         = "Tags"
+	FieldCoverURL     = "CoverURL"
+)
+
+type sourceResult struct {
+	parsedData book.ParsedData
+	err        error
+}
+
+// Registry runs several registered BookDataScrapper sources for the same book ID,
+// and merges their results field by field. For every field the first source (according to the field precedence,
+// or the registration order if there is no precedence for the field) returning a non-empty value wins.
+// The merged diagnostics keep the field diagnostics of the winning sources.
+type Registry struct {
+	sources    map[string]BookDataScrapper
+	order      []string
+	precedence map[string][]string
+	logger     *log.Logger
+}
+
+func NewRegistry(logger *log.Logger) *Registry {
+	return &Registry{
+		sources:    make(map[string]BookDataScrapper),
+		order:      make([]string, 0),
+		precedence: make(map[string][]string),
+		logger:     logger,
+	}
+}
+
+// Register adds a named source to the registry. Sources are queried in the registration order.
+func (r *Registry) Register(name string, source BookDataScrapper) {
+	if _, ok := r.sources[name]; !ok {
+		r.order = append(r.order, name)
+	}
+	r.sources[name] = source
+}
+
+// SetFieldPrecedence sets an ordered list of source names used to pick the particular field value.
+// Registered sources which are not in the list are used after the listed ones, in the registration order.
+func (r *Registry) SetFieldPrecedence(field string, sourceNames ...string) {
+	r.precedence[field] = sourceNames
+}
+
+// GetBookData gets the book data from all registered sources in parallel, and merges them into a single ParsedData.
+// An error is returned only if all sources failed, or the context is cancelled.
+func (r *Registry) GetBookData(ctx context.Context, bookID string) (book.ParsedData, error) {
+	if len(r.order) == 0 {
+		return book.ParsedData{}, fmt.Errorf("there are no registered book data sources")
+	}
+
+	sourceResults := make([]sourceResult, len(r.order))
+	var wg sync.WaitGroup
+	for i, name := range r.order {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			parsedData, err := r.sources[name].GetBookData(ctx, bookID)
+			sourceResults[i] = sourceResult{parsedData: parsedData, err: err}
+		}(i, name)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return book.ParsedData{}, err
+	}
+
+	results := make(map[string]book.ParsedData)
+	var sourceErrors SourceErrors
+	for i, name := range r.order {
+		if err := sourceResults[i].err; err != nil {
+			r.logger.Printf("[WARN] - Can not get book data from the %q source: %v", name, err)
+			sourceErrors = append(sourceErrors, SourceError{Source: name, Err: err})
+			continue
+		}
+		results[name] = sourceResults[i].parsedData
+	}
+
+	if len(results) == 0 {
+		return book.ParsedData{}, sourceErrors
+	}
+
+	return r.merge(results), nil
+}
+
+// Close closes all registered sources. Returns an error describing all failed sources, if any.
+func (r *Registry) Close() error {
+	var closeErrors []string
+	for _, name := range r.order {
+		if err := r.sources[name].Close(); err != nil {
+			closeErrors = append(closeErrors, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	if len(closeErrors) > 0 {
+		return fmt.Errorf("can not close book data sources: %s", strings.Join(closeErrors, "; "))
+	}
+
+	return nil
+}
+
+func (r *Registry) merge(results map[string]book.ParsedData) book.ParsedData {
+	var merged book.ParsedData
+	pick := func(field string, isSet func(parsedData book.ParsedData) bool, set func(parsedData book.ParsedData)) {
+		for _, name := range r.fieldOrder(field) {
+			parsedData, ok := results[name]
+			if ok && isSet(parsedData) {
+				set(parsedData)
+				diagnostic, found := parsedData.Diagnostics.GetField(field)
+				if !found {
+					diagnostic = book.FieldDiagnostic{Field: field}
+				}
+				if diagnostic.Source == "" {
+					diagnostic.Source = name
+				}
+				merged.Diagnostics.AddField(diagnostic)
+				return
+			}
+		}
+	}
+
+	pick(FieldTitle, func(pd book.ParsedData) bool { return pd.Title != "" },
+		func(pd book.ParsedData) { merged.Title = pd.Title })
+	pick(FieldSubtitle, func(pd book.ParsedData) bool { return pd.Subtitle != "" },
+		func(pd book.ParsedData) { merged.Subtitle = pd.Subtitle })
+	pick(FieldDescription, func(pd book.ParsedData) bool { return pd.Description != "" },
+		func(pd book.ParsedData) {
+			merged.Description = pd.Description
+			merged.DescriptionMarkdown = pd.DescriptionMarkdown
+			merged.DescriptionText = pd.DescriptionText
+		})
+	pick(FieldISBN10, func(pd book.ParsedData) bool { return pd.ISBN10 != "" },
+		func(pd book.ParsedData) { merged.ISBN10 = pd.ISBN10 })
+	pick(FieldISBN13, func(pd book.ParsedData) bool { return pd.ISBN13 != 0 },
+		func(pd book.ParsedData) { merged.ISBN13 = pd.ISBN13 })
+	pick(FieldASIN, func(pd book.ParsedData) bool { return pd.ASIN != "" },
+		func(pd book.ParsedData) { merged.ASIN = pd.ASIN })
+	pick(FieldDOI, func(pd book.ParsedData) bool { return pd.DOI != "" },
+		func(pd book.ParsedData) { merged.DOI = pd.DOI })
+	pick(FieldPages, func(pd book.ParsedData) bool { return pd.Pages != 0 },
+		func(pd book.ParsedData) { merged.Pages = pd.Pages })
+	pick(FieldLanguage, func(pd book.ParsedData) bool { return pd.Language != "" },
+		func(pd book.ParsedData) { merged.Language = pd.Language })
+	pick(FieldPublisher, func(pd book.ParsedData) bool { return pd.Publisher != "" },
+		func(pd book.ParsedData) { merged.Publisher = pd.Publisher })
+	pick(FieldPublisherURL, func(pd book.ParsedData) bool { return pd.PublisherURL != "" },
+		func(pd book.ParsedData) { merged.PublisherURL = pd.PublisherURL })
+	pick(FieldEdition, func(pd book.ParsedData) bool { return !pd.Edition.IsEmpty() },
+		func(pd book.ParsedData) { merged.Edition = pd.Edition })
+	pick(FieldPubDate, func(pd book.ParsedData) bool { return !pd.PubDate.IsZero() },
+		func(pd book.ParsedData) { merged.PubDate, merged.PubDatePrecision = pd.PubDate, pd.PubDatePrecision })
+	// The series name and volume are picked together, to not mix them up from different sources
+	pick(FieldSeries, func(pd book.ParsedData) bool { return pd.Series != "" },
+		func(pd book.ParsedData) { merged.Series, merged.SeriesVolume = pd.Series, pd.SeriesVolume })
+	pick(FieldAuthors, func(pd book.ParsedData) bool { return len(pd.Contributors) > 0 },
+		func(pd book.ParsedData) {
+			merged.Contributors, merged.AuthorProfiles = pd.Contributors, pd.AuthorProfiles
+		})
+	pick(FieldCategories, func(pd book.ParsedData) bool { return len(pd.Categories) > 0 },
+		func(pd book.ParsedData) { merged.Categories = pd.Categories })
+	pick(FieldTags, func(pd book.ParsedData) bool { return len(pd.Tags) > 0 },
+		func(pd book.ParsedData) { merged.Tags = pd.Tags })
+	pick(FieldCoverURL, func(pd book.ParsedData) bool { return pd.CoverURL != "" },
+		func(pd book.ParsedData) { merged.CoverURL = pd.CoverURL })
+
+	merged.CoverFileName = fmt.Sprint(merged.GetPrimaryId(), getCoverExtension(merged.CoverURL))
+	merged.BookFileName = merged.GetBookFileName()
+	for _, name := range r.order {
+		if parsedData, ok := results[name]; ok {
+			for _, warning := range parsedData.Diagnostics.Warnings {
+				merged.Diagnostics.AddWarning("%s: %s", name, warning)
+			}
+		}
+	}
+	checkRequiredFields(&merged)
+
+	return merged
+}
+
+// fieldOrder returns source names in the order they should be checked for the particular field.
+func (r *Registry) fieldOrder(field string) []string {
+	fieldPrecedence, ok := r.precedence[field]
+	if !ok {
+		return r.order
+	}
+
+	order := make([]string, 0, len(r.order))
+	listed := make(map[string]bool)
+	for _, name := range fieldPrecedence {
+		if _, registered := r.sources[name]; registered && !listed[name] {
+			order = append(order, name)
+			listed[name] = true
+		}
+	}
+	for _, name := range r.order {
+		if !listed[name] {
+			order = append(order, name)
+		}
+	}
+
+	return order
+}
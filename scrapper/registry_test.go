@@ -0,0 +1,187 @@
+package scrapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"log"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	testFirstSourceName  = "first"
+	testSecondSourceName = "second"
+)
+
+func TestRegistry_GetBookData(t *testing.T) {
+	t.Log("Given the need to test book data merging from several sources.")
+	t.Run("Empty fields are filled from the next source", testRegistryFillsEmptyFields)
+	t.Run("Field precedence overrides the registration order", testRegistryFieldPrecedence)
+	t.Run("Failed source is skipped", testRegistryFailedSource)
+	t.Run("All sources failed", testRegistryAllSourcesFailed)
+}
+
+func testRegistryFillsEmptyFields(t *testing.T) {
+	t.Logf("\t\tWhen checking for empty fields merging\n")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	firstSource := NewMockBookDataScrapper(ctrl)
+	firstSource.EXPECT().GetBookData(context.Background(), testBookID01).Return(book.ParsedData{
+		Title:        testBookTitle,
+		ISBN10:       testBookISBN10,
+		Publisher:    "",
+		PubDate:      testBookPubDate,
+		Contributors: book.NewAuthors(testBookAuthors),
+		CoverURL:     testCoverURL,
+		Diagnostics: book.Diagnostics{
+			Fields:   []book.FieldDiagnostic{{Field: FieldTitle, Source: "amazon.com", Selector: SelectorTitle}},
+			Warnings: []string{"can not parse the publisher string"},
+		},
+	}, nil).Times(1)
+	secondSource := NewMockBookDataScrapper(ctrl)
+	secondSource.EXPECT().GetBookData(context.Background(), testBookID01).Return(book.ParsedData{
+		Title:        "Other Title",
+		ISBN13:       testBookISBN13,
+		Publisher:    testBookPublisher,
+		Contributors: book.NewAuthors([]string{testBookAuthorName01}),
+	}, nil).Times(1)
+
+	registry := NewRegistry(log.Default())
+	registry.Register(testFirstSourceName, firstSource)
+	registry.Register(testSecondSourceName, secondSource)
+
+	bookMeta, err := registry.GetBookData(context.Background(), testBookID01)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get merged book data: %v", failed, err)
+	}
+	if bookMeta.Title != testBookTitle {
+		t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+	}
+	if bookMeta.ISBN13 != testBookISBN13 {
+		t.Fatalf("\t\t%s\tShould get a %d book ISBN13: %d", failed, testBookISBN13, bookMeta.ISBN13)
+	}
+	if bookMeta.Publisher != testBookPublisher {
+		t.Fatalf("\t\t%s\tShould get a %q book publisher: %q", failed, testBookPublisher, bookMeta.Publisher)
+	}
+	if !reflect.DeepEqual(bookMeta.GetAuthorNames(), testBookAuthors) {
+		t.Fatalf("\t\t%s\tShould get %v book authors: %v", failed, testBookAuthors, bookMeta.GetAuthorNames())
+	}
+	if bookMeta.CoverFileName != testCoverFileName {
+		t.Fatalf("\t\t%s\tShould get a %q book cover file name: %q", failed, testCoverFileName, bookMeta.CoverFileName)
+	}
+	if diagnostic, _ := bookMeta.Diagnostics.GetField(FieldTitle); diagnostic.Source != "amazon.com" {
+		t.Fatalf("\t\t%s\tShould keep the source title diagnostic: %+v", failed, diagnostic)
+	}
+	if diagnostic, _ := bookMeta.Diagnostics.GetField(FieldPublisher); diagnostic.Source != testSecondSourceName {
+		t.Fatalf("\t\t%s\tShould get the publisher from the %q source: %+v", failed, testSecondSourceName,
+			diagnostic)
+	}
+	if !reflect.DeepEqual(bookMeta.Diagnostics.Warnings, []string{"first: can not parse the publisher string"}) {
+		t.Fatalf("\t\t%s\tShould keep the source warnings: %v", failed, bookMeta.Diagnostics.Warnings)
+	}
+	if len(bookMeta.Diagnostics.MissingRequired) != 0 {
+		t.Fatalf("\t\t%s\tShould not miss required fields: %v", failed, bookMeta.Diagnostics.MissingRequired)
+	}
+
+	t.Logf("\t\t%s\tShould be able to fill empty fields from the next source", succeed)
+}
+
+func testRegistryFieldPrecedence(t *testing.T) {
+	t.Logf("\t\tWhen checking for the field precedence\n")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	firstSource := NewMockBookDataScrapper(ctrl)
+	firstSource.EXPECT().GetBookData(context.Background(), testBookID01).Return(book.ParsedData{
+		Title:   testBookTitle,
+		ISBN13:  9780000000000,
+		PubDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, nil).Times(1)
+	secondSource := NewMockBookDataScrapper(ctrl)
+	secondSource.EXPECT().GetBookData(context.Background(), testBookID01).Return(book.ParsedData{
+		Title:   "Other Title",
+		ISBN13:  testBookISBN13,
+		PubDate: testBookPubDate,
+	}, nil).Times(1)
+
+	registry := NewRegistry(log.Default())
+	registry.Register(testFirstSourceName, firstSource)
+	registry.Register(testSecondSourceName, secondSource)
+	registry.SetFieldPrecedence(FieldISBN13, testSecondSourceName)
+	registry.SetFieldPrecedence(FieldPubDate, "unknown", testSecondSourceName, testFirstSourceName)
+
+	bookMeta, err := registry.GetBookData(context.Background(), testBookID01)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get merged book data: %v", failed, err)
+	}
+	if bookMeta.Title != testBookTitle {
+		t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+	}
+	if bookMeta.ISBN13 != testBookISBN13 {
+		t.Fatalf("\t\t%s\tShould get a %d book ISBN13: %d", failed, testBookISBN13, bookMeta.ISBN13)
+	}
+	if !bookMeta.PubDate.Equal(testBookPubDate) {
+		t.Fatalf("\t\t%s\tShould get a %v book publish date: %v", failed, testBookPubDate, bookMeta.PubDate)
+	}
+
+	t.Logf("\t\t%s\tShould be able to pick field values according to the precedence", succeed)
+}
+
+func testRegistryFailedSource(t *testing.T) {
+	t.Logf("\t\tWhen checking for a failed source\n")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	firstSource := NewMockBookDataScrapper(ctrl)
+	firstSource.EXPECT().GetBookData(context.Background(), testBookID01).Return(book.ParsedData{}, fmt.Errorf("blocked")).Times(1)
+	secondSource := NewMockBookDataScrapper(ctrl)
+	secondSource.EXPECT().GetBookData(context.Background(), testBookID01).Return(book.ParsedData{Title: testBookTitle}, nil).Times(1)
+
+	registry := NewRegistry(log.Default())
+	registry.Register(testFirstSourceName, firstSource)
+	registry.Register(testSecondSourceName, secondSource)
+
+	bookMeta, err := registry.GetBookData(context.Background(), testBookID01)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+	}
+	if bookMeta.Title != testBookTitle {
+		t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+	}
+
+	t.Logf("\t\t%s\tShould be able to skip a failed source", succeed)
+}
+
+func testRegistryAllSourcesFailed(t *testing.T) {
+	t.Logf("\t\tWhen checking for all sources failed\n")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	firstSource := NewMockBookDataScrapper(ctrl)
+	firstSource.EXPECT().GetBookData(context.Background(), testBookID01).Return(book.ParsedData{}, fmt.Errorf("blocked")).Times(1)
+	secondSource := NewMockBookDataScrapper(ctrl)
+	secondSource.EXPECT().GetBookData(context.Background(), testBookID01).
+		Return(book.ParsedData{}, fmt.Errorf("%w: response status 404", ErrNotFound)).Times(1)
+
+	registry := NewRegistry(log.Default())
+	registry.Register(testFirstSourceName, firstSource)
+	registry.Register(testSecondSourceName, secondSource)
+
+	_, err := registry.GetBookData(context.Background(), testBookID01)
+	if err == nil {
+		t.Fatalf("\t\t%s\tShould get an error if all sources failed", failed)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("\t\t%s\tShould keep the %v source error: %v", failed, ErrNotFound, err)
+	}
+	if errors.Is(err, ErrBlocked) {
+		t.Fatalf("\t\t%s\tShould not match the %v error: %v", failed, ErrBlocked, err)
+	}
+
+	t.Logf("\t\t%s\tShould get an error if all sources failed", succeed)
+}
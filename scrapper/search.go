@@ -0,0 +1,186 @@
+package scrapper
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	DefaultSearchLimit = 10
+
+	// A candidate title covering the whole query is ranked higher, than a candidate matching it with authors only
+	searchTitleWeight = 0.5
+	// A candidate found by several sources is ranked higher
+	searchSourceWeight = 0.1
+)
+
+// SearchCandidate is a book found by a free text search.
+type SearchCandidate struct {
+	BookID   string
+	Title    string
+	Authors  []string
+	Year     int
+	CoverURL string
+	Sources  []string
+	Score    float64
+}
+
+type searchResult struct {
+	candidates []SearchCandidate
+	err        error
+}
+
+// SearchBooks searches all registered sources, supporting the free text search, in parallel. The candidates are
+// merged by the book ID, and ranked by their similarity to the query. At most 'limit' candidates are returned.
+// An error is returned only if all searchable sources failed, or the context is cancelled.
+// The ErrSearchNotSupported error is returned, if none of the registered sources supports search.
+func (r *Registry) SearchBooks(ctx context.Context, query string, limit int) ([]SearchCandidate, error) {
+	searchers := make(map[string]BookSearcher)
+	for _, name := range r.order {
+		if searcher, ok := r.sources[name].(BookSearcher); ok {
+			searchers[name] = searcher
+		}
+	}
+	if len(searchers) == 0 {
+		return nil, ErrSearchNotSupported
+	}
+
+	searchResults := make([]searchResult, len(r.order))
+	var wg sync.WaitGroup
+	for i, name := range r.order {
+		searcher, ok := searchers[name]
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, name string, searcher BookSearcher) {
+			defer wg.Done()
+			candidates, err := searcher.SearchBooks(ctx, query, limit)
+			for j := range candidates {
+				candidates[j].Sources = []string{name}
+			}
+			searchResults[i] = searchResult{candidates: candidates, err: err}
+		}(i, name, searcher)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]SearchCandidate, 0)
+	var sourceErrors []string
+	for i, name := range r.order {
+		if _, ok := searchers[name]; !ok {
+			continue
+		}
+		if err := searchResults[i].err; err != nil {
+			r.logger.Printf("[WARN] - Can not search books in the %q source: %v", name, err)
+			sourceErrors = append(sourceErrors, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		candidates = append(candidates, searchResults[i].candidates...)
+	}
+	if len(sourceErrors) == len(searchers) {
+		return nil, fmt.Errorf("all book search sources failed: %s", strings.Join(sourceErrors, "; "))
+	}
+
+	return RankCandidates(query, mergeCandidates(candidates), limit), nil
+}
+
+// mergeCandidates merges candidates with the same book ID, filling the missing fields from the later ones.
+func mergeCandidates(candidates []SearchCandidate) []SearchCandidate {
+	merged := make([]SearchCandidate, 0, len(candidates))
+	indexes := make(map[string]int)
+	for _, candidate := range candidates {
+		index, ok := indexes[candidate.BookID]
+		if !ok {
+			indexes[candidate.BookID] = len(merged)
+			merged = append(merged, candidate)
+			continue
+		}
+		existing := &merged[index]
+		existing.Sources = append(existing.Sources, candidate.Sources...)
+		if existing.Title == "" {
+			existing.Title = candidate.Title
+		}
+		if len(existing.Authors) == 0 {
+			existing.Authors = candidate.Authors
+		}
+		if existing.Year == 0 {
+			existing.Year = candidate.Year
+		}
+		if existing.CoverURL == "" {
+			existing.CoverURL = candidate.CoverURL
+		}
+	}
+
+	return merged
+}
+
+// RankCandidates scores the candidates by the share of the query words found in their titles and authors,
+// and returns at most 'limit' best candidates, sorted by the score (descending).
+func RankCandidates(query string, candidates []SearchCandidate, limit int) []SearchCandidate {
+	queryWords := searchWords(query)
+	for i := range candidates {
+		candidates[i].Score = scoreCandidate(queryWords, candidates[i])
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Year > candidates[j].Year
+	})
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates
+}
+
+func scoreCandidate(queryWords []string, candidate SearchCandidate) float64 {
+	if len(queryWords) == 0 {
+		return 0
+	}
+
+	titleWords := toSet(searchWords(candidate.Title))
+	authorWords := toSet(searchWords(strings.Join(candidate.Authors, " ")))
+	var matched, titleMatched int
+	for _, word := range queryWords {
+		if titleWords[word] {
+			titleMatched++
+			matched++
+		} else if authorWords[word] {
+			matched++
+		}
+	}
+
+	score := float64(matched) / float64(len(queryWords))
+	if len(titleWords) > 0 {
+		score += searchTitleWeight * float64(titleMatched) / float64(len(titleWords))
+	}
+	if len(candidate.Sources) > 1 {
+		score += searchSourceWeight * float64(len(candidate.Sources)-1)
+	}
+
+	return score
+}
+
+// searchWords splits the text into lower-cased words, ignoring punctuation.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+
+	return set
+}
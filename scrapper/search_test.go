@@ -0,0 +1,81 @@
+package scrapper
+
+import (
+	"context"
+	"errors"
+	"log"
+	"reflect"
+	"testing"
+)
+
+const (
+	testSearchQuery              = "test title first author"
+	testOpenLibrarySearchResults = "testdata/openlibrary_search.json"
+)
+
+func TestRegistry_SearchBooks(t *testing.T) {
+	t.Log("Given the need to test free text book search.")
+	openLibraryServer := testJSONMockServer(t, map[string]string{"/search.json": testOpenLibrarySearchResults})
+	defer openLibraryServer.Close()
+	googleBooksServer := testJSONMockServer(t, map[string]string{"/volumes": testGoogleBooksVolumesResponse})
+	defer googleBooksServer.Close()
+
+	registry := NewRegistry(log.Default())
+	registry.Register(OpenLibrarySourceName, NewOpenLibraryScrapper(openLibraryServer.URL, "", log.Default()))
+	registry.Register(GoogleBooksSourceName, NewGoogleBooksScrapper(googleBooksServer.URL, "", log.Default()))
+
+	candidates, err := registry.SearchBooks(context.Background(), testSearchQuery, DefaultSearchLimit)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to search books: %v", failed, err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("\t\t%s\tShould get 2 candidates with an ISBN10, but got: %+v", failed, candidates)
+	}
+	t.Logf("\t\t%s\tShould skip candidates without an ISBN10.", succeed)
+
+	best := candidates[0]
+	if best.BookID != testBookID01 || best.Title != testBookTitle || best.Year != 2022 {
+		t.Fatalf("\t\t%s\tShould rank the %q book first, but got: %+v", failed, testBookID01, best)
+	}
+	if !reflect.DeepEqual(best.Sources, []string{OpenLibrarySourceName, GoogleBooksSourceName}) {
+		t.Fatalf("\t\t%s\tShould merge the candidate found by both sources, but got: %v", failed, best.Sources)
+	}
+	if best.CoverURL == "" {
+		t.Fatalf("\t\t%s\tShould get the candidate cover URL", failed)
+	}
+	t.Logf("\t\t%s\tShould merge and rank candidates from all sources.", succeed)
+}
+
+func TestRegistry_SearchBooksNotSupported(t *testing.T) {
+	t.Log("Given the need to test free text book search without searchable sources.")
+	registry := NewRegistry(log.Default())
+	registry.Register(CrossrefSourceName, NewCrossrefScrapper("", log.Default()))
+
+	_, err := registry.SearchBooks(context.Background(), testSearchQuery, DefaultSearchLimit)
+	if !errors.Is(err, ErrSearchNotSupported) {
+		t.Fatalf("\t\t%s\tShould get the %v error, if there are no searchable sources: %v", failed,
+			ErrSearchNotSupported, err)
+	}
+	t.Logf("\t\t%s\tShould get an error, if there are no searchable sources.", succeed)
+}
+
+func TestRankCandidates(t *testing.T) {
+	t.Log("Given the need to test search candidates ranking.")
+	candidates := []SearchCandidate{
+		{BookID: "0000000001", Title: "Unrelated Book", Authors: []string{"Somebody Else"}, Year: 2022},
+		{BookID: "0000000002", Title: "Test Title Workbook", Authors: []string{"First Author"}, Year: 2021},
+		{BookID: "0000000003", Title: "Test Title", Authors: []string{"First Author"}, Year: 2020},
+		{BookID: "0000000004", Title: "Test Title", Authors: []string{"First Author"}, Year: 2022},
+	}
+
+	ranked := RankCandidates(testSearchQuery, candidates, 3)
+	rankedIDs := make([]string, 0, len(ranked))
+	for _, candidate := range ranked {
+		rankedIDs = append(rankedIDs, candidate.BookID)
+	}
+	expectedIDs := []string{"0000000004", "0000000003", "0000000002"}
+	if !reflect.DeepEqual(rankedIDs, expectedIDs) {
+		t.Fatalf("\t\t%s\tShould rank candidates as %v, but got: %v", failed, expectedIDs, rankedIDs)
+	}
+	t.Logf("\t\t%s\tShould rank exact titles first, newer books first, and limit the candidates.", succeed)
+}
@@ -0,0 +1,310 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/sdreger/lib-file-processor-go/domain/author"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"github.com/sdreger/lib-file-processor-go/parser"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	defaultSelectorSetVersion = "1"
+
+	SelectorTitle       = "title"
+	SelectorSubtitle    = "subtitle"
+	SelectorDescription = "description"
+	SelectorCategories  = "categories"
+	SelectorAuthors     = "authors"
+	SelectorDetails     = "details"
+	SelectorCarousel    = "carousel"
+	SelectorSeries      = "series"
+	SelectorFormats     = "formats"
+	SelectorISBNBlock   = "isbn"
+	SelectorCoverURL    = "cover"
+
+	SelectorAuthorBio   = "author_bio"
+	SelectorAuthorPhoto = "author_photo"
+)
+
+// SelectorFields is the list of all page fields, extracted by the selector set.
+var SelectorFields = []string{
+	SelectorTitle, SelectorSubtitle, SelectorDescription, SelectorCategories, SelectorAuthors,
+	SelectorDetails, SelectorCarousel, SelectorSeries, SelectorFormats, SelectorISBNBlock, SelectorCoverURL,
+}
+
+// AuthorSelectorFields is the list of all author page fields, extracted by the selector set.
+var AuthorSelectorFields = []string{SelectorAuthorBio, SelectorAuthorPhoto}
+
+// SelectorSet is a versioned set of CSS selectors, used to extract the raw book data from a product page.
+// Every field has an ordered list of fallback selectors: the first selector matching any element is used.
+type SelectorSet struct {
+	Version string              `json:"version" yaml:"version"`
+	Fields  map[string][]string `json:"fields" yaml:"fields"`
+}
+
+// DefaultSelectorSet returns the built-in selector set.
+func DefaultSelectorSet() SelectorSet {
+	return SelectorSet{
+		Version: defaultSelectorSetVersion,
+		Fields: map[string][]string{
+			SelectorTitle:       {bookTitleSelector},
+			SelectorSubtitle:    {bookSubtitleSelector},
+			SelectorDescription: {bookDescriptionSelector},
+			SelectorCategories:  {bookCategoriesSelector},
+			SelectorAuthors:     {bookAuthorsSelector},
+			SelectorDetails:     {bookDetailsSelector},
+			SelectorCarousel:    {bookCarouserSelector},
+			SelectorSeries:      {bookSeriesSelector},
+			SelectorFormats:     {bookFormatsSelector},
+			SelectorISBNBlock:   {bookISBNBlockSelector},
+			SelectorCoverURL:    bookCoverURLSelectors,
+			SelectorAuthorBio:   authorBioSelectors,
+			SelectorAuthorPhoto: authorPhotoSelectors,
+		},
+	}
+}
+
+// LoadSelectorSet loads a selector set from a YAML (.yaml, .yml) or JSON (.json) file.
+// Fields absent in the file fall back to the built-in selectors.
+func LoadSelectorSet(path string) (SelectorSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SelectorSet{}, fmt.Errorf("can not read selector set file: %w", err)
+	}
+
+	var selectorSet SelectorSet
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &selectorSet)
+	case ".json":
+		err = json.Unmarshal(data, &selectorSet)
+	default:
+		return SelectorSet{}, fmt.Errorf("unsupported selector set file format: %q", path)
+	}
+	if err != nil {
+		return SelectorSet{}, fmt.Errorf("can not decode selector set file %q: %w", path, err)
+	}
+
+	if selectorSet.Version == "" {
+		return SelectorSet{}, fmt.Errorf("the selector set %q has no version", path)
+	}
+	for field := range selectorSet.Fields {
+		if !isSelectorField(field) {
+			return SelectorSet{}, fmt.Errorf("the selector set %q has an unknown field: %q", path, field)
+		}
+	}
+	defaultSet := DefaultSelectorSet()
+	if selectorSet.Fields == nil {
+		selectorSet.Fields = make(map[string][]string)
+	}
+	for field, selectors := range defaultSet.Fields {
+		if len(selectorSet.Fields[field]) == 0 {
+			selectorSet.Fields[field] = selectors
+		}
+	}
+
+	return selectorSet, nil
+}
+
+func isSelectorField(field string) bool {
+	for _, selectorField := range SelectorFields {
+		if field == selectorField {
+			return true
+		}
+	}
+	for _, selectorField := range AuthorSelectorFields {
+		if field == selectorField {
+			return true
+		}
+	}
+
+	return false
+}
+
+// find returns the elements matched by the first matching selector of the field, and the selector itself.
+func (s SelectorSet) find(root *goquery.Selection, field string) (*goquery.Selection, string) {
+	for _, selector := range s.Fields[field] {
+		selection := root.Find(selector)
+		if selection.Length() > 0 {
+			return selection, selector
+		}
+	}
+
+	return root.Find(":not(*)"), ""
+}
+
+// extractRawData fills the raw book data from the page, and returns the selectors matched for every found field.
+func (s SelectorSet) extractRawData(root *goquery.Selection, rawData *scrappedRawData) map[string]string {
+	matched := make(map[string]string)
+	find := func(field string) *goquery.Selection {
+		selection, selector := s.find(root, field)
+		if selector != "" {
+			matched[field] = selector
+		}
+		return selection
+	}
+
+	rawData.titleString = strings.TrimSpace(find(SelectorTitle).First().Text())
+	rawData.subtitleString = strings.TrimSpace(find(SelectorSubtitle).First().Text())
+	if description := find(SelectorDescription).First(); description.Length() > 0 {
+		html, _ := description.Html()
+		rawData.description = strings.TrimSpace(html)
+	}
+
+	find(SelectorCategories).Each(func(_ int, element *goquery.Selection) {
+		text := strings.TrimSpace(element.Text())
+		if skippedCategories[text] {
+			return
+		}
+		rawData.categories = append(rawData.categories, text)
+	})
+
+	find(SelectorAuthors).Each(func(_ int, element *goquery.Selection) {
+		role, _ := element.Attr("role")
+		if role == "button" || element.Text() == "" {
+			return
+		}
+		// The contributor role is either a suffix of the name, or a sibling of the author link,
+		// like: '<span class="contribution">(Author, Editor)</span>', a contributor is added per role
+		name, roles := parser.ParseContributorString(element.Text())
+		if len(roles) == 1 && roles[0] == parser.ContributorRoleAuthor {
+			contribution := element.Closest("span.author").Find("span.contribution").First().Text()
+			if contributionRoles := parser.ParseContributorRoles(contribution); len(contributionRoles) > 0 {
+				roles = contributionRoles
+			}
+		}
+		for _, role := range roles {
+			rawData.contributors = append(rawData.contributors, book.Contributor{Name: name, Role: role})
+		}
+		if href, ok := element.Attr("href"); ok && href != "" {
+			rawData.authorLinks[name] = href
+		}
+	})
+
+	find(SelectorDetails).Each(func(_ int, element *goquery.Selection) {
+		liParts := strings.Split(element.Text(), ":")
+		if len(liParts) < 2 {
+			return
+		}
+		key := strings.TrimSpace(strings.Map(removeNonPrintable, liParts[0]))
+		value := strings.TrimSpace(strings.Map(removeNonPrintable, liParts[1]))
+		rawData.detailsBlock[key] = value
+	})
+
+	find(SelectorCarousel).Each(func(_ int, element *goquery.Selection) {
+		key := strings.TrimSpace(strings.Map(removeNonPrintable, element.Find("div.rpi-attribute-label").Text()))
+		value := strings.TrimSpace(strings.Map(removeNonPrintable, element.Find("div.rpi-attribute-value").Text()))
+		if key != "" {
+			rawData.detailsCarousel[key] = value
+		}
+	})
+
+	rawData.seriesString = strings.TrimSpace(strings.Map(removeNonPrintable, find(SelectorSeries).First().Text()))
+
+	find(SelectorFormats).Each(func(_ int, element *goquery.Selection) {
+		href, ok := element.Attr("href")
+		if !ok || href == "" {
+			return
+		}
+		format := element.Find("span").First()
+		if format.Length() == 0 {
+			format = element
+		}
+		key := strings.TrimSpace(strings.Map(removeNonPrintable, format.Text()))
+		if key != "" {
+			rawData.formatLinks[key] = href
+		}
+	})
+
+	find(SelectorISBNBlock).Each(func(_ int, element *goquery.Selection) {
+		var key, value string
+		for i, node := range element.Children().Nodes {
+			if node.LastChild == nil {
+				continue
+			}
+			if i == 0 {
+				key = strings.Trim(node.LastChild.Data, ":")
+			}
+			value = strings.TrimSpace(node.LastChild.Data)
+		}
+		if key == ISBN10Key {
+			rawData.ISBN10String = value
+		}
+		if key == ISBN13Key {
+			rawData.ISBN13String = value
+		}
+	})
+
+	rawData.coverURL, _ = find(SelectorCoverURL).First().Attr("src")
+
+	return matched
+}
+
+// extractAuthorProfile fills the author bio and photo URL from the author page.
+func (s SelectorSet) extractAuthorProfile(root *goquery.Selection, profile *author.Profile) {
+	bio, _ := s.find(root, SelectorAuthorBio)
+	profile.Bio = strings.TrimSpace(bio.First().Text())
+	photo, _ := s.find(root, SelectorAuthorPhoto)
+	profile.PhotoURL, _ = photo.First().Attr("src")
+}
+
+// FixtureReport is the result of a selector set validation against a single fixture page.
+type FixtureReport struct {
+	Fixture string
+	// Matched maps every extracted field to the selector, which has matched it
+	Matched map[string]string
+	// Missing is the list of fields, not extracted from the fixture page
+	Missing []string
+}
+
+// ValidateSelectorSet runs all fixture pages (*.html) in the folder against the selector set,
+// and reports which fields each page has been extracted with.
+func ValidateSelectorSet(selectors SelectorSet, fixtureDir string) ([]FixtureReport, error) {
+	fixtures, err := filepath.Glob(filepath.Join(fixtureDir, "*.html"))
+	if err != nil {
+		return nil, fmt.Errorf("can not list fixture pages: %w", err)
+	}
+
+	reports := make([]FixtureReport, 0, len(fixtures))
+	for _, fixture := range fixtures {
+		report, err := validateFixture(selectors, fixture)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func validateFixture(selectors SelectorSet, fixture string) (FixtureReport, error) {
+	file, err := os.Open(fixture)
+	if err != nil {
+		return FixtureReport{}, fmt.Errorf("can not open fixture page: %w", err)
+	}
+	defer file.Close()
+
+	document, err := goquery.NewDocumentFromReader(file)
+	if err != nil {
+		return FixtureReport{}, fmt.Errorf("can not parse fixture page %q: %w", fixture, err)
+	}
+
+	rawData := newScrappedRawData()
+	report := FixtureReport{
+		Fixture: filepath.Base(fixture),
+		Matched: selectors.extractRawData(document.Selection, &rawData),
+	}
+	for _, field := range SelectorFields {
+		if _, ok := report.Matched[field]; !ok {
+			report.Missing = append(report.Missing, field)
+		}
+	}
+
+	return report, nil
+}
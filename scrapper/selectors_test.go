@@ -0,0 +1,120 @@
+package scrapper
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const (
+	testSelectorsDir     = "selectors"
+	testSelectorsFileAmz = "selectors/amazon.yaml"
+	testFixturesDir      = "testdata"
+)
+
+func TestLoadSelectorSet(t *testing.T) {
+	t.Log("Given the need to test selector set loading.")
+	selectors, err := LoadSelectorSet(testSelectorsFileAmz)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to load the selector set file: %v", failed, err)
+	}
+	if !reflect.DeepEqual(selectors, DefaultSelectorSet()) {
+		t.Fatalf("\t\t%s\tShould get the built-in selector set from %q: %+v", failed, testSelectorsFileAmz, selectors)
+	}
+	t.Logf("\t\t%s\tShould keep the selector set file in sync with the built-in selectors.", succeed)
+
+	t.Logf("\t\tWhen loading a partial JSON selector set\n")
+	{
+		selectorsFile := writeSelectorSetFile(t, "selectors.json",
+			`{"version": "2", "fields": {"title": ["h1#title", "span[id=productTitle]"]}}`)
+		selectors, err := LoadSelectorSet(selectorsFile)
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to load the selector set file: %v", failed, err)
+		}
+		if selectors.Version != "2" {
+			t.Fatalf("\t\t%s\tShould get the selector set version '2', but got: %q", failed, selectors.Version)
+		}
+		if len(selectors.Fields[SelectorTitle]) != 2 {
+			t.Fatalf("\t\t%s\tShould get 2 title selectors, but got: %v", failed, selectors.Fields[SelectorTitle])
+		}
+		if !reflect.DeepEqual(selectors.Fields[SelectorCoverURL], bookCoverURLSelectors) {
+			t.Fatalf("\t\t%s\tShould get the built-in selectors for the absent fields: %v", failed,
+				selectors.Fields[SelectorCoverURL])
+		}
+		t.Logf("\t\t%s\tShould fall back to the built-in selectors for the absent fields.", succeed)
+	}
+
+	t.Logf("\t\tWhen loading an invalid selector set\n")
+	{
+		invalidFiles := map[string]string{
+			"no_version.yaml":    "fields:\n  title: ['h1']\n",
+			"unknown_field.yaml": "version: '1'\nfields:\n  price: ['span.price']\n",
+			"selectors.txt":      "version: '1'\n",
+		}
+		for name, content := range invalidFiles {
+			if _, err := LoadSelectorSet(writeSelectorSetFile(t, name, content)); err == nil {
+				t.Fatalf("\t\t%s\tShould get an error for the %q selector set file", failed, name)
+			}
+		}
+		t.Logf("\t\t%s\tShould get an error for invalid selector set files.", succeed)
+	}
+}
+
+func TestSelectorSet_Fallback(t *testing.T) {
+	t.Log("Given the need to test selector fallbacks.")
+	selectors := DefaultSelectorSet()
+	selectors.Fields[SelectorTitle] = []string{"h1#missingTitle", bookTitleSelector}
+
+	reports, err := ValidateSelectorSet(selectors, testFixturesDir)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to validate the selector set: %v", failed, err)
+	}
+	report := findFixtureReport(t, reports, filepath.Base(testBookResponse01))
+	if report.Matched[SelectorTitle] != bookTitleSelector {
+		t.Fatalf("\t\t%s\tShould extract the title with the fallback selector, but got: %q", failed,
+			report.Matched[SelectorTitle])
+	}
+	t.Logf("\t\t%s\tShould extract the title with the fallback selector.", succeed)
+}
+
+func TestValidateSelectorSet(t *testing.T) {
+	t.Log("Given the need to test selector set validation against the fixture pages.")
+	reports, err := ValidateSelectorSet(DefaultSelectorSet(), testFixturesDir)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to validate the selector set: %v", failed, err)
+	}
+
+	fullReport := findFixtureReport(t, reports, filepath.Base(testBookResponse01))
+	if len(fullReport.Missing) != 0 || len(fullReport.Matched) != len(SelectorFields) {
+		t.Fatalf("\t\t%s\tShould extract all fields from the full page, missing: %v", failed, fullReport.Missing)
+	}
+	t.Logf("\t\t%s\tShould extract all fields from the full page.", succeed)
+
+	captchaReport := findFixtureReport(t, reports, "captcha.html")
+	if len(captchaReport.Matched) != 0 {
+		t.Fatalf("\t\t%s\tShould extract no fields from the CAPTCHA page, but got: %v", failed,
+			captchaReport.Matched)
+	}
+	t.Logf("\t\t%s\tShould extract no fields from the CAPTCHA page.", succeed)
+}
+
+func findFixtureReport(t *testing.T, reports []FixtureReport, fixture string) FixtureReport {
+	for _, report := range reports {
+		if report.Fixture == fixture {
+			return report
+		}
+	}
+	t.Fatalf("\t\t%s\tShould get a report for the %q fixture page", failed, fixture)
+
+	return FixtureReport{}
+}
+
+func writeSelectorSetFile(t *testing.T, name, content string) string {
+	selectorsFile := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(selectorsFile, []byte(content), 0644); err != nil {
+		t.Fatalf("\t\t%s\tShould be able to write the selector set file: %v", failed, err)
+	}
+
+	return selectorsFile
+}
@@ -0,0 +1,252 @@
+package scrapper
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	snapshotObjectsDir = "objects"
+	snapshotIndexDir   = "index"
+	snapshotExtension  = ".html"
+	snapshotIndexExt   = ".json"
+)
+
+var ErrSnapshotNotFound = errors.New("page snapshot not found")
+
+// SnapshotCache is an on-disk, content-addressed cache of fetched HTML pages.
+// Page bodies are stored once per content hash under the 'objects' folder, and the 'index' folder keeps
+// the snapshot history per storefront host and book ID. Snapshots are never removed by TTL expiration,
+// so a page could be re-parsed later without fetching it again. The cache is safe for concurrent use.
+type SnapshotCache struct {
+	dir    string
+	ttl    time.Duration
+	mu     sync.RWMutex
+	logger *log.Logger
+}
+
+// Snapshot is a reference to a stored page body.
+type Snapshot struct {
+	URL       string    `json:"url"`
+	Hash      string    `json:"hash"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+type snapshotIndex struct {
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+func NewSnapshotCache(dir string, ttl time.Duration, logger *log.Logger) (*SnapshotCache, error) {
+	for _, subDir := range []string{snapshotObjectsDir, snapshotIndexDir} {
+		if err := os.MkdirAll(filepath.Join(dir, subDir), os.ModePerm); err != nil {
+			return nil, fmt.Errorf("can not create snapshot cache folder: %w", err)
+		}
+	}
+
+	return &SnapshotCache{dir: dir, ttl: ttl, logger: logger}, nil
+}
+
+// Latest returns the latest snapshot of the page and its body.
+func (c *SnapshotCache) Latest(pageURL string) (Snapshot, []byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	index, err := c.readIndex(pageURL)
+	if err != nil {
+		return Snapshot{}, nil, err
+	}
+	if len(index.Snapshots) == 0 {
+		return Snapshot{}, nil, ErrSnapshotNotFound
+	}
+
+	snapshot := index.Snapshots[len(index.Snapshots)-1]
+	body, err := os.ReadFile(c.objectPath(snapshot.Hash))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Snapshot{}, nil, ErrSnapshotNotFound
+		}
+		return Snapshot{}, nil, fmt.Errorf("can not read page snapshot: %w", err)
+	}
+
+	return snapshot, body, nil
+}
+
+// Store saves the page body and appends it to the page snapshot history.
+func (c *SnapshotCache) Store(pageURL string, body []byte) (Snapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hashBytes := sha256.Sum256(body)
+	snapshot := Snapshot{URL: pageURL, Hash: hex.EncodeToString(hashBytes[:]), FetchedAt: time.Now().UTC()}
+
+	objectPath := c.objectPath(snapshot.Hash)
+	if _, err := os.Stat(objectPath); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(objectPath), os.ModePerm); err != nil {
+			return Snapshot{}, fmt.Errorf("can not create snapshot folder: %w", err)
+		}
+		if err := os.WriteFile(objectPath, body, 0644); err != nil {
+			return Snapshot{}, fmt.Errorf("can not write page snapshot: %w", err)
+		}
+	}
+
+	index, err := c.readIndex(pageURL)
+	if err != nil && !errors.Is(err, ErrSnapshotNotFound) {
+		return Snapshot{}, err
+	}
+	index.Snapshots = append(index.Snapshots, snapshot)
+	indexBytes, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("can not encode snapshot index: %w", err)
+	}
+	indexPath := c.indexPath(pageURL)
+	if err := os.MkdirAll(filepath.Dir(indexPath), os.ModePerm); err != nil {
+		return Snapshot{}, fmt.Errorf("can not create snapshot index folder: %w", err)
+	}
+	if err := os.WriteFile(indexPath, indexBytes, 0644); err != nil {
+		return Snapshot{}, fmt.Errorf("can not write snapshot index: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// IsFresh checks if the snapshot is younger than the cache TTL.
+func (c *SnapshotCache) IsFresh(snapshot Snapshot) bool {
+	return time.Since(snapshot.FetchedAt) < c.ttl
+}
+
+func (c *SnapshotCache) readIndex(pageURL string) (snapshotIndex, error) {
+	var index snapshotIndex
+	indexBytes, err := os.ReadFile(c.indexPath(pageURL))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, ErrSnapshotNotFound
+		}
+		return index, fmt.Errorf("can not read snapshot index: %w", err)
+	}
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return index, fmt.Errorf("can not decode snapshot index: %w", err)
+	}
+
+	return index, nil
+}
+
+func (c *SnapshotCache) objectPath(hash string) string {
+	return filepath.Join(c.dir, snapshotObjectsDir, hash[:2], hash+snapshotExtension)
+}
+
+// indexPath returns the page index file path, keyed by the storefront host and the book ID (the last URL segment).
+func (c *SnapshotCache) indexPath(pageURL string) string {
+	host, bookID := "local", pageURL
+	if request, err := http.NewRequest(http.MethodGet, pageURL, nil); err == nil {
+		if request.URL.Host != "" {
+			host = request.URL.Host
+		}
+		bookID = path.Base(request.URL.Path)
+	}
+
+	return filepath.Join(c.dir, snapshotIndexDir, sanitizeFileName(host), sanitizeFileName(bookID)+snapshotIndexExt)
+}
+
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// snapshotTransport serves pages from the snapshot cache, and stores successfully fetched pages into it.
+// In the offline mode only the cached pages are served, regardless of their age.
+type snapshotTransport struct {
+	cache     *SnapshotCache
+	transport http.RoundTripper
+	offline   bool
+}
+
+func (t *snapshotTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	pageURL := request.URL.String()
+	snapshot, body, err := t.cache.Latest(pageURL)
+	if err != nil && !errors.Is(err, ErrSnapshotNotFound) {
+		t.cache.logger.Printf("[WARN] - %v", err)
+	}
+	if err == nil && (t.offline || t.cache.IsFresh(snapshot)) {
+		t.cache.logger.Printf("[INFO] - Using page snapshot %q, fetched at: %s", snapshot.Hash, snapshot.FetchedAt)
+		return newSnapshotResponse(request, body), nil
+	}
+	if t.offline {
+		return nil, fmt.Errorf("%w, offline mode: %q", ErrSnapshotNotFound, pageURL)
+	}
+
+	response, err := t.transport.RoundTrip(request)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return response, err
+	}
+	defer closeResponseBody(response.Body, t.cache.logger)
+
+	body, err = readResponseBody(response)
+	if err != nil {
+		return nil, err
+	}
+	// Robot check and error pages are not stored, so they are not replayed later
+	if classifyResponse(response.StatusCode, body) == nil {
+		if _, err := t.cache.Store(pageURL, body); err != nil {
+			t.cache.logger.Printf("[WARN] - %v", err)
+		}
+	}
+
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Length")
+	response.ContentLength = int64(len(body))
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	return response, nil
+}
+
+// readResponseBody reads the response body, and decompresses it if needed, so the snapshot could be re-parsed as is.
+func readResponseBody(response *http.Response) ([]byte, error) {
+	var reader io.Reader = response.Body
+	if strings.Contains(response.Header.Get("Content-Encoding"), "gzip") {
+		gzipReader, err := gzip.NewReader(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("can not decompress page body: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("can not read page body: %w", err)
+	}
+
+	return body, nil
+}
+
+func newSnapshotResponse(request *http.Request, body []byte) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}
+}
@@ -0,0 +1,170 @@
+package scrapper
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSnapshotCache_StoreAndLatest(t *testing.T) {
+	t.Log("Given the need to test page snapshot storing.")
+	cache, err := NewSnapshotCache(t.TempDir(), time.Hour, log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a snapshot cache: %v", failed, err)
+	}
+
+	pageURL := "https://www.amazon.de/dp/" + testBookID01
+	if _, _, err := cache.Latest(pageURL); !errors.Is(err, ErrSnapshotNotFound) {
+		t.Fatalf("\t\t%s\tShould get a %v error for an unknown page: %v", failed, ErrSnapshotNotFound, err)
+	}
+
+	first, err := cache.Store(pageURL, []byte("<html>first</html>"))
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to store a page snapshot: %v", failed, err)
+	}
+	second, err := cache.Store(pageURL, []byte("<html>second</html>"))
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to store a page snapshot: %v", failed, err)
+	}
+	if first.Hash == second.Hash {
+		t.Fatalf("\t\t%s\tShould get different hashes for different page bodies", failed)
+	}
+
+	snapshot, body, err := cache.Latest(pageURL)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get the latest page snapshot: %v", failed, err)
+	}
+	if snapshot.Hash != second.Hash || string(body) != "<html>second</html>" {
+		t.Fatalf("\t\t%s\tShould get the latest page snapshot: %q", failed, body)
+	}
+	if _, _, err := cache.Latest("https://www.amazon.com/dp/" + testBookID01); !errors.Is(err, ErrSnapshotNotFound) {
+		t.Fatalf("\t\t%s\tShould key snapshots by the storefront: %v", failed, err)
+	}
+
+	t.Logf("\t\t%s\tShould be able to store and get page snapshots.", succeed)
+}
+
+func TestAmazonScrapper_GetBookDataSnapshotCache(t *testing.T) {
+	t.Log("Given the need to test book page scrapping with the snapshot cache.")
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requestCount++
+		http.ServeFile(rw, req, testBookResponse01)
+	}))
+	defer server.Close()
+	cacheDir := t.TempDir()
+
+	t.Logf("\t\tWhen checking for a fresh snapshot\n")
+	{
+		cache, err := NewSnapshotCache(cacheDir, time.Hour, log.Default())
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to create a snapshot cache: %v", failed, err)
+		}
+		amazonScrapper, err := NewAmazonScrapper(server.URL+"/", log.Default())
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+		}
+		amazonScrapper.SetMinRequestDelay(0)
+		amazonScrapper.EnableSnapshotCache(cache, false)
+		for i := 0; i < 2; i++ {
+			bookMeta, err := amazonScrapper.GetBookData(context.Background(), testBookID01)
+			if err != nil {
+				t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+			}
+			if bookMeta.Title != testBookTitle {
+				t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+			}
+		}
+		if requestCount != 1 {
+			t.Fatalf("\t\t%s\tShould fetch the page only once: %d", failed, requestCount)
+		}
+		t.Logf("\t\t%s\tShould be able to use a fresh page snapshot.", succeed)
+	}
+
+	t.Logf("\t\tWhen checking for an expired snapshot in the offline mode\n")
+	{
+		server.Close()
+		cache, err := NewSnapshotCache(cacheDir, 0, log.Default())
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to create a snapshot cache: %v", failed, err)
+		}
+		amazonScrapper, err := NewAmazonScrapper(server.URL+"/", log.Default())
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to create a scrapper: %v", failed, err)
+		}
+		amazonScrapper.SetMinRequestDelay(0)
+		amazonScrapper.EnableSnapshotCache(cache, true)
+		bookMeta, err := amazonScrapper.GetBookData(context.Background(), testBookID01)
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to replay the page snapshot: %v", failed, err)
+		}
+		if bookMeta.Title != testBookTitle {
+			t.Fatalf("\t\t%s\tShould get a %q book title: %q", failed, testBookTitle, bookMeta.Title)
+		}
+		if _, err := amazonScrapper.GetBookData(context.Background(), "0000000000"); err == nil {
+			t.Fatalf("\t\t%s\tShould get an error for a missing snapshot in the offline mode", failed)
+		}
+		t.Logf("\t\t%s\tShould be able to replay page snapshots offline.", succeed)
+	}
+}
+
+func TestSnapshotTransport_StoresDecompressedBody(t *testing.T) {
+	t.Log("Given the need to test page snapshot storing for compressed responses.")
+	page, err := os.ReadFile(testBookResponse01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/html")
+		rw.Header().Set("Content-Encoding", "gzip")
+		gzipWriter := gzip.NewWriter(rw)
+		defer gzipWriter.Close()
+		if _, err := gzipWriter.Write(page); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	cache, err := NewSnapshotCache(t.TempDir(), time.Hour, log.Default())
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to create a snapshot cache: %v", failed, err)
+	}
+	pageURL := server.URL + "/" + testBookID01
+	request, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An explicit header disables the transparent decompression of the default transport
+	request.Header.Set("Accept-Encoding", "gzip")
+	transport := &snapshotTransport{cache: cache, transport: http.DefaultTransport}
+	response, err := transport.RoundTrip(request)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get the page: %v", failed, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to read the page: %v", failed, err)
+	}
+	if response.Header.Get("Content-Encoding") != "" || !bytes.Equal(body, page) {
+		t.Fatalf("\t\t%s\tShould get a decompressed page body", failed)
+	}
+
+	_, snapshotBody, err := cache.Latest(pageURL)
+	if err != nil {
+		t.Fatalf("\t\t%s\tShould be able to get the page snapshot: %v", failed, err)
+	}
+	if !bytes.Equal(snapshotBody, page) {
+		t.Fatalf("\t\t%s\tShould store a decompressed page snapshot", failed)
+	}
+
+	t.Logf("\t\t%s\tShould be able to store a decompressed page snapshot.", succeed)
+}
@@ -0,0 +1,396 @@
+package scrapper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+	"github.com/sdreger/lib-file-processor-go/domain/publisher"
+	"github.com/sdreger/lib-file-processor-go/isbn"
+	"github.com/sdreger/lib-file-processor-go/parser"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	StructuredDataSourceName = "structured"
+
+	structuredDataRequestTimeout = 30 * time.Second
+
+	jsonLDSelector    = `script[type="application/ld+json"]`
+	openGraphSelector = `meta[property], meta[name]`
+	jsonLDBookType    = "Book"
+	openGraphBookType = "book"
+)
+
+// structuredDataTimeLayouts are the date time layouts, the dates and the partial dates are parsed
+// by the ParsePartialDateString
+var structuredDataTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05"}
+
+// openGraphPrefixes are the OpenGraph book property prefixes: 'book:isbn' (the OpenGraph book type),
+// and 'books:isbn' (the legacy Facebook 'books.book' type)
+var openGraphPrefixes = []string{"book:", "books:"}
+
+// StructuredDataScrapper gets a book data from any product page (like publisher sites: O'Reilly, Manning, Packt,
+// No Starch), which embeds the schema.org 'Book' JSON-LD data, or the OpenGraph book tags.
+// The book ID is the product page URL.
+type StructuredDataScrapper struct {
+	collector *colly.Collector
+	logger    *log.Logger
+}
+
+func NewStructuredDataScrapper(logger *log.Logger) *StructuredDataScrapper {
+	collector := colly.NewCollector()
+	collector.AllowURLRevisit = true
+	collector.UserAgent = userAgentFirefox
+	collector.SetRequestTimeout(structuredDataRequestTimeout)
+
+	return &StructuredDataScrapper{collector: collector, logger: logger}
+}
+
+// IsProductURL returns true if the book ID is an HTTP(S) product page URL.
+func IsProductURL(bookID string) bool {
+	pageURL, err := url.Parse(bookID)
+	return err == nil && (pageURL.Scheme == "http" || pageURL.Scheme == "https") && pageURL.Host != ""
+}
+
+func (s *StructuredDataScrapper) GetBookData(ctx context.Context, bookID string) (book.ParsedData, error) {
+	if !IsProductURL(bookID) {
+		return book.ParsedData{}, fmt.Errorf("the book ID should be a product page URL: %q", bookID)
+	}
+
+	var document *goquery.Selection
+	var responseErr error
+	collector := s.collector.Clone()
+	initCallbacks(ctx, collector, &responseErr, s.logger)
+	collector.OnHTML("html", func(element *colly.HTMLElement) {
+		document = element.DOM
+	})
+	err := collector.Request(http.MethodGet, bookID, nil, nil, nil)
+	if responseErr != nil {
+		return book.ParsedData{}, responseErr
+	}
+	if err != nil {
+		return book.ParsedData{}, err
+	}
+	if document == nil {
+		return book.ParsedData{}, fmt.Errorf("%w: empty product page", ErrNotFound)
+	}
+
+	return s.parseStructuredData(bookID, document)
+}
+
+func (s *StructuredDataScrapper) Close() error {
+	return nil
+}
+
+// parseStructuredData maps the JSON-LD 'Book' data into the book data. The fields, missing in the JSON-LD data,
+// are taken from the OpenGraph tags.
+func (s *StructuredDataScrapper) parseStructuredData(pageURL string, document *goquery.Selection) (book.ParsedData,
+	error) {
+	jsonLDBook := getJSONLDBook(document, s.logger)
+	openGraph := getOpenGraphProperties(document)
+	if jsonLDBook == nil && openGraph["og:type"] != openGraphBookType {
+		return book.ParsedData{}, fmt.Errorf("%w: there is no structured book data on the page", ErrNotFound)
+	}
+
+	var metadata book.ParsedData
+	var description string
+	// set assigns the first non-empty value, and records its diagnostic
+	set := func(field, selector, raw, rule string, assign func(raw string) bool) {
+		if raw == "" {
+			return
+		}
+		if _, ok := metadata.Diagnostics.GetField(field); ok {
+			return
+		}
+		if assign(raw) {
+			metadata.Diagnostics.AddField(book.FieldDiagnostic{Field: field, Selector: selector, Raw: raw, Rule: rule})
+		}
+	}
+	assignTitle := func(raw string) bool {
+		metadata.Title, metadata.Subtitle = parser.ParseTitleString(raw)
+		return metadata.Title != ""
+	}
+	assignISBN := func(raw string) bool {
+		switch value := isbn.Normalize(raw); len(value) {
+		case 10:
+			metadata.ISBN10 = value
+		case 13:
+			metadata.ISBN13 = parseISBN13(value)
+		}
+		return metadata.ISBN10 != "" || metadata.ISBN13 != 0
+	}
+	assignAuthors := func(raw string) bool {
+		for _, author := range strings.Split(raw, ";") {
+			// The OpenGraph 'book:author' could be an author profile URL
+			if author = strings.TrimSpace(author); author != "" && !IsProductURL(author) {
+				metadata.Contributors = append(metadata.Contributors,
+					book.Contributor{Name: author, Role: parser.ContributorRoleAuthor})
+			}
+		}
+		return len(metadata.Contributors) > 0
+	}
+	assignPubDate := func(raw string) bool {
+		pubDate, precision, err := parseStructuredDataDate(raw)
+		if err != nil {
+			metadata.Diagnostics.AddWarning("%v", err)
+		}
+		metadata.PubDate, metadata.PubDatePrecision = pubDate, precision
+		return !pubDate.IsZero()
+	}
+	assignPages := func(raw string) bool {
+		pages, _ := strconv.Atoi(raw)
+		if pages <= 0 || pages > 0xFFFF {
+			pages = int(parser.ParseLengthString(raw))
+		}
+		metadata.Pages = uint16(pages)
+		return metadata.Pages != 0
+	}
+	assignCoverURL := func(raw string) bool {
+		metadata.CoverURL = resolveURL(pageURL, raw)
+		return true
+	}
+	assignPublisher := func(raw string) bool {
+		metadata.Publisher = publisher.MapPublisherName(raw)
+		return metadata.Publisher != ""
+	}
+	assignLanguage := func(raw string) bool {
+		metadata.Language, _ = parser.NormalizeLanguage(raw)
+		return metadata.Language != ""
+	}
+	assignEdition := func(raw string) bool {
+		edition, err := parser.ParseEdition(raw)
+		if err != nil {
+			metadata.Diagnostics.AddWarning("%v", err)
+		}
+		metadata.Edition = edition
+		return !edition.IsEmpty()
+	}
+	assignDescription := func(raw string) bool {
+		description = raw
+		return true
+	}
+
+	if jsonLDBook != nil {
+		editions := jsonLDObjects(jsonLDBook["workExample"])
+		editionValue := func(key string, nameKeys ...string) (string, string) {
+			if value := jsonLDString(jsonLDBook[key], nameKeys...); value != "" {
+				return value, "json-ld Book." + key
+			}
+			for _, edition := range editions {
+				if value := jsonLDString(edition[key], nameKeys...); value != "" {
+					return value, "json-ld Book.workExample." + key
+				}
+			}
+			return "", ""
+		}
+		jsonLD := func(field, key, rule string, assign func(raw string) bool, nameKeys ...string) {
+			raw, selector := editionValue(key, nameKeys...)
+			set(field, selector, raw, rule, assign)
+		}
+
+		jsonLD(FieldTitle, "name", "ParseTitleString", assignTitle)
+		if metadata.Subtitle == "" {
+			jsonLD(FieldSubtitle, "alternativeHeadline", "", func(raw string) bool {
+				metadata.Subtitle = raw
+				return true
+			})
+		}
+		for _, edition := range append([]map[string]interface{}{jsonLDBook}, editions...) {
+			for _, value := range jsonLDStrings(edition["isbn"]) {
+				assignISBN(value)
+			}
+		}
+		if metadata.ISBN10 != "" {
+			set(FieldISBN10, "json-ld Book.isbn", metadata.ISBN10, "", func(string) bool { return true })
+		}
+		if metadata.ISBN13 != 0 {
+			set(FieldISBN13, "json-ld Book.isbn", isbn.FormatISBN13(metadata.ISBN13), "",
+				func(string) bool { return true })
+		}
+		set(FieldAuthors, "json-ld Book.author", strings.Join(jsonLDStrings(jsonLDBook["author"], "name"), ";"), "",
+			assignAuthors)
+		jsonLD(FieldPubDate, "datePublished", "parseStructuredDataDate", assignPubDate)
+		jsonLD(FieldPages, "numberOfPages", "ParseLengthString", assignPages)
+		jsonLD(FieldCoverURL, "image", "", assignCoverURL, "url", "contentUrl")
+		jsonLD(FieldPublisher, "publisher", "MapPublisherName", assignPublisher, "name")
+		jsonLD(FieldLanguage, "inLanguage", "NormalizeLanguage", assignLanguage, "alternateName", "name")
+		jsonLD(FieldEdition, "bookEdition", "ParseEdition", assignEdition)
+		jsonLD(FieldDescription, "description", "SanitizeDescription", assignDescription)
+	}
+
+	openGraphValue := func(property string) (string, string) {
+		for _, prefix := range openGraphPrefixes {
+			if value := openGraph[prefix+property]; value != "" {
+				return value, prefix + property
+			}
+		}
+		return "", ""
+	}
+	openGraphBook := func(field, property, rule string, assign func(raw string) bool) {
+		raw, selector := openGraphValue(property)
+		set(field, "og "+selector, raw, rule, assign)
+	}
+	set(FieldTitle, "og og:title", openGraph["og:title"], "ParseTitleString", assignTitle)
+	if metadata.ISBN10 == "" && metadata.ISBN13 == 0 {
+		raw, selector := openGraphValue("isbn")
+		if assignISBN(raw) {
+			field := FieldISBN13
+			if metadata.ISBN10 != "" {
+				field = FieldISBN10
+			}
+			set(field, "og "+selector, raw, "", func(string) bool { return true })
+		}
+	}
+	openGraphBook(FieldAuthors, "author", "", assignAuthors)
+	openGraphBook(FieldPubDate, "release_date", "parseStructuredDataDate", assignPubDate)
+	openGraphBook(FieldPages, "page_count", "ParseLengthString", assignPages)
+	set(FieldCoverURL, "og og:image", openGraph["og:image"], "", assignCoverURL)
+	set(FieldDescription, "og og:description", openGraph["og:description"], "SanitizeDescription",
+		assignDescription)
+
+	metadata.SetDescription(description)
+	if canonicalURL := openGraph["og:url"]; canonicalURL != "" {
+		pageURL = resolveURL(pageURL, canonicalURL)
+	}
+	metadata.PublisherURL = pageURL
+	metadata.Diagnostics.AddField(book.FieldDiagnostic{Field: FieldPublisherURL, Selector: "page URL", Raw: pageURL})
+	metadata.CoverFileName = fmt.Sprint(metadata.GetPrimaryId(), getCoverExtension(metadata.CoverURL))
+	metadata.BookFileName = metadata.GetBookFileName()
+	source := StructuredDataSourceName
+	if parsedURL, err := url.Parse(pageURL); err == nil {
+		source = parsedURL.Host
+	}
+	completeDiagnostics(&metadata, source, nil)
+
+	return metadata, nil
+}
+
+// getJSONLDBook returns the first schema.org 'Book' object of the page JSON-LD scripts, or nil if there is none.
+func getJSONLDBook(document *goquery.Selection, logger *log.Logger) map[string]interface{} {
+	var jsonLDBook map[string]interface{}
+	document.Find(jsonLDSelector).EachWithBreak(func(_ int, script *goquery.Selection) bool {
+		var data interface{}
+		if err := json.Unmarshal([]byte(script.Text()), &data); err != nil {
+			logger.Printf("[WARN] - Can not decode the JSON-LD script: %v", err)
+			return true
+		}
+		jsonLDBook = findJSONLDBook(data)
+		return jsonLDBook == nil
+	})
+
+	return jsonLDBook
+}
+
+// findJSONLDBook searches for the 'Book' object in the JSON-LD data: a single object, an array of objects,
+// an '@graph' list, or a 'mainEntity' of a web page.
+func findJSONLDBook(data interface{}) map[string]interface{} {
+	for _, object := range jsonLDObjects(data) {
+		for _, objectType := range jsonLDStrings(object["@type"]) {
+			if objectType == jsonLDBookType {
+				return object
+			}
+		}
+		for _, key := range []string{"@graph", "mainEntity"} {
+			if found := findJSONLDBook(object[key]); found != nil {
+				return found
+			}
+		}
+	}
+
+	return nil
+}
+
+// jsonLDObjects returns the JSON-LD value as a list of objects: a single object, or an array of objects.
+func jsonLDObjects(value interface{}) []map[string]interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{typedValue}
+	case []interface{}:
+		objects := make([]map[string]interface{}, 0, len(typedValue))
+		for _, item := range typedValue {
+			objects = append(objects, jsonLDObjects(item)...)
+		}
+		return objects
+	}
+
+	return nil
+}
+
+// jsonLDStrings returns the JSON-LD value as a list of strings. The value could be a string, a number, an object
+// (the first non-empty of the name keys is taken, like: 'name'), or an array of them.
+func jsonLDStrings(value interface{}, nameKeys ...string) []string {
+	values := make([]string, 0)
+	switch typedValue := value.(type) {
+	case string:
+		if trimmed := strings.TrimSpace(typedValue); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	case float64:
+		values = append(values, strconv.FormatFloat(typedValue, 'f', -1, 64))
+	case map[string]interface{}:
+		for _, key := range nameKeys {
+			if nameValues := jsonLDStrings(typedValue[key]); len(nameValues) > 0 {
+				return append(values, nameValues[0])
+			}
+		}
+	case []interface{}:
+		for _, item := range typedValue {
+			values = append(values, jsonLDStrings(item, nameKeys...)...)
+		}
+	}
+
+	return values
+}
+
+// jsonLDString returns the first string of the JSON-LD value, or an empty string.
+func jsonLDString(value interface{}, nameKeys ...string) string {
+	if values := jsonLDStrings(value, nameKeys...); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// getOpenGraphProperties returns the page meta tag values by their property (or name), like: 'og:title'.
+// The first tag wins, except for the authors, which are joined.
+func getOpenGraphProperties(document *goquery.Selection) map[string]string {
+	properties := make(map[string]string)
+	document.Find(openGraphSelector).Each(func(_ int, meta *goquery.Selection) {
+		property := meta.AttrOr("property", meta.AttrOr("name", ""))
+		content := strings.TrimSpace(meta.AttrOr("content", ""))
+		if property == "" || content == "" {
+			return
+		}
+		if strings.HasSuffix(property, ":author") && properties[property] != "" {
+			properties[property] += ";" + content
+			return
+		}
+		if properties[property] == "" {
+			properties[property] = content
+		}
+	})
+
+	return properties
+}
+
+func parseStructuredDataDate(dateString string) (time.Time, parser.DatePrecision, error) {
+	for _, layout := range structuredDataTimeLayouts {
+		if date, err := time.Parse(layout, dateString); err == nil {
+			return date, parser.DatePrecisionDay, nil
+		}
+	}
+	if date, precision, err := parser.ParsePartialDateString(dateString, parser.LanguageEnglish); err == nil {
+		return date, precision, nil
+	}
+
+	return time.Time{}, parser.DatePrecisionDay,
+		fmt.Errorf("the structured data date string %q can not be parsed", dateString)
+}
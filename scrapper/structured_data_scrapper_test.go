@@ -0,0 +1,124 @@
+package scrapper
+
+import (
+	"context"
+	"errors"
+	"log"
+	"reflect"
+	"testing"
+)
+
+const (
+	testStructuredJSONLDResponse    = "testdata/structured_jsonld.html"
+	testStructuredOpenGraphResponse = "testdata/structured_opengraph.html"
+	testStructuredNoneResponse      = "testdata/structured_none.html"
+)
+
+func TestStructuredDataScrapper_GetBookData(t *testing.T) {
+	t.Log("Given the need to test the schema.org and OpenGraph book data fetching.")
+	server := testStorefrontMockServer(t, map[string]string{
+		"/books/jsonld":    testStructuredJSONLDResponse,
+		"/books/opengraph": testStructuredOpenGraphResponse,
+		"/blog/post":       testStructuredNoneResponse,
+	}, nil)
+	defer server.Close()
+	structuredDataScrapper := NewStructuredDataScrapper(log.Default())
+
+	t.Logf("\t\tWhen the page has the JSON-LD book data\n")
+	{
+		bookMeta, err := structuredDataScrapper.GetBookData(context.Background(), server.URL+"/books/jsonld")
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+		}
+		if bookMeta.Title != testBookTitle || bookMeta.Subtitle != testBookSubtitle {
+			t.Fatalf("\t\t%s\tShould get a %q book title from the 'Book' node: %q, %q", failed, testBookTitle,
+				bookMeta.Title, bookMeta.Subtitle)
+		}
+		if bookMeta.ISBN10 != testBookISBN10 || bookMeta.ISBN13 != testBookISBN13 {
+			t.Fatalf("\t\t%s\tShould get the book ISBNs from the editions: %q, %d", failed, bookMeta.ISBN10,
+				bookMeta.ISBN13)
+		}
+		if !reflect.DeepEqual(bookMeta.GetAuthorNames(), []string{testBookAuthorName01, testBookAuthorName02}) {
+			t.Fatalf("\t\t%s\tShould get the book authors: %v", failed, bookMeta.GetAuthorNames())
+		}
+		if bookMeta.Pages != testBookPages {
+			t.Fatalf("\t\t%s\tShould get a %d book pages: %d", failed, testBookPages, bookMeta.Pages)
+		}
+		if bookMeta.Publisher != testBookPublisher {
+			t.Fatalf("\t\t%s\tShould get a %q book publisher: %q", failed, testBookPublisher, bookMeta.Publisher)
+		}
+		if bookMeta.Language != testBookLanguage {
+			t.Fatalf("\t\t%s\tShould get a %q book language: %q", failed, testBookLanguage, bookMeta.Language)
+		}
+		if bookMeta.Edition.Number != testBookEdition {
+			t.Fatalf("\t\t%s\tShould get a %d book edition: %d", failed, testBookEdition, bookMeta.Edition.Number)
+		}
+		if !bookMeta.PubDate.Equal(testBookPubDate) {
+			t.Fatalf("\t\t%s\tShould get a %v book publish date: %v", failed, testBookPubDate, bookMeta.PubDate)
+		}
+		if bookMeta.Description != testBookDescription {
+			t.Fatalf("\t\t%s\tShould get a %q book description: %q", failed, testBookDescription,
+				bookMeta.Description)
+		}
+		if bookMeta.CoverURL != server.URL+"/images/cover.jpg" || bookMeta.CoverFileName != testBookISBN10+".jpg" {
+			t.Fatalf("\t\t%s\tShould get the absolute book cover URL: %q, %q", failed, bookMeta.CoverURL,
+				bookMeta.CoverFileName)
+		}
+		if bookMeta.PublisherURL != server.URL+"/books/test-title" {
+			t.Fatalf("\t\t%s\tShould get the canonical publisher URL: %q", failed, bookMeta.PublisherURL)
+		}
+		if diagnostic, ok := bookMeta.Diagnostics.GetField(FieldISBN13); !ok ||
+			diagnostic.Selector != "json-ld Book.isbn" {
+			t.Fatalf("\t\t%s\tShould get the ISBN13 provenance: %+v", failed, diagnostic)
+		}
+		if diagnostic, ok := bookMeta.Diagnostics.GetField(FieldPages); !ok ||
+			diagnostic.Selector != "json-ld Book.workExample.numberOfPages" {
+			t.Fatalf("\t\t%s\tShould get the pages provenance: %+v", failed, diagnostic)
+		}
+		if diagnostic, ok := bookMeta.Diagnostics.GetField(FieldLanguage); !ok ||
+			diagnostic.Rule != "NormalizeLanguage" {
+			t.Fatalf("\t\t%s\tShould get the language provenance: %+v", failed, diagnostic)
+		}
+		t.Logf("\t\t%s\tShould be able to get the JSON-LD book data", succeed)
+	}
+
+	t.Logf("\t\tWhen the page has the OpenGraph book tags only\n")
+	{
+		bookMeta, err := structuredDataScrapper.GetBookData(context.Background(), server.URL+"/books/opengraph")
+		if err != nil {
+			t.Fatalf("\t\t%s\tShould be able to get book data: %v", failed, err)
+		}
+		if bookMeta.Title != testBookTitle || bookMeta.Subtitle != testBookSubtitle {
+			t.Fatalf("\t\t%s\tShould get a %q book title: %q, %q", failed, testBookTitle, bookMeta.Title,
+				bookMeta.Subtitle)
+		}
+		if bookMeta.ISBN13 != testBookISBN13 {
+			t.Fatalf("\t\t%s\tShould get a %d book ISBN13: %d", failed, testBookISBN13, bookMeta.ISBN13)
+		}
+		if !reflect.DeepEqual(bookMeta.GetAuthorNames(), []string{testBookAuthorName01}) {
+			t.Fatalf("\t\t%s\tShould skip the author profile URLs: %v", failed, bookMeta.GetAuthorNames())
+		}
+		if !bookMeta.PubDate.Equal(testBookPubDate) {
+			t.Fatalf("\t\t%s\tShould get a %v book publish date: %v", failed, testBookPubDate, bookMeta.PubDate)
+		}
+		if bookMeta.CoverURL != "https://covers.test/cover.png" || bookMeta.CoverFileName != "9781234567890.png" {
+			t.Fatalf("\t\t%s\tShould get the book cover: %q, %q", failed, bookMeta.CoverURL, bookMeta.CoverFileName)
+		}
+		if diagnostic, ok := bookMeta.Diagnostics.GetField(FieldAuthors); !ok || diagnostic.Selector != "og book:author" {
+			t.Fatalf("\t\t%s\tShould get the authors provenance: %+v", failed, diagnostic)
+		}
+		t.Logf("\t\t%s\tShould be able to get the OpenGraph book data", succeed)
+	}
+
+	t.Logf("\t\tWhen the page has no structured book data\n")
+	{
+		if _, err := structuredDataScrapper.GetBookData(context.Background(), server.URL+"/blog/post"); !errors.Is(err,
+			ErrNotFound) {
+			t.Fatalf("\t\t%s\tShould get the %v error: %v", failed, ErrNotFound, err)
+		}
+		if _, err := structuredDataScrapper.GetBookData(context.Background(), testBookID01); err == nil {
+			t.Fatalf("\t\t%s\tShould get an error for a non-URL book ID", failed)
+		}
+		t.Logf("\t\t%s\tShould not be able to get the book data without the structured data", succeed)
+	}
+}
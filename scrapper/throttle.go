@@ -0,0 +1,82 @@
+package scrapper
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxRetries      = 3
+	defaultRetryDelay      = 2 * time.Second
+	defaultMaxRetryDelay   = time.Minute
+	defaultMinRequestDelay = time.Second
+)
+
+// throttledTransport enforces a minimum delay between consecutive outgoing requests. Every request reserves its own
+// time slot, so the waiting requests do not block each other, and a cancelled request stops waiting immediately.
+// The wrapped transport could be replaced at any time, so it is read under the lock.
+type throttledTransport struct {
+	transport   http.RoundTripper
+	minDelay    time.Duration
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+func (t *throttledTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	wait, transport := t.reserve()
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	if transport == nil {
+		return nil, fmt.Errorf("there is no transport to send the request")
+	}
+
+	return transport.RoundTrip(request)
+}
+
+// reserve reserves the next request time slot, and returns the delay before it, with the current wrapped transport.
+func (t *throttledTransport) reserve() (time.Duration, http.RoundTripper) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	next := t.lastRequest.Add(t.minDelay)
+	if next.Before(now) {
+		next = now
+	}
+	t.lastRequest = next
+
+	return next.Sub(now), t.transport
+}
+
+// retryPolicy defines the number of retries for blocked and rate limited requests,
+// and the exponential backoff delay between them.
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// delay returns the backoff delay before the retry attempt (starting from 0), with a random jitter
+// in the [delay/2, delay] range, so parallel clients do not retry at the same moment.
+func (p retryPolicy) delay(attempt int) time.Duration {
+	delay := p.baseDelay << attempt
+	if delay > p.maxDelay || delay <= 0 {
+		delay = p.maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
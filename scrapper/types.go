@@ -1,9 +1,28 @@
 package scrapper
 
-import "github.com/sdreger/lib-file-processor-go/domain/book"
+import (
+	"context"
+	"github.com/sdreger/lib-file-processor-go/domain/book"
+)
 
+// BookDataScrapper gets a book data by its ID. Implementations should be safe for concurrent use,
+// and should stop processing when the context is cancelled.
+//
 //go:generate mockgen -destination=./book_data_scrapper_mock.go -package=scrapper github.com/sdreger/lib-file-processor-go/scrapper BookDataScrapper
 type BookDataScrapper interface {
-	GetBookData(bookID string) (book.ParsedData, error)
+	GetBookData(ctx context.Context, bookID string) (book.ParsedData, error)
 	Close() error
 }
+
+// BookSearcher finds books by a free text (like: a title and an author name), and returns candidates
+// with book IDs, which could be passed to the BookDataScrapper.
+type BookSearcher interface {
+	SearchBooks(ctx context.Context, query string, limit int) ([]SearchCandidate, error)
+}
+
+// BookPageParser parses a book product page file, saved in a browser.
+//
+//go:generate mockgen -destination=./book_page_parser_mock.go -package=scrapper github.com/sdreger/lib-file-processor-go/scrapper BookPageParser
+type BookPageParser interface {
+	ParseBookPageFile(path string) (book.ParsedData, error)
+}